@@ -7,6 +7,7 @@ type Browser interface {
 	Close()
 	Context() BrowserContext
 	IsConnected() bool
+	NewBrowserCDPSession() (CDPSession, error)
 	NewContext(opts goja.Value) (BrowserContext, error)
 	NewPage(opts goja.Value) (Page, error)
 	On(string) (bool, error)
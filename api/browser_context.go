@@ -12,14 +12,17 @@ type BrowserContext interface {
 	ClearCookies()
 	ClearPermissions()
 	Close()
-	Cookies() ([]any, error) // TODO: make it []Cookie later on
-	ExposeBinding(name string, callback goja.Callable, opts goja.Value)
+	Cookies(urls goja.Value) ([]*Cookie, error)
+	ExposeBinding(name string, callback BindingCallback, opts goja.Value)
 	ExposeFunction(name string, callback goja.Callable)
 	GrantPermissions(permissions []string, opts goja.Value)
-	NewCDPSession() CDPSession
+	NewCDPSession(pageOrFrame any) (CDPSession, error)
 	NewPage() (Page, error)
 	Pages() []Page
-	Route(url goja.Value, handler goja.Callable)
+	// Route registers a handler for the requests of all the pages in the
+	// browser context matching the url, which can be a glob pattern, a RegExp
+	// or a predicate function. The key identifies the handler for Unroute.
+	Route(url, key goja.Value, handler func(Route) error)
 	SetDefaultNavigationTimeout(timeout int64)
 	SetDefaultTimeout(timeout int64)
 	SetExtraHTTPHeaders(headers map[string]string) error
@@ -32,7 +35,9 @@ type BrowserContext interface {
 	// - https://github.com/microsoft/playwright/pull/2763
 	SetHTTPCredentials(httpCredentials goja.Value)
 	SetOffline(offline bool)
-	StorageState(opts goja.Value)
-	Unroute(url goja.Value, handler goja.Callable)
+	StorageState(opts goja.Value) (*StorageState, error)
+	// Unroute removes the route handlers registered for the url, or only
+	// the one registered with the key if it's given.
+	Unroute(url, key goja.Value)
 	WaitForEvent(event string, optsOrPredicate goja.Value) any
 }
@@ -4,6 +4,7 @@ import "github.com/dop251/goja"
 
 // CDPSession is the interface of a raw CDP session.
 type CDPSession interface {
-	Detach()
-	Send(method string, params goja.Value) goja.Value
+	Detach() error
+	On(event string, handler func(any) error) error
+	Send(method string, params goja.Value) *goja.Promise
 }
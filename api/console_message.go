@@ -0,0 +1,17 @@
+package api
+
+// ConsoleMessage is the interface of a message that a page logs
+// with the console API.
+type ConsoleMessage interface {
+	// Args returns the arguments of the console call as JS handles.
+	Args() []JSHandle
+	// Location returns the location in the code of the page that the
+	// message is logged at.
+	Location() ConsoleMessageLocation
+	// Page returns the page that logged the message.
+	Page() Page
+	// Text returns the text of the message.
+	Text() string
+	// Type returns the type of the console call, such as log or error.
+	Type() string
+}
@@ -0,0 +1,13 @@
+package api
+
+// Dialog is the interface of a JavaScript dialog, such as alert, confirm,
+// prompt or beforeunload. The dialogs that are opened by synchronous calls,
+// such as Page.Evaluate, can't be handled, as the calls block the event
+// loop that the dialog handlers run on.
+type Dialog interface {
+	Accept(promptText string)
+	DefaultValue() string
+	Dismiss()
+	Message() string
+	Type() string
+}
@@ -0,0 +1,23 @@
+package api
+
+// Download is the interface of a file downloaded by a page.
+type Download interface {
+	// Delete waits for the download to finish and deletes the file.
+	Delete() error
+	// Failure waits for the download to finish and returns the reason
+	// of its failure, or an empty string if it succeeded.
+	Failure() (string, error)
+	// Page returns the page that started the download.
+	Page() Page
+	// Path waits for the download to finish and returns the path of
+	// the file. It returns an error if the download failed.
+	Path() (string, error)
+	// SaveAs waits for the download to finish and copies the file
+	// to the path.
+	SaveAs(path string) error
+	// SuggestedFilename returns the name of the file that the browser
+	// suggests for the download.
+	SuggestedFilename() string
+	// URL returns the URL of the download.
+	URL() string
+}
@@ -0,0 +1,16 @@
+package api
+
+import "github.com/dop251/goja"
+
+// FileChooser is the interface of a file chooser that a page opens to
+// select the files of an input[type=file] element.
+type FileChooser interface {
+	// Element returns the input element that the file chooser is opened for.
+	Element() ElementHandle
+	// IsMultiple returns true if the file chooser accepts multiple files.
+	IsMultiple() bool
+	// Page returns the page that opened the file chooser.
+	Page() Page
+	// SetFiles sets the files of the input element.
+	SetFiles(files goja.Value, opts goja.Value)
+}
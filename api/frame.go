@@ -4,20 +4,27 @@ import "github.com/dop251/goja"
 
 // Frame is the interface of a CDP target frame.
 type Frame interface {
-	AddScriptTag(opts goja.Value)
-	AddStyleTag(opts goja.Value)
+	AddScriptTag(opts goja.Value) (ElementHandle, error)
+	AddStyleTag(opts goja.Value) (ElementHandle, error)
 	Check(selector string, opts goja.Value)
 	ChildFrames() []Frame
 	Click(selector string, opts goja.Value) error
 	Content() string
 	Dblclick(selector string, opts goja.Value)
 	DispatchEvent(selector string, typ string, eventInit goja.Value, opts goja.Value)
+	DragAndDrop(source string, target string, opts goja.Value)
 	Evaluate(pageFunc goja.Value, args ...goja.Value) any
 	EvaluateHandle(pageFunc goja.Value, args ...goja.Value) (JSHandle, error)
 	Fill(selector string, value string, opts goja.Value)
 	Focus(selector string, opts goja.Value)
 	FrameElement() (ElementHandle, error)
 	GetAttribute(selector string, name string, opts goja.Value) goja.Value
+	GetByAltText(text goja.Value, opts goja.Value) Locator
+	GetByLabel(text goja.Value, opts goja.Value) Locator
+	GetByPlaceholder(text goja.Value, opts goja.Value) Locator
+	GetByRole(role string, opts goja.Value) Locator
+	GetByTestID(testID goja.Value) Locator
+	GetByTitle(text goja.Value, opts goja.Value) Locator
 	Goto(url string, opts goja.Value) (Response, error)
 	Hover(selector string, opts goja.Value)
 	InnerHTML(selector string, opts goja.Value) string
@@ -10,14 +10,23 @@ import "github.com/dop251/goja"
 
 // Locator represents a way to find element(s) on a page at any moment.
 type Locator interface {
+	// All returns a locator for each element matching the locator's selector.
+	All() []Locator
 	// Click on an element using locator's selector with strict mode on.
 	Click(opts goja.Value) error
+	// Count returns the number of elements matching the locator's selector.
+	Count() int
 	// Dblclick double clicks on an element using locator's selector with strict mode on.
 	Dblclick(opts goja.Value)
 	// Check element using locator's selector with strict mode on.
 	Check(opts goja.Value)
 	// Uncheck element using locator's selector with strict mode on.
 	Uncheck(opts goja.Value)
+	// Filter returns a new locator that narrows down the elements of
+	// this locator to the ones that contain a text or a locator.
+	Filter(opts goja.Value) Locator
+	// First returns a locator for the first element of this locator.
+	First() Locator
 	// IsChecked returns true if the element matches the locator's
 	// selector and is checked. Otherwise, returns false.
 	IsChecked(opts goja.Value) bool
@@ -36,12 +45,37 @@ type Locator interface {
 	// IsHidden returns true if the element matches the locator's
 	// selector and is hidden. Otherwise, returns false.
 	IsHidden(opts goja.Value) bool
+	// Last returns a locator for the last element of this locator.
+	Last() Locator
+	// Locator returns a new locator that finds the elements matching
+	// the selector within the elements of this locator.
+	Locator(selector string, opts goja.Value) Locator
+	// Nth returns a locator for the n-th element of this locator.
+	Nth(index int) Locator
 	// Fill out the element using locator's selector with strict mode on.
 	Fill(value string, opts goja.Value)
 	// Focus on the element using locator's selector with strict mode on.
 	Focus(opts goja.Value)
 	// GetAttribute of the element using locator's selector with strict mode on.
 	GetAttribute(name string, opts goja.Value) goja.Value
+	// GetByAltText returns a new locator for the elements with the
+	// given alt text within the elements of this locator.
+	GetByAltText(text goja.Value, opts goja.Value) Locator
+	// GetByLabel returns a new locator for the elements that are labelled
+	// with the given text within the elements of this locator.
+	GetByLabel(text goja.Value, opts goja.Value) Locator
+	// GetByPlaceholder returns a new locator for the input elements with
+	// the given placeholder text within the elements of this locator.
+	GetByPlaceholder(text goja.Value, opts goja.Value) Locator
+	// GetByRole returns a new locator for the elements with the given
+	// ARIA role within the elements of this locator.
+	GetByRole(role string, opts goja.Value) Locator
+	// GetByTestID returns a new locator for the elements with the given
+	// test ID within the elements of this locator.
+	GetByTestID(testID goja.Value) Locator
+	// GetByTitle returns a new locator for the elements with the given
+	// title within the elements of this locator.
+	GetByTitle(text goja.Value, opts goja.Value) Locator
 	// InnerHTML returns the element's inner HTML that matches
 	// the locator's selector with strict mode on.
 	InnerHTML(opts goja.Value) string
@@ -58,6 +92,9 @@ type Locator interface {
 	// the locator's selector (with strict mode on), selects the
 	// options, and returns the filtered options.
 	SelectOption(values goja.Value, opts goja.Value) []string
+	// SetInputFiles sets the files of the input[type=file] element that
+	// matches the locator's selector with strict mode on.
+	SetInputFiles(files goja.Value, opts goja.Value)
 	// Press the given key on the element found that matches the locator's
 	// selector with strict mode on.
 	Press(key string, opts goja.Value)
@@ -72,6 +109,14 @@ type Locator interface {
 	// DispatchEvent dispatches an event for the element matching the
 	// locator's selector with strict mode on.
 	DispatchEvent(typ string, eventInit, opts goja.Value)
+	// ScrollIntoViewIfNeeded scrolls the element matching the locator's
+	// selector into view with the mouse wheel with strict mode on, unless
+	// it's already visible.
+	ScrollIntoViewIfNeeded(opts goja.Value)
+	// DragTo drags the element matching the locator's selector and drops
+	// it onto the element matching the target locator's selector, both
+	// with strict mode on.
+	DragTo(target Locator, opts goja.Value)
 	// WaitFor waits for the element matching the locator's selector
 	// with strict mode on.
 	WaitFor(opts goja.Value)
@@ -5,8 +5,8 @@ import "github.com/dop251/goja"
 // Page is the interface of a single browser tab.
 type Page interface {
 	AddInitScript(script goja.Value, arg goja.Value)
-	AddScriptTag(opts goja.Value)
-	AddStyleTag(opts goja.Value)
+	AddScriptTag(opts goja.Value) (ElementHandle, error)
+	AddStyleTag(opts goja.Value) (ElementHandle, error)
 	BringToFront()
 	Check(selector string, opts goja.Value)
 	Click(selector string, opts goja.Value) error
@@ -20,13 +20,19 @@ type Page interface {
 	EmulateVisionDeficiency(typ string)
 	Evaluate(pageFunc goja.Value, arg ...goja.Value) any
 	EvaluateHandle(pageFunc goja.Value, arg ...goja.Value) (JSHandle, error)
-	ExposeBinding(name string, callback goja.Callable, opts goja.Value)
+	ExposeBinding(name string, callback BindingCallback, opts goja.Value)
 	ExposeFunction(name string, callback goja.Callable)
 	Fill(selector string, value string, opts goja.Value)
 	Focus(selector string, opts goja.Value)
-	Frame(frameSelector goja.Value) Frame
+	Frame(frameSelector goja.Value) (Frame, error)
 	Frames() []Frame
 	GetAttribute(selector string, name string, opts goja.Value) goja.Value
+	GetByAltText(text goja.Value, opts goja.Value) Locator
+	GetByLabel(text goja.Value, opts goja.Value) Locator
+	GetByPlaceholder(text goja.Value, opts goja.Value) Locator
+	GetByRole(role string, opts goja.Value) Locator
+	GetByTestID(testID goja.Value) Locator
+	GetByTitle(text goja.Value, opts goja.Value) Locator
 	GetKeyboard() Keyboard
 	GetMouse() Mouse
 	GetTouchscreen() Touchscreen
@@ -47,14 +53,26 @@ type Page interface {
 	// Locator creates and returns a new locator for this page (main frame).
 	Locator(selector string, opts goja.Value) Locator
 	MainFrame() Frame
+	// Off removes the handler registered for the event with the key.
+	Off(event string, key goja.Value)
+	// On registers a handler that is called on the VU event loop
+	// each time the page emits the event. The dialog event handlers
+	// must either accept or dismiss the dialog, and a synchronous call
+	// that opens a dialog hangs, as it blocks the event loop that the
+	// handlers run on. The key identifies the handler for Off.
+	On(event string, key goja.Value, handler func(any) error) error
 	Opener() Page
 	Pause()
-	Pdf(opts goja.Value) []byte
+	// Pdf prints the page to PDF and returns the PDF data.
+	Pdf(opts goja.Value) goja.ArrayBuffer
 	Press(selector string, key string, opts goja.Value)
 	Query(selector string) (ElementHandle, error)
 	QueryAll(selector string) ([]ElementHandle, error)
 	Reload(opts goja.Value) Response
-	Route(url goja.Value, handler goja.Callable)
+	// Route registers a handler for the requests matching the url, which can
+	// be a glob pattern, a RegExp or a predicate function. The key identifies
+	// the handler for Unroute.
+	Route(url, key goja.Value, handler func(Route) error)
 	Screenshot(opts goja.Value) goja.ArrayBuffer
 	SelectOption(selector string, values goja.Value, opts goja.Value) []string
 	SetContent(html string, opts goja.Value)
@@ -68,16 +86,25 @@ type Page interface {
 	Title() string
 	Type(selector string, text string, opts goja.Value)
 	Uncheck(selector string, opts goja.Value)
-	Unroute(url goja.Value, handler goja.Callable)
+	// Unroute removes the route handlers registered for the url, or only
+	// the one registered with the key if it's given.
+	Unroute(url, key goja.Value)
 	URL() string
 	Video() Video
 	ViewportSize() map[string]float64
-	WaitForEvent(event string, optsOrPredicate goja.Value) any
+	// WaitForEvent returns a function that waits for the page to emit the
+	// event, and returns the event data. If a predicate is given, it waits
+	// for the event data that the predicate returns true for.
+	WaitForEvent(event string, optsOrPredicate goja.Value) (func() (any, error), error)
 	WaitForFunction(fn, opts goja.Value, args ...goja.Value) (any, error)
 	WaitForLoadState(state string, opts goja.Value)
 	WaitForNavigation(opts goja.Value) (Response, error)
-	WaitForRequest(urlOrPredicate, opts goja.Value) Request
-	WaitForResponse(urlOrPredicate, opts goja.Value) Response
+	// WaitForRequest returns a function that waits for a request whose URL
+	// matches the glob pattern, RegExp or predicate.
+	WaitForRequest(urlOrPredicate, opts goja.Value) (func() (Request, error), error)
+	// WaitForResponse returns a function that waits for a response whose URL
+	// matches the glob pattern, RegExp or predicate.
+	WaitForResponse(urlOrPredicate, opts goja.Value) (func() (Response, error), error)
 	WaitForSelector(selector string, opts goja.Value) (ElementHandle, error)
 	WaitForTimeout(timeout int64)
 	Workers() []Worker
@@ -1,6 +1,14 @@
 package api
 
+import "github.com/dop251/goja"
+
 // Touchscreen is the interface of a touchscreen.
 type Touchscreen interface {
+	LongPress(x float64, y float64, opts goja.Value)
+	Pinch(x float64, y float64, opts goja.Value)
+	Swipe(x float64, y float64, opts goja.Value)
 	Tap(x float64, y float64)
+	TouchEnd()
+	TouchMove(points goja.Value)
+	TouchStart(points goja.Value)
 }
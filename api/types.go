@@ -1,5 +1,7 @@
 package api
 
+import "github.com/dop251/goja"
+
 // HTTPHeader is a single HTTP header.
 type HTTPHeader struct {
 	Name  string `json:"name"`
@@ -23,3 +25,75 @@ type Rect struct {
 	Width  float64 `js:"width"`
 	Height float64 `js:"height"`
 }
+
+// Cookie represents a browser cookie.
+type Cookie struct {
+	Name   string `js:"name" json:"name"`
+	Value  string `js:"value" json:"value"`
+	Domain string `js:"domain" json:"domain"`
+	Path   string `js:"path" json:"path"`
+	// Expires is the expiration date of the cookie in seconds since
+	// the UNIX epoch. It's -1 for session cookies.
+	Expires  float64 `js:"expires" json:"expires"`
+	HTTPOnly bool    `js:"httpOnly" json:"httpOnly"`
+	Secure   bool    `js:"secure" json:"secure"`
+	// SameSite is one of "Strict", "Lax" or "None".
+	SameSite string `js:"sameSite" json:"sameSite"`
+}
+
+// NameValue is a name and value pair, such as a local storage item.
+type NameValue struct {
+	Name  string `js:"name" json:"name"`
+	Value string `js:"value" json:"value"`
+}
+
+// OriginStorage is the local storage of an origin.
+type OriginStorage struct {
+	Origin       string       `js:"origin" json:"origin"`
+	LocalStorage []*NameValue `js:"localStorage" json:"localStorage"`
+}
+
+// StorageState is the storage state of a browser context, i.e. its
+// cookies and the local storage of the origins it visited.
+type StorageState struct {
+	Cookies []*Cookie        `js:"cookies" json:"cookies"`
+	Origins []*OriginStorage `js:"origins" json:"origins"`
+}
+
+// BindingSource is the source of a call to an exposed binding.
+type BindingSource struct {
+	BrowserContext BrowserContext
+	Page           Page
+	Frame          Frame
+}
+
+// BindingCallback is the callback of an exposed binding. It's called with
+// the source of the call and the arguments that the page passed, which are
+// either JSON values or a single JSHandle. Its result, or the value of the
+// promise it returns, is sent back to the page.
+type BindingCallback func(source *BindingSource, args ...any) (goja.Value, error)
+
+// WebSocketFrame is a frame that a WebSocket sends or receives.
+type WebSocketFrame struct {
+	// Payload is the text of a text frame, or the data of a binary frame.
+	Payload []byte
+	// Binary is true if the frame is a binary frame.
+	Binary bool
+}
+
+// ConsoleMessageLocation is the location in the code of a page
+// that a console message is logged at.
+type ConsoleMessageLocation struct {
+	URL          string `js:"url"`
+	LineNumber   int64  `js:"lineNumber"`
+	ColumnNumber int64  `js:"columnNumber"`
+}
+
+// PageError is an error that is thrown in a page and isn't caught.
+type PageError struct {
+	Name    string
+	Message string
+	// Stack is the stack trace of the error, if the thrown value is
+	// an Error object.
+	Stack string
+}
@@ -2,5 +2,10 @@ package api
 
 // Video is the interface of a recorded video.
 type Video interface {
+	// Path returns the path of the video file. The file is complete
+	// once the page, its browser context or the browser is closed.
 	Path() string
+	// SaveAs waits for the recording to finish and copies the video
+	// to the path.
+	SaveAs(path string) error
 }
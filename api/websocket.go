@@ -0,0 +1,12 @@
+package api
+
+// WebSocket is the interface of a WebSocket that a page opens.
+type WebSocket interface {
+	// IsClosed returns true if the WebSocket is closed.
+	IsClosed() bool
+	// On registers a handler that is called each time the WebSocket
+	// emits the event.
+	On(event string, handler func(any) error) error
+	// URL returns the URL of the WebSocket.
+	URL() string
+}
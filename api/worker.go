@@ -6,5 +6,6 @@ import "github.com/dop251/goja"
 type Worker interface {
 	Evaluate(pageFunc goja.Value, args ...goja.Value) any
 	EvaluateHandle(pageFunc goja.Value, args ...goja.Value) (JSHandle, error)
+	On(event string, handler func(any) error) error
 	URL() string
 }
@@ -47,38 +47,242 @@ func mapBrowserToGoja(vu moduleVU) *goja.Object {
 	return obj
 }
 
+// locatorSymbol keys the locator on the JS object of a mapped locator,
+// so that the locator can be passed back to the API, e.g. as the has
+// option of a filter.
+var locatorSymbol = goja.NewSymbol("locator") //nolint:gochecknoglobals
+
+// mapLocatorObject maps the locator to a JS object.
+func mapLocatorObject(vu moduleVU, lo api.Locator) *goja.Object {
+	rt := vu.Runtime()
+	obj := rt.ToValue(mapLocator(vu, lo)).ToObject(rt)
+	if err := obj.SetSymbol(locatorSymbol, rt.ToValue(lo)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping locator: %w", err))
+	}
+
+	return obj
+}
+
+// exportLocator returns the locator of a mapped locator.
+func exportLocator(vu moduleVU, v goja.Value) api.Locator {
+	if obj, ok := v.(*goja.Object); ok {
+		if v := obj.GetSymbol(locatorSymbol); v != nil {
+			if lo, ok := v.Export().(api.Locator); ok {
+				return lo
+			}
+		}
+	}
+	k6common.Throw(vu.Runtime(), errors.New("target must be a locator"))
+
+	return nil
+}
+
+// exportLocatorOptions returns a copy of the locator options where the
+// mapped locator of the has option is replaced by the locator itself.
+func exportLocatorOptions(vu moduleVU, opts goja.Value) goja.Value {
+	rt := vu.Runtime()
+	if opts == nil || goja.IsUndefined(opts) || goja.IsNull(opts) {
+		return opts
+	}
+	src := opts.ToObject(rt)
+	dst := rt.NewObject()
+	for _, k := range src.Keys() {
+		v := src.Get(k)
+		if obj, ok := v.(*goja.Object); ok && k == "has" {
+			if lo := obj.GetSymbol(locatorSymbol); lo != nil {
+				v = lo
+			}
+		}
+		if err := dst.Set(k, v); err != nil {
+			k6common.Throw(rt, fmt.Errorf("exporting locator options: %w", err))
+		}
+	}
+
+	return dst
+}
+
+// jsHandleSymbol keys the JS handle on the JS object of a mapped JS handle,
+// so that the handle can be passed back to the API as an argument.
+var jsHandleSymbol = goja.NewSymbol("jsHandle") //nolint:gochecknoglobals
+
+// mapJSHandleObject maps the JS handle to a JS object.
+func mapJSHandleObject(vu moduleVU, jsh api.JSHandle) *goja.Object {
+	rt := vu.Runtime()
+	obj := rt.ToValue(mapJSHandle(vu, jsh)).ToObject(rt)
+	if err := obj.SetSymbol(jsHandleSymbol, rt.ToValue(jsh)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping JS handle: %w", err))
+	}
+
+	return obj
+}
+
+// exportEvaluateArgs returns the arguments of an evaluation where the
+// mapped JS handles are replaced by the JS handles themselves.
+func exportEvaluateArgs(args []goja.Value) []goja.Value {
+	exported := make([]goja.Value, 0, len(args))
+	for _, a := range args {
+		if obj, ok := a.(*goja.Object); ok {
+			if jsh := obj.GetSymbol(jsHandleSymbol); jsh != nil {
+				a = jsh
+			}
+		}
+		exported = append(exported, a)
+	}
+
+	return exported
+}
+
+// handlerFunc returns the handler as a function, as the handler value
+// itself identifies it to remove it later.
+func handlerFunc(handler goja.Value) (goja.Callable, error) {
+	fn, ok := goja.AssertFunction(handler)
+	if !ok {
+		return nil, errors.New("handler must be a function")
+	}
+	return fn, nil
+}
+
+// pageSymbol and frameSymbol key the page and the frame on the JS objects
+// of the mapped pages and frames, so that they can be passed back to the
+// API, e.g. to create a CDP session.
+var (
+	pageSymbol  = goja.NewSymbol("page")  //nolint:gochecknoglobals
+	frameSymbol = goja.NewSymbol("frame") //nolint:gochecknoglobals
+)
+
+// mapPageObject maps the page to a JS object.
+func mapPageObject(vu moduleVU, p api.Page) *goja.Object {
+	rt := vu.Runtime()
+	obj := rt.ToValue(mapPage(vu, p)).ToObject(rt)
+	if err := obj.SetSymbol(pageSymbol, rt.ToValue(p)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping page: %w", err))
+	}
+
+	return obj
+}
+
+// mapFrameObject maps the frame to a JS object.
+func mapFrameObject(vu moduleVU, f api.Frame) *goja.Object {
+	rt := vu.Runtime()
+	obj := rt.ToValue(mapFrame(vu, f)).ToObject(rt)
+	if err := obj.SetSymbol(frameSymbol, rt.ToValue(f)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping frame: %w", err))
+	}
+
+	return obj
+}
+
+// exportPageOrFrame returns the page or the frame of a mapped page or frame.
+func exportPageOrFrame(vu moduleVU, v goja.Value) any {
+	if obj, ok := v.(*goja.Object); ok {
+		for _, sym := range []*goja.Symbol{pageSymbol, frameSymbol} {
+			if v := obj.GetSymbol(sym); v != nil {
+				return v.Export()
+			}
+		}
+	}
+	k6common.Throw(vu.Runtime(), errors.New("target must be a page or a frame"))
+
+	return nil
+}
+
+// mapCDPSession to the JS module.
+func mapCDPSession(vu moduleVU, s api.CDPSession) mapping {
+	rt := vu.Runtime()
+	return mapping{
+		"detach": s.Detach,
+		"on": func(event string, handler goja.Callable) error {
+			return s.On(event, func(params any) error { //nolint:wrapcheck
+				_, err := handler(goja.Undefined(), rt.ToValue(params))
+				return err //nolint:wrapcheck
+			})
+		},
+		"send": s.Send,
+	}
+}
+
 // mapLocator API to the JS module.
 func mapLocator(vu moduleVU, lo api.Locator) mapping {
+	rt := vu.Runtime()
 	return mapping{
+		"all": func() *goja.Object {
+			var (
+				all  = lo.All()
+				objs = make([]any, len(all))
+			)
+			for i, l := range all {
+				objs[i] = mapLocatorObject(vu, l)
+			}
+			return rt.NewArray(objs...)
+		},
 		"click": func(opts goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				err := lo.Click(opts)
 				return nil, err //nolint:wrapcheck
 			})
 		},
-		"dblclick":      lo.Dblclick,
-		"check":         lo.Check,
-		"uncheck":       lo.Uncheck,
-		"isChecked":     lo.IsChecked,
-		"isEditable":    lo.IsEditable,
-		"isEnabled":     lo.IsEnabled,
-		"isDisabled":    lo.IsDisabled,
-		"isVisible":     lo.IsVisible,
-		"isHidden":      lo.IsHidden,
-		"fill":          lo.Fill,
-		"focus":         lo.Focus,
-		"getAttribute":  lo.GetAttribute,
+		"count":    lo.Count,
+		"dblclick": lo.Dblclick,
+		"check":    lo.Check,
+		"uncheck":  lo.Uncheck,
+		"filter": func(opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.Filter(exportLocatorOptions(vu, opts)))
+		},
+		"first": func() *goja.Object {
+			return mapLocatorObject(vu, lo.First())
+		},
+		"last": func() *goja.Object {
+			return mapLocatorObject(vu, lo.Last())
+		},
+		"locator": func(selector string, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.Locator(selector, exportLocatorOptions(vu, opts)))
+		},
+		"nth": func(index int) *goja.Object {
+			return mapLocatorObject(vu, lo.Nth(index))
+		},
+		"isChecked":    lo.IsChecked,
+		"isEditable":   lo.IsEditable,
+		"isEnabled":    lo.IsEnabled,
+		"isDisabled":   lo.IsDisabled,
+		"isVisible":    lo.IsVisible,
+		"isHidden":     lo.IsHidden,
+		"fill":         lo.Fill,
+		"focus":        lo.Focus,
+		"getAttribute": lo.GetAttribute,
+		"getByAltText": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByAltText(text, opts))
+		},
+		"getByLabel": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByLabel(text, opts))
+		},
+		"getByPlaceholder": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByPlaceholder(text, opts))
+		},
+		"getByRole": func(role string, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByRole(role, opts))
+		},
+		"getByTestId": func(testID goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByTestID(testID))
+		},
+		"getByTitle": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, lo.GetByTitle(text, opts))
+		},
 		"innerHTML":     lo.InnerHTML,
 		"innerText":     lo.InnerText,
 		"textContent":   lo.TextContent,
 		"inputValue":    lo.InputValue,
 		"selectOption":  lo.SelectOption,
+		"setInputFiles": lo.SetInputFiles,
 		"press":         lo.Press,
 		"type":          lo.Type,
 		"hover":         lo.Hover,
 		"tap":           lo.Tap,
 		"dispatchEvent": lo.DispatchEvent,
-		"waitFor":       lo.WaitFor,
+		"dragTo": func(target goja.Value, opts goja.Value) {
+			lo.DragTo(exportLocator(vu, target), opts)
+		},
+		"scrollIntoViewIfNeeded": lo.ScrollIntoViewIfNeeded,
+		"waitFor":                lo.WaitFor,
 	}
 }
 
@@ -89,8 +293,7 @@ func mapRequest(vu moduleVU, r api.Request) mapping {
 		"allHeaders": r.AllHeaders,
 		"failure":    r.Failure,
 		"frame": func() *goja.Object {
-			mf := mapFrame(vu, r.Frame())
-			return rt.ToValue(mf).ToObject(rt)
+			return mapFrameObject(vu, r.Frame())
 		},
 		"headerValue":         r.HeaderValue,
 		"headers":             r.Headers,
@@ -132,8 +335,7 @@ func mapResponse(vu moduleVU, r api.Response) mapping {
 		"body":       r.Body,
 		"finished":   r.Finished,
 		"frame": func() *goja.Object {
-			mf := mapFrame(vu, r.Frame())
-			return rt.ToValue(mf).ToObject(rt)
+			return mapFrameObject(vu, r.Frame())
 		},
 		"headerValue":  r.HeaderValue,
 		"headerValues": r.HeaderValues,
@@ -156,6 +358,158 @@ func mapResponse(vu moduleVU, r api.Response) mapping {
 	return maps
 }
 
+// mapRoute to the JS module.
+func mapRoute(vu moduleVU, r api.Route) mapping {
+	rt := vu.Runtime()
+	return mapping{
+		"abort":    r.Abort,
+		"continue": r.Continue,
+		"fulfill":  r.Fulfill,
+		"request": func() *goja.Object {
+			mr := mapRequest(vu, r.Request())
+			return rt.ToValue(mr).ToObject(rt)
+		},
+	}
+}
+
+// mapDialog to the JS module.
+func mapDialog(_ moduleVU, d api.Dialog) mapping {
+	return mapping{
+		"accept":       d.Accept,
+		"defaultValue": d.DefaultValue,
+		"dismiss":      d.Dismiss,
+		"message":      d.Message,
+		"type":         d.Type,
+	}
+}
+
+// mapBindingCallback returns a binding callback that calls the JS callback
+// with the mapped source of the call and the arguments.
+func mapBindingCallback(vu moduleVU, callback goja.Callable) api.BindingCallback {
+	return func(source *api.BindingSource, args ...any) (goja.Value, error) {
+		rt := vu.Runtime()
+		src := mapping{
+			"context": rt.ToValue(mapBrowserContext(vu, source.BrowserContext)).ToObject(rt),
+			"page":    mapPageObject(vu, source.Page),
+			"frame":   mapFrameObject(vu, source.Frame),
+		}
+		jsArgs := []goja.Value{rt.ToValue(src)}
+		for _, a := range args {
+			switch a := a.(type) {
+			case api.ElementHandle:
+				jsArgs = append(jsArgs, rt.ToValue(mapElementHandle(vu, a)))
+			case api.JSHandle:
+				jsArgs = append(jsArgs, rt.ToValue(mapJSHandle(vu, a)))
+			default:
+				jsArgs = append(jsArgs, rt.ToValue(a))
+			}
+		}
+
+		return callback(goja.Undefined(), jsArgs...)
+	}
+}
+
+// mapVideo to the JS module.
+func mapVideo(vu moduleVU, v api.Video) mapping {
+	return mapping{
+		"path": v.Path,
+		"saveAs": func(path string) *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, v.SaveAs(path) //nolint:wrapcheck
+			})
+		},
+	}
+}
+
+// mapConsoleMessage to the JS module.
+func mapConsoleMessage(vu moduleVU, m api.ConsoleMessage) mapping {
+	return mapping{
+		"args": func() []*goja.Object {
+			var margs []*goja.Object
+			for _, a := range m.Args() {
+				margs = append(margs, mapJSHandleObject(vu, a))
+			}
+			return margs
+		},
+		"location": m.Location,
+		"page": func() *goja.Object {
+			return mapPageObject(vu, m.Page())
+		},
+		"text": m.Text,
+		"type": m.Type,
+	}
+}
+
+// mapPageError to the JS module as an Error object.
+func mapPageError(vu moduleVU, e *api.PageError) *goja.Object {
+	rt := vu.Runtime()
+	obj, err := rt.New(rt.Get("Error"), rt.ToValue(e.Message))
+	if err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping page error: %w", err))
+	}
+	if e.Name != "" {
+		if err := obj.Set("name", e.Name); err != nil {
+			k6common.Throw(rt, fmt.Errorf("mapping page error: %w", err))
+		}
+	}
+	if e.Stack != "" {
+		if err := obj.Set("stack", e.Stack); err != nil {
+			k6common.Throw(rt, fmt.Errorf("mapping page error: %w", err))
+		}
+	}
+
+	return obj
+}
+
+// mapDownload to the JS module.
+func mapDownload(vu moduleVU, d api.Download) mapping {
+	return mapping{
+		"delete": func() *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, d.Delete() //nolint:wrapcheck
+			})
+		},
+		"failure": func() *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				failure, err := d.Failure()
+				if err != nil || failure == "" {
+					return nil, err //nolint:wrapcheck
+				}
+				return failure, nil
+			})
+		},
+		"page": func() *goja.Object {
+			return mapPageObject(vu, d.Page())
+		},
+		"path": func() *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return d.Path() //nolint:wrapcheck
+			})
+		},
+		"saveAs": func(path string) *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				return nil, d.SaveAs(path) //nolint:wrapcheck
+			})
+		},
+		"suggestedFilename": d.SuggestedFilename,
+		"url":               d.URL,
+	}
+}
+
+// mapFileChooser to the JS module.
+func mapFileChooser(vu moduleVU, fc api.FileChooser) mapping {
+	return mapping{
+		"element": func() mapping {
+			return mapElementHandle(vu, fc.Element())
+		},
+		"isMultiple": fc.IsMultiple,
+		"page": func() *goja.Object {
+			return mapPageObject(vu, fc.Page())
+		},
+		"setFiles": fc.SetFiles,
+	}
+}
+
 // mapJSHandle to the JS module.
 func mapJSHandle(vu moduleVU, jsh api.JSHandle) mapping {
 	rt := vu.Runtime()
@@ -164,14 +518,16 @@ func mapJSHandle(vu moduleVU, jsh api.JSHandle) mapping {
 			m := mapElementHandle(vu, jsh.AsElement())
 			return rt.ToValue(m).ToObject(rt)
 		},
-		"dispose":  jsh.Dispose,
-		"evaluate": jsh.Evaluate,
-		"evaluateHandle": func(pageFunc goja.Value, args ...goja.Value) (mapping, error) {
-			h, err := jsh.EvaluateHandle(pageFunc, args...)
+		"dispose": jsh.Dispose,
+		"evaluate": func(pageFunc goja.Value, args ...goja.Value) any {
+			return jsh.Evaluate(pageFunc, exportEvaluateArgs(args)...)
+		},
+		"evaluateHandle": func(pageFunc goja.Value, args ...goja.Value) (*goja.Object, error) {
+			h, err := jsh.EvaluateHandle(pageFunc, exportEvaluateArgs(args)...)
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
-			return mapJSHandle(vu, h), nil
+			return mapJSHandleObject(vu, h), nil
 		},
 		"getProperties": func() (mapping, error) {
 			props, err := jsh.GetProperties()
@@ -209,12 +565,12 @@ func mapElementHandle(vu moduleVU, eh api.ElementHandle) mapping {
 				return nil, err //nolint:wrapcheck
 			})
 		},
-		"contentFrame": func() (mapping, error) {
+		"contentFrame": func() (*goja.Object, error) {
 			f, err := eh.ContentFrame()
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
-			return mapFrame(vu, f), nil
+			return mapFrameObject(vu, f), nil
 		},
 		"dblclick":      eh.Dblclick,
 		"dispatchEvent": eh.DispatchEvent,
@@ -231,12 +587,12 @@ func mapElementHandle(vu moduleVU, eh api.ElementHandle) mapping {
 		"isEnabled":     eh.IsEnabled,
 		"isHidden":      eh.IsHidden,
 		"isVisible":     eh.IsVisible,
-		"ownerFrame": func() (mapping, error) {
+		"ownerFrame": func() (*goja.Object, error) {
 			f, err := eh.OwnerFrame()
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
-			return mapFrame(vu, f), nil
+			return mapFrameObject(vu, f), nil
 		},
 		"press":                  eh.Press,
 		"screenshot":             eh.Screenshot,
@@ -292,16 +648,28 @@ func mapElementHandle(vu moduleVU, eh api.ElementHandle) mapping {
 func mapFrame(vu moduleVU, f api.Frame) mapping {
 	rt := vu.Runtime()
 	maps := mapping{
-		"addScriptTag": f.AddScriptTag,
-		"addStyleTag":  f.AddStyleTag,
-		"check":        f.Check,
+		"addScriptTag": func(opts goja.Value) (mapping, error) {
+			eh, err := f.AddScriptTag(opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapElementHandle(vu, eh), nil
+		},
+		"addStyleTag": func(opts goja.Value) (mapping, error) {
+			eh, err := f.AddStyleTag(opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapElementHandle(vu, eh), nil
+		},
+		"check": f.Check,
 		"childFrames": func() *goja.Object {
 			var (
-				mcfs []mapping
+				mcfs []*goja.Object
 				cfs  = f.ChildFrames()
 			)
 			for _, fr := range cfs {
-				mcfs = append(mcfs, mapFrame(vu, fr))
+				mcfs = append(mcfs, mapFrameObject(vu, fr))
 			}
 			return rt.ToValue(mcfs).ToObject(rt)
 		},
@@ -314,6 +682,7 @@ func mapFrame(vu moduleVU, f api.Frame) mapping {
 		"content":       f.Content,
 		"dblclick":      f.Dblclick,
 		"dispatchEvent": f.DispatchEvent,
+		"dragAndDrop":   f.DragAndDrop,
 		"evaluate":      f.Evaluate,
 		"evaluateHandle": func(pageFunction goja.Value, args ...goja.Value) (mapping, error) {
 			jsh, err := f.EvaluateHandle(pageFunction, args...)
@@ -332,6 +701,24 @@ func mapFrame(vu moduleVU, f api.Frame) mapping {
 			return mapElementHandle(vu, fe), nil
 		},
 		"getAttribute": f.GetAttribute,
+		"getByAltText": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByAltText(text, opts))
+		},
+		"getByLabel": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByLabel(text, opts))
+		},
+		"getByPlaceholder": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByPlaceholder(text, opts))
+		},
+		"getByRole": func(role string, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByRole(role, opts))
+		},
+		"getByTestId": func(testID goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByTestID(testID))
+		},
+		"getByTitle": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, f.GetByTitle(text, opts))
+		},
 		"goto": func(url string, opts goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				resp, err := f.Goto(url, opts)
@@ -354,17 +741,14 @@ func mapFrame(vu moduleVU, f api.Frame) mapping {
 		"isHidden":   f.IsHidden,
 		"isVisible":  f.IsVisible,
 		"locator": func(selector string, opts goja.Value) *goja.Object {
-			ml := mapLocator(vu, f.Locator(selector, opts))
-			return rt.ToValue(ml).ToObject(rt)
+			return mapLocatorObject(vu, f.Locator(selector, exportLocatorOptions(vu, opts)))
 		},
 		"name": f.Name,
 		"page": func() *goja.Object {
-			mp := mapPage(vu, f.Page())
-			return rt.ToValue(mp).ToObject(rt)
+			return mapPageObject(vu, f.Page())
 		},
 		"parentFrame": func() *goja.Object {
-			mf := mapFrame(vu, f.ParentFrame())
-			return rt.ToValue(mf).ToObject(rt)
+			return mapFrameObject(vu, f.ParentFrame())
 		},
 		"press":         f.Press,
 		"selectOption":  f.SelectOption,
@@ -424,6 +808,78 @@ func mapFrame(vu moduleVU, f api.Frame) mapping {
 	return maps
 }
 
+// mapPageEvent maps the data of a page event to the JS module.
+func mapPageEvent(vu moduleVU, data any) any {
+	switch d := data.(type) {
+	case api.ConsoleMessage:
+		return mapConsoleMessage(vu, d)
+	case api.Dialog:
+		return mapDialog(vu, d)
+	case api.Download:
+		return mapDownload(vu, d)
+	case api.FileChooser:
+		return mapFileChooser(vu, d)
+	case api.Frame:
+		return mapFrameObject(vu, d)
+	case api.Page:
+		return mapPageObject(vu, d)
+	case *api.PageError:
+		return mapPageError(vu, d)
+	case api.Request:
+		return mapRequest(vu, d)
+	case api.Response:
+		return mapResponse(vu, d)
+	// WebSocket comes after Page, which has its methods too.
+	case api.WebSocket:
+		return mapWebSocket(vu, d)
+	case *api.WebSocketFrame:
+		return mapWebSocketFrame(vu, d)
+	case api.Worker:
+		return mapWorker(vu, d)
+	default:
+		return d
+	}
+}
+
+// mapWaitForEventPredicate returns the waitForEvent options, or the
+// predicate given instead of them, with the predicate wrapped to be called
+// with the mapped event data, as the event handlers are.
+func mapWaitForEventPredicate(vu moduleVU, optsOrPredicate goja.Value) goja.Value {
+	rt := vu.Runtime()
+	wrap := func(predicate goja.Value) goja.Value {
+		fn, ok := goja.AssertFunction(predicate)
+		if !ok {
+			return predicate // the options report the invalid predicate.
+		}
+		return rt.ToValue(func(data goja.Value) (goja.Value, error) {
+			return fn(goja.Undefined(), rt.ToValue(mapPageEvent(vu, data.Export())))
+		})
+	}
+
+	if optsOrPredicate == nil || goja.IsUndefined(optsOrPredicate) || goja.IsNull(optsOrPredicate) {
+		return optsOrPredicate
+	}
+	if _, ok := goja.AssertFunction(optsOrPredicate); ok {
+		return wrap(optsOrPredicate)
+	}
+	opts := optsOrPredicate.ToObject(rt)
+	predicate := opts.Get("predicate")
+	if predicate == nil {
+		return optsOrPredicate
+	}
+	wrapped := rt.NewObject()
+	for _, k := range opts.Keys() {
+		if err := wrapped.Set(k, opts.Get(k)); err != nil {
+			k6common.Throw(rt, fmt.Errorf("mapping waitForEvent options: %w", err))
+		}
+	}
+	if err := wrapped.Set("predicate", wrap(predicate)); err != nil {
+		k6common.Throw(rt, fmt.Errorf("mapping waitForEvent options: %w", err))
+	}
+
+	return wrapped
+}
+
 // mapPage to the JS module.
 //
 //nolint:funlen
@@ -431,10 +887,22 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 	rt := vu.Runtime()
 	maps := mapping{
 		"addInitScript": p.AddInitScript,
-		"addScriptTag":  p.AddScriptTag,
-		"addStyleTag":   p.AddStyleTag,
-		"bringToFront":  p.BringToFront,
-		"check":         p.Check,
+		"addScriptTag": func(opts goja.Value) (mapping, error) {
+			eh, err := p.AddScriptTag(opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapElementHandle(vu, eh), nil
+		},
+		"addStyleTag": func(opts goja.Value) (mapping, error) {
+			eh, err := p.AddStyleTag(opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapElementHandle(vu, eh), nil
+		},
+		"bringToFront": p.BringToFront,
+		"check":        p.Check,
 		"click": func(selector string, opts goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				err := p.Click(selector, opts)
@@ -457,29 +925,63 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 			}
 			return mapJSHandle(vu, jsh), nil
 		},
-		"exposeBinding":  p.ExposeBinding,
+		"exposeBinding": func(name string, callback goja.Callable, opts goja.Value) {
+			p.ExposeBinding(name, mapBindingCallback(vu, callback), opts)
+		},
 		"exposeFunction": p.ExposeFunction,
 		"fill":           p.Fill,
 		"focus":          p.Focus,
-		"frame":          p.Frame,
+		"frame": func(frameSelector goja.Value) (*goja.Object, error) {
+			f, err := p.Frame(frameSelector)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			if f == nil {
+				return nil, nil
+			}
+			return mapFrameObject(vu, f), nil
+		},
 		"frames": func() *goja.Object {
 			var (
-				mfrs []mapping
+				mfrs []*goja.Object
 				frs  = p.Frames()
 			)
 			for _, fr := range frs {
-				mfrs = append(mfrs, mapFrame(vu, fr))
+				mfrs = append(mfrs, mapFrameObject(vu, fr))
 			}
 			return rt.ToValue(mfrs).ToObject(rt)
 		},
 		"getAttribute": p.GetAttribute,
+		"getByAltText": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByAltText(text, opts))
+		},
+		"getByLabel": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByLabel(text, opts))
+		},
+		"getByPlaceholder": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByPlaceholder(text, opts))
+		},
+		"getByRole": func(role string, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByRole(role, opts))
+		},
+		"getByTestId": func(testID goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByTestID(testID))
+		},
+		"getByTitle": func(text, opts goja.Value) *goja.Object {
+			return mapLocatorObject(vu, p.GetByTitle(text, opts))
+		},
 		"goBack": func(opts goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				resp := p.GoBack(opts)
 				return mapResponse(vu, resp), nil
 			})
 		},
-		"goForward": p.GoForward,
+		"goForward": func(opts goja.Value) *goja.Promise {
+			return k6ext.Promise(vu.Context(), func() (any, error) {
+				resp := p.GoForward(opts)
+				return mapResponse(vu, resp), nil
+			})
+		},
 		"goto": func(url string, opts goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (any, error) {
 				resp, err := p.Goto(url, opts)
@@ -503,14 +1005,23 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 		"isVisible":  p.IsVisible,
 		"keyboard":   rt.ToValue(p.GetKeyboard()).ToObject(rt),
 		"locator": func(selector string, opts goja.Value) *goja.Object {
-			ml := mapLocator(vu, p.Locator(selector, opts))
-			return rt.ToValue(ml).ToObject(rt)
+			return mapLocatorObject(vu, p.Locator(selector, exportLocatorOptions(vu, opts)))
 		},
 		"mainFrame": func() *goja.Object {
-			mf := mapFrame(vu, p.MainFrame())
-			return rt.ToValue(mf).ToObject(rt)
+			return mapFrameObject(vu, p.MainFrame())
+		},
+		"mouse": rt.ToValue(p.GetMouse()).ToObject(rt),
+		"off":   p.Off,
+		"on": func(event string, handler goja.Value) error {
+			fn, err := handlerFunc(handler)
+			if err != nil {
+				return err
+			}
+			return p.On(event, handler, func(data any) error { //nolint:wrapcheck
+				_, err := fn(goja.Undefined(), rt.ToValue(mapPageEvent(vu, data)))
+				return err //nolint:wrapcheck
+			})
 		},
-		"mouse":  rt.ToValue(p.GetMouse()).ToObject(rt),
 		"opener": p.Opener,
 		"pause":  p.Pause,
 		"pdf":    p.Pdf,
@@ -519,7 +1030,17 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 			r := mapResponse(vu, p.Reload(opts))
 			return rt.ToValue(r).ToObject(rt)
 		},
-		"route":                       p.Route,
+		"route": func(url, handler goja.Value) error {
+			fn, err := handlerFunc(handler)
+			if err != nil {
+				return err
+			}
+			p.Route(url, handler, func(r api.Route) error {
+				_, err := fn(goja.Undefined(), rt.ToValue(mapRoute(vu, r)))
+				return err //nolint:wrapcheck
+			})
+			return nil
+		},
 		"screenshot":                  p.Screenshot,
 		"selectOption":                p.SelectOption,
 		"setContent":                  p.SetContent,
@@ -536,9 +1057,26 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 		"uncheck":                     p.Uncheck,
 		"unroute":                     p.Unroute,
 		"url":                         p.URL,
-		"video":                       p.Video,
-		"viewportSize":                p.ViewportSize,
-		"waitForEvent":                p.WaitForEvent,
+		"video": func() any {
+			v := p.Video()
+			if v == nil {
+				return nil
+			}
+			return mapVideo(vu, v)
+		},
+		"viewportSize": p.ViewportSize,
+		"waitForEvent": func(event string, optsOrPredicate goja.Value) (*goja.Promise, error) {
+			// the options are read here, as the promise runs outside the event loop.
+			wait, err := p.WaitForEvent(event, mapWaitForEventPredicate(vu, optsOrPredicate))
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return k6ext.MappedPromise(vu.Context(), func() (result any, reason error) {
+				return wait()
+			}, func(data any) any {
+				return mapPageEvent(vu, data)
+			}), nil
+		},
 		"waitForFunction": func(pageFunc, opts goja.Value, args ...goja.Value) *goja.Promise {
 			return k6ext.Promise(vu.Context(), func() (result any, reason error) {
 				return p.WaitForFunction(pageFunc, opts, args...) //nolint:wrapcheck
@@ -554,8 +1092,34 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 				return mapResponse(vu, resp), nil
 			})
 		},
-		"waitForRequest":  p.WaitForRequest,
-		"waitForResponse": p.WaitForResponse,
+		"waitForRequest": func(urlOrPredicate, opts goja.Value) (*goja.Promise, error) {
+			// the URL matcher is read here, as the promise runs outside the event loop.
+			wait, err := p.WaitForRequest(urlOrPredicate, opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return k6ext.Promise(vu.Context(), func() (result any, reason error) {
+				req, err := wait()
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return mapRequest(vu, req), nil
+			}), nil
+		},
+		"waitForResponse": func(urlOrPredicate, opts goja.Value) (*goja.Promise, error) {
+			// the URL matcher is read here, as the promise runs outside the event loop.
+			wait, err := p.WaitForResponse(urlOrPredicate, opts)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return k6ext.Promise(vu.Context(), func() (result any, reason error) {
+				resp, err := wait()
+				if err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return mapResponse(vu, resp), nil
+			}), nil
+		},
 		"waitForSelector": func(selector string, opts goja.Value) (mapping, error) {
 			eh, err := p.WaitForSelector(selector, opts)
 			if err != nil {
@@ -599,20 +1163,53 @@ func mapPage(vu moduleVU, p api.Page) mapping {
 
 // mapWorker to the JS module.
 func mapWorker(vu moduleVU, w api.Worker) mapping {
+	rt := vu.Runtime()
 	return mapping{
-		"evaluate": w.Evaluate,
-		"evaluateHandle": func(pageFunc goja.Value, args ...goja.Value) (mapping, error) {
-			h, err := w.EvaluateHandle(pageFunc, args...)
+		"evaluate": func(pageFunc goja.Value, args ...goja.Value) any {
+			return w.Evaluate(pageFunc, exportEvaluateArgs(args)...)
+		},
+		"evaluateHandle": func(pageFunc goja.Value, args ...goja.Value) (*goja.Object, error) {
+			h, err := w.EvaluateHandle(pageFunc, exportEvaluateArgs(args)...)
 			if err != nil {
-				panicIfFatalError(vu.Context(), err)
 				return nil, err //nolint:wrapcheck
 			}
-			return mapJSHandle(vu, h), nil
+			return mapJSHandleObject(vu, h), nil
+		},
+		"on": func(event string, handler goja.Callable) error {
+			return w.On(event, func(data any) error { //nolint:wrapcheck
+				_, err := handler(goja.Undefined(), rt.ToValue(mapPageEvent(vu, data)))
+				return err //nolint:wrapcheck
+			})
 		},
 		"url": w.URL(),
 	}
 }
 
+// mapWebSocket to the JS module.
+func mapWebSocket(vu moduleVU, ws api.WebSocket) mapping {
+	rt := vu.Runtime()
+	return mapping{
+		"isClosed": ws.IsClosed,
+		"on": func(event string, handler goja.Callable) error {
+			return ws.On(event, func(data any) error { //nolint:wrapcheck
+				_, err := handler(goja.Undefined(), rt.ToValue(mapPageEvent(vu, data)))
+				return err //nolint:wrapcheck
+			})
+		},
+		"url": ws.URL,
+	}
+}
+
+// mapWebSocketFrame to the JS module. The payload of a text frame is
+// a string, and the payload of a binary frame is an ArrayBuffer.
+func mapWebSocketFrame(vu moduleVU, f *api.WebSocketFrame) mapping {
+	rt := vu.Runtime()
+	if f.Binary {
+		return mapping{"payload": rt.NewArrayBuffer(f.Payload)}
+	}
+	return mapping{"payload": string(f.Payload)}
+}
+
 // mapBrowserContext to the JS module.
 func mapBrowserContext(vu moduleVU, bc api.BrowserContext) mapping {
 	rt := vu.Runtime()
@@ -623,17 +1220,30 @@ func mapBrowserContext(vu moduleVU, bc api.BrowserContext) mapping {
 		"clearCookies":     bc.ClearCookies,
 		"clearPermissions": bc.ClearPermissions,
 		"close":            bc.Close,
-		"cookies": func() ([]any, error) {
-			cc, err := bc.Cookies()
-			ctx := vu.Context()
-			panicIfFatalError(ctx, err)
-			return cc, err //nolint:wrapcheck
-		},
-		"exposeBinding":               bc.ExposeBinding,
-		"exposeFunction":              bc.ExposeFunction,
-		"grantPermissions":            bc.GrantPermissions,
-		"newCDPSession":               bc.NewCDPSession,
-		"route":                       bc.Route,
+		"cookies":          bc.Cookies,
+		"exposeBinding": func(name string, callback goja.Callable, opts goja.Value) {
+			bc.ExposeBinding(name, mapBindingCallback(vu, callback), opts)
+		},
+		"exposeFunction":   bc.ExposeFunction,
+		"grantPermissions": bc.GrantPermissions,
+		"newCDPSession": func(pageOrFrame goja.Value) (mapping, error) {
+			s, err := bc.NewCDPSession(exportPageOrFrame(vu, pageOrFrame))
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapCDPSession(vu, s), nil
+		},
+		"route": func(url, handler goja.Value) error {
+			fn, err := handlerFunc(handler)
+			if err != nil {
+				return err
+			}
+			bc.Route(url, handler, func(r api.Route) error {
+				_, err := fn(goja.Undefined(), rt.ToValue(mapRoute(vu, r)))
+				return err //nolint:wrapcheck
+			})
+			return nil
+		},
 		"setDefaultNavigationTimeout": bc.SetDefaultNavigationTimeout,
 		"setDefaultTimeout":           bc.SetDefaultTimeout,
 		"setExtraHTTPHeaders": func(headers map[string]string) *goja.Promise {
@@ -652,25 +1262,24 @@ func mapBrowserContext(vu moduleVU, bc api.BrowserContext) mapping {
 		"waitForEvent":       bc.WaitForEvent,
 		"pages": func() *goja.Object {
 			var (
-				mpages []mapping
+				mpages []*goja.Object
 				pages  = bc.Pages()
 			)
 			for _, page := range pages {
 				if page == nil {
 					continue
 				}
-				m := mapPage(vu, page)
-				mpages = append(mpages, m)
+				mpages = append(mpages, mapPageObject(vu, page))
 			}
 
 			return rt.ToValue(mpages).ToObject(rt)
 		},
-		"newPage": func() (mapping, error) {
+		"newPage": func() (*goja.Object, error) {
 			page, err := bc.NewPage()
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
-			return mapPage(vu, page), nil
+			return mapPageObject(vu, page), nil
 		},
 	}
 }
@@ -697,6 +1306,17 @@ func mapBrowser(vu moduleVU, wsURL string, isRemoteBrowser bool) mapping { //nol
 			}
 			return b.IsConnected(), nil
 		},
+		"newBrowserCDPSession": func() (mapping, error) {
+			b, err := getOrInitBrowser(ctx, bt, vu, wsURL, isRemoteBrowser)
+			if err != nil {
+				return nil, err
+			}
+			s, err := b.NewBrowserCDPSession()
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			return mapCDPSession(vu, s), nil
+		},
 		"newContext": func(opts goja.Value) (*goja.Object, error) {
 			b, err := getOrInitBrowser(ctx, bt, vu, wsURL, isRemoteBrowser)
 			if err != nil {
@@ -723,7 +1343,7 @@ func mapBrowser(vu moduleVU, wsURL string, isRemoteBrowser bool) mapping { //nol
 			}
 			return b.Version(), nil
 		},
-		"newPage": func(opts goja.Value) (mapping, error) {
+		"newPage": func(opts goja.Value) (*goja.Object, error) {
 			b, err := getOrInitBrowser(ctx, bt, vu, wsURL, isRemoteBrowser)
 			if err != nil {
 				return nil, err
@@ -732,7 +1352,7 @@ func mapBrowser(vu moduleVU, wsURL string, isRemoteBrowser bool) mapping { //nol
 			if err != nil {
 				return nil, err //nolint:wrapcheck
 			}
-			return mapPage(vu, page), nil
+			return mapPageObject(vu, page), nil
 		},
 	}
 }
@@ -1,6 +1,7 @@
 package browser
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
 
 	k6common "go.k6.io/k6/js/common"
 	k6modulestest "go.k6.io/k6/js/modulestest"
@@ -30,6 +32,10 @@ func customMappings() map[string]string {
 		"Frame.queryAll":         "$$",
 		"ElementHandle.query":    "$",
 		"ElementHandle.queryAll": "$$",
+		// ID acronyms
+		"Page.getByTestID":    "getByTestId",
+		"Frame.getByTestID":   "getByTestId",
+		"Locator.getByTestID": "getByTestId",
 		// getters
 		"Page.getKeyboard":    "keyboard",
 		"Page.getMouse":       "mouse",
@@ -173,18 +179,66 @@ func TestMappings(t *testing.T) {
 				return mapResponse(moduleVU{VU: vu}, &common.Response{})
 			},
 		},
+		"mapConsoleMessage": {
+			apiInterface: (*api.ConsoleMessage)(nil),
+			mapp: func() mapping {
+				return mapConsoleMessage(moduleVU{VU: vu}, &common.ConsoleMessage{})
+			},
+		},
+		"mapDialog": {
+			apiInterface: (*api.Dialog)(nil),
+			mapp: func() mapping {
+				return mapDialog(moduleVU{VU: vu}, &common.Dialog{})
+			},
+		},
+		"mapVideo": {
+			apiInterface: (*api.Video)(nil),
+			mapp: func() mapping {
+				return mapVideo(moduleVU{VU: vu}, &common.Video{})
+			},
+		},
+		"mapDownload": {
+			apiInterface: (*api.Download)(nil),
+			mapp: func() mapping {
+				return mapDownload(moduleVU{VU: vu}, &common.Download{})
+			},
+		},
+		"mapFileChooser": {
+			apiInterface: (*api.FileChooser)(nil),
+			mapp: func() mapping {
+				return mapFileChooser(moduleVU{VU: vu}, &common.FileChooser{})
+			},
+		},
+		"mapRoute": {
+			apiInterface: (*api.Route)(nil),
+			mapp: func() mapping {
+				return mapRoute(moduleVU{VU: vu}, &common.Route{})
+			},
+		},
 		"mapWorker": {
 			apiInterface: (*api.Worker)(nil),
 			mapp: func() mapping {
 				return mapWorker(moduleVU{VU: vu}, &common.Worker{})
 			},
 		},
+		"mapWebSocket": {
+			apiInterface: (*api.WebSocket)(nil),
+			mapp: func() mapping {
+				return mapWebSocket(moduleVU{VU: vu}, &common.WebSocket{})
+			},
+		},
 		"mapLocator": {
 			apiInterface: (*api.Locator)(nil),
 			mapp: func() mapping {
 				return mapLocator(moduleVU{VU: vu}, &common.Locator{})
 			},
 		},
+		"mapCDPSession": {
+			apiInterface: (*api.CDPSession)(nil),
+			mapp: func() mapping {
+				return mapCDPSession(moduleVU{VU: vu}, &common.CDPSession{})
+			},
+		},
 	} {
 		tt := tt
 		t.Run(name, func(t *testing.T) {
@@ -194,6 +248,57 @@ func TestMappings(t *testing.T) {
 	}
 }
 
+func TestMapPageError(t *testing.T) {
+	t.Parallel()
+
+	rt := goja.New()
+	vu := moduleVU{VU: &k6modulestest.VU{RuntimeField: rt}}
+	require.NoError(t, rt.Set("pageError", mapPageError(vu, &api.PageError{
+		Name:    "TypeError",
+		Message: "x is not a function",
+		Stack:   "TypeError: x is not a function\n    at <anonymous>:1:1",
+	})))
+
+	v, err := rt.RunString(`[
+		pageError instanceof Error,
+		pageError.name,
+		pageError.message,
+		pageError.stack,
+		String(pageError),
+	]`)
+	require.NoError(t, err)
+	require.Equal(t, []any{
+		true,
+		"TypeError",
+		"x is not a function",
+		"TypeError: x is not a function\n    at <anonymous>:1:1",
+		"TypeError: x is not a function",
+	}, v.Export())
+}
+
+func TestMapWaitForEventPredicate(t *testing.T) {
+	t.Parallel()
+
+	vu := &k6modulestest.VU{RuntimeField: goja.New()}
+	rt := vu.Runtime()
+	predicate, err := rt.RunString(`(msg) => typeof msg.text === "function"`)
+	require.NoError(t, err)
+
+	for name, optsOrPredicate := range map[string]goja.Value{
+		"predicate": predicate,
+		"options":   rt.ToValue(map[string]any{"predicate": predicate, "timeout": 1000}),
+	} {
+		v := mapWaitForEventPredicate(moduleVU{VU: vu}, optsOrPredicate)
+		opts := common.NewPageWaitForEventOptions(0)
+		require.NoError(t, opts.Parse(k6ext.WithVU(context.Background(), vu), v), name)
+		// the predicate is called with the event data as it's emitted.
+		matched, err := opts.Predicate(goja.Undefined(), rt.ToValue(&common.ConsoleMessage{}))
+		require.NoError(t, err, name)
+		require.True(t, matched.ToBoolean(), "predicate must receive the mapped event data: %s", name)
+	}
+	require.True(t, goja.IsUndefined(mapWaitForEventPredicate(moduleVU{VU: vu}, goja.Undefined())))
+}
+
 // toFirstLetterLower converts the first letter of the string to lower case.
 func toFirstLetterLower(s string) string {
 	// Special cases.
@@ -33,6 +33,7 @@ type BrowserType struct {
 	// shouldn't be stored on structs if we can avoid it.
 	Ctx          context.Context
 	vu           k6modules.VU
+	initEnv      *k6common.InitEnvironment
 	hooks        *common.Hooks
 	k6Metrics    *k6ext.CustomMetrics
 	execPath     string // path to the Chromium executable
@@ -49,6 +50,7 @@ func NewBrowserType(vu k6modules.VU) *BrowserType {
 
 	return &BrowserType{
 		vu:           vu,
+		initEnv:      env,
 		hooks:        common.NewHooks(),
 		k6Metrics:    k6ext.RegisterCustomMetrics(env.Registry),
 		randSrc:      rand.New(rand.NewSource(time.Now().UnixNano())), //nolint: gosec
@@ -91,6 +93,7 @@ func (b *BrowserType) init(
 
 func (b *BrowserType) initContext(ctx context.Context) context.Context {
 	ctx = k6ext.WithVU(ctx, b.vu)
+	ctx = k6ext.WithInitEnv(ctx, b.initEnv)
 	ctx = k6ext.WithCustomMetrics(ctx, b.k6Metrics)
 	ctx = common.WithHooks(ctx, b.hooks)
 	ctx = common.WithIterationID(ctx, fmt.Sprintf("%x", b.randSrc.Uint64()))
@@ -0,0 +1,211 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dop251/goja"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+// exposedBinding is the name of the CDP runtime binding that the functions
+// exposed with ExposeBinding and ExposeFunction call to reach the VU.
+const exposedBinding = "k6browserCallExposedBinding"
+
+// bindingInstallScript installs an exposed binding as a function on the
+// global object of a page. The function sends its arguments to the VU
+// through the CDP runtime binding and returns a promise that is settled
+// with the result of the binding callback.
+//
+// With needsHandle, the single argument of the function is kept in the
+// page so that it can be passed to the callback as a JS handle.
+const bindingInstallScript = `(name, bindingName, needsHandle) => {
+	const binding = globalThis[bindingName];
+	if (typeof binding !== "function") {
+		return;
+	}
+	if (globalThis[name] && globalThis[name].__k6browserBinding) {
+		return;
+	}
+	const calls = new Map();
+	const handles = new Map();
+	let lastSeq = 0;
+	const fn = (...args) => {
+		if (needsHandle && args.length > 1) {
+			return Promise.reject(new Error("exposed binding with the handle option can only have a single argument"));
+		}
+		const seq = ++lastSeq;
+		const promise = new Promise((resolve, reject) => calls.set(seq, { resolve, reject }));
+		if (needsHandle) {
+			handles.set(seq, args[0]);
+			args = [];
+		}
+		binding(JSON.stringify({ name, seq, args }));
+		return promise;
+	};
+	Object.defineProperties(fn, {
+		__k6browserBinding: { value: true },
+		__k6browserHandle: {
+			value: (seq) => {
+				const handle = handles.get(seq);
+				handles.delete(seq);
+				return handle;
+			},
+		},
+		__k6browserDeliver: {
+			value: (seq, result, error) => {
+				const call = calls.get(seq);
+				calls.delete(seq);
+				if (!call) {
+					return;
+				}
+				if (error) {
+					call.reject(new Error(error));
+				} else {
+					call.resolve(result === "" ? undefined : JSON.parse(result));
+				}
+			},
+		},
+	});
+	globalThis[name] = fn;
+}`
+
+// binding is a function exposed to the pages with ExposeBinding or
+// ExposeFunction.
+type binding struct {
+	name     string
+	callback api.BindingCallback
+	// handle passes the argument of the binding as a JS handle
+	// instead of by value.
+	handle bool
+}
+
+// newBinding returns a new binding that calls the callback.
+func newBinding(name string, callback api.BindingCallback, handle bool) *binding {
+	return &binding{
+		name:     name,
+		callback: callback,
+		handle:   handle,
+	}
+}
+
+// initScript returns the script that installs the binding
+// in the new documents.
+func (b *binding) initScript() (string, error) {
+	name, err := json.Marshal(b.name)
+	if err != nil {
+		return "", fmt.Errorf("encoding binding name: %w", err)
+	}
+
+	return fmt.Sprintf("(%s)(%s, %q, %t);", bindingInstallScript, name, exposedBinding, b.handle), nil
+}
+
+// functionBinding returns the binding callback of an exposed function,
+// which is only called with the arguments.
+func functionBinding(rt *goja.Runtime, fn goja.Callable) api.BindingCallback {
+	return func(_ *api.BindingSource, args ...any) (goja.Value, error) {
+		jsArgs := make([]goja.Value, 0, len(args))
+		for _, a := range args {
+			jsArgs = append(jsArgs, rt.ToValue(a))
+		}
+		return fn(goja.Undefined(), jsArgs...)
+	}
+}
+
+// bindingCall is a call to an exposed binding from a page.
+type bindingCall struct {
+	Name string `json:"name"`
+	Seq  int64  `json:"seq"`
+	Args []any  `json:"args"`
+}
+
+// bindings is a registry of exposed bindings that is safe for concurrent use.
+type bindings struct {
+	mu       sync.RWMutex
+	bindings map[string]*binding
+}
+
+// add registers the binding. It returns an error if a binding
+// with the same name is already registered.
+func (bs *bindings) add(b *binding) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	if _, ok := bs.bindings[b.name]; ok {
+		return fmt.Errorf("function %q has been already registered", b.name)
+	}
+	if bs.bindings == nil {
+		bs.bindings = make(map[string]*binding)
+	}
+	bs.bindings[b.name] = b
+
+	return nil
+}
+
+// get returns the binding registered with the name or nil.
+func (bs *bindings) get(name string) *binding {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	return bs.bindings[name]
+}
+
+// all returns the registered bindings.
+func (bs *bindings) all() []*binding {
+	bs.mu.RLock()
+	defer bs.mu.RUnlock()
+
+	all := make([]*binding, 0, len(bs.bindings))
+	for _, b := range bs.bindings {
+		all = append(all, b)
+	}
+
+	return all
+}
+
+// bindingResult returns the result of a binding callback encoded as JSON
+// to be delivered to the page, or the message of the error to reject the
+// call with.
+func bindingResult(v goja.Value, err error) (result string, errMsg string) {
+	if err != nil {
+		var ex *goja.Exception
+		if errors.As(err, &ex) {
+			return "", jsErrorMessage(ex.Value())
+		}
+		return "", err.Error()
+	}
+	if !gojaValueExists(v) {
+		return "", ""
+	}
+	b, err := json.Marshal(v.Export())
+	if err != nil {
+		return "", fmt.Sprintf("encoding result: %v", err)
+	}
+
+	return string(b), ""
+}
+
+// jsErrorMessage returns the message of a thrown JS value,
+// which is usually an Error.
+func jsErrorMessage(v goja.Value) string {
+	if obj, ok := v.(*goja.Object); ok {
+		if msg := obj.Get("message"); gojaValueExists(msg) {
+			return msg.String()
+		}
+	}
+
+	return v.String()
+}
+
+// exportedPromise returns the promise that the value is, if any.
+func exportedPromise(v goja.Value) (*goja.Promise, bool) {
+	if !gojaValueExists(v) {
+		return nil, false
+	}
+	promise, ok := v.Export().(*goja.Promise)
+
+	return promise, ok
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+func TestBindings(t *testing.T) {
+	t.Parallel()
+
+	noop := func(*api.BindingSource, ...any) (goja.Value, error) { return nil, nil }
+
+	var bs bindings
+	assert.Nil(t, bs.get("fn"))
+	require.NoError(t, bs.add(newBinding("fn", noop, false)))
+	require.NoError(t, bs.add(newBinding("fn2", noop, true)))
+	assert.ErrorContains(t, bs.add(newBinding("fn", noop, false)), `function "fn" has been already registered`)
+
+	b := bs.get("fn2")
+	require.NotNil(t, b)
+	assert.True(t, b.handle)
+	assert.Len(t, bs.all(), 2)
+}
+
+func TestBindingResult(t *testing.T) {
+	t.Parallel()
+
+	rt := goja.New()
+	throw, err := rt.RunString(`(() => { try { throw new Error("boom") } catch (e) { return e } })()`)
+	require.NoError(t, err)
+	_, ex := rt.RunString(`throw new TypeError("bad type")`)
+	require.Error(t, ex)
+
+	testCases := []struct {
+		name       string
+		value      goja.Value
+		err        error
+		wantResult string
+		wantErr    string
+	}{
+		{name: "undefined", value: goja.Undefined()},
+		{name: "nil"},
+		{name: "number", value: rt.ToValue(42), wantResult: "42"},
+		{name: "object", value: rt.ToValue(map[string]any{"ok": true}), wantResult: `{"ok":true}`},
+		{name: "error", err: errors.New("failed"), wantErr: "failed"},
+		{name: "exception", err: ex, wantErr: "bad type"},
+		{name: "rejection_reason", err: errors.New(jsErrorMessage(throw)), wantErr: "boom"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, errMsg := bindingResult(tc.value, tc.err)
+			assert.Equal(t, tc.wantResult, result)
+			assert.Equal(t, tc.wantErr, errMsg)
+		})
+	}
+}
+
+func TestBindingInitScript(t *testing.T) {
+	t.Parallel()
+
+	rt := goja.New()
+	var calls []bindingCall
+	require.NoError(t, rt.Set(exposedBinding, func(payload string) {
+		var call bindingCall
+		require.NoError(t, json.Unmarshal([]byte(payload), &call))
+		calls = append(calls, call)
+	}))
+
+	script, err := newBinding(`say"hi`, nil, false).initScript()
+	require.NoError(t, err)
+	_, err = rt.RunString(script)
+	require.NoError(t, err)
+	// installing the binding again in the same document does nothing.
+	_, err = rt.RunString(script)
+	require.NoError(t, err)
+
+	_, err = rt.RunString(`
+		var results = [];
+		globalThis['say"hi'](1, "two").then(v => results.push(v));
+		globalThis['say"hi']().catch(e => results.push(e.message));
+	`)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	assert.Equal(t, bindingCall{Name: `say"hi`, Seq: 1, Args: []any{float64(1), "two"}}, calls[0])
+	assert.Equal(t, int64(2), calls[1].Seq)
+
+	_, err = rt.RunString(`
+		const deliver = globalThis['say"hi'].__k6browserDeliver;
+		deliver(2, "", "boom");
+		deliver(1, '{"ok":true}', "");
+		// a call is settled only once.
+		deliver(1, '"again"', "");
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"boom", map[string]any{"ok": true}}, rt.Get("results").Export())
+}
@@ -61,6 +61,16 @@ type Browser struct {
 	sessionIDtoTargetIDMu sync.RWMutex
 	sessionIDtoTargetID   map[target.SessionID]target.ID
 
+	// newPageMu serializes creating the pages, so that the page that's
+	// attached while an internal page is created is the internal page.
+	newPageMu sync.Mutex
+	// internalPage receives the internal page that's being created in
+	// the browser context with internalPageCtxID, if any.
+	// See newInternalPageInContext.
+	internalPageMu    sync.Mutex
+	internalPageCtxID cdp.BrowserContextID
+	internalPage      chan *Page
+
 	// Used to display a warning when the browser is reclosed.
 	closed bool
 
@@ -151,6 +161,9 @@ func (b *Browser) getPages() []*Page {
 	defer b.pagesMu.RUnlock()
 	pages := make([]*Page, 0, len(b.pages))
 	for _, p := range b.pages {
+		if p.internal {
+			continue
+		}
 		pages = append(pages, p)
 	}
 	return pages
@@ -243,7 +256,8 @@ func (b *Browser) onAttachedToTarget(ev *target.EventAttachedToTarget) {
 		}
 		b.pagesMu.RUnlock()
 	}
-	p, err := NewPage(b.ctx, session, browserCtx, targetPage.TargetID, opener, isPage, b.logger)
+	internalPage := b.takeInternalPage(targetPage)
+	p, err := newPage(b.ctx, session, browserCtx, targetPage.TargetID, opener, isPage, internalPage != nil, b.logger)
 	if err != nil && b.isPageAttachmentErrorIgnorable(ev, session, err) {
 		return // Ignore this page.
 	}
@@ -251,6 +265,11 @@ func (b *Browser) onAttachedToTarget(ev *target.EventAttachedToTarget) {
 		k6ext.Panic(b.ctx, "creating a new %s: %w", targetPage.Type, err)
 	}
 	b.attachNewPage(p, ev) // Register the page as an active page.
+	// Internal pages are not one of the pages of the browser context.
+	if internalPage != nil {
+		internalPage <- p
+		return
+	}
 	// Emit the page event only for pages, not for background pages.
 	// Background pages are created by extensions.
 	if isPage {
@@ -278,12 +297,43 @@ func (b *Browser) attachNewPage(p *Page, ev *target.EventAttachedToTarget) {
 	b.sessionIDtoTargetIDMu.Unlock()
 }
 
+// takeInternalPage returns the channel that receives the internal page
+// if the attached target is the internal page that's being created, and
+// nil otherwise. The internal page is a top-level page without an opener,
+// unlike the popups that are opened meanwhile.
+func (b *Browser) takeInternalPage(targetPage *target.Info) chan *Page {
+	if targetPage.Type != "page" || targetPage.OpenerID != "" {
+		return nil
+	}
+
+	b.internalPageMu.Lock()
+	defer b.internalPageMu.Unlock()
+
+	if b.internalPage == nil || b.internalPageCtxID != targetPage.BrowserContextID {
+		return nil
+	}
+	internalPage := b.internalPage
+	b.internalPage = nil
+
+	return internalPage
+}
+
 // isAttachedPageValid returns true if the attached page is valid and should be
 // added to the browser's pages. It returns false if the attached page is not
 // valid and should be ignored.
 func (b *Browser) isAttachedPageValid(ev *target.EventAttachedToTarget, browserCtx *BrowserContext) bool {
 	targetPage := ev.TargetInfo
 
+	// A target that is already attached has an additional session, such
+	// as a CDP session created by the user, and it's not a new page.
+	b.pagesMu.RLock()
+	_, attached := b.pages[targetPage.TargetID]
+	b.pagesMu.RUnlock()
+	if attached || targetPage.Type == "iframe" {
+		b.logger.Debugf("Browser:isAttachedPageValid:return", "sid:%v tid:%v (additional session)", ev.SessionID, targetPage.TargetID)
+		return false
+	}
+
 	// We're not interested in the top-level browser target, other targets or DevTools targets right now.
 	isDevTools := strings.HasPrefix(targetPage.URL, "devtools://devtools")
 	if targetPage.Type == "browser" || targetPage.Type == "other" || isDevTools {
@@ -371,6 +421,9 @@ func (b *Browser) newPageInContext(id cdp.BrowserContextID) (*Page, error) {
 		return nil, fmt.Errorf("missing browser context %s, current context is %s", id, b.context.id)
 	}
 
+	b.newPageMu.Lock()
+	defer b.newPageMu.Unlock()
+
 	ctx, cancel := context.WithTimeout(b.ctx, b.browserOpts.Timeout)
 	defer cancel()
 
@@ -419,6 +472,47 @@ func (b *Browser) newPageInContext(id cdp.BrowserContextID) (*Page, error) {
 	return page, err
 }
 
+// newInternalPageInContext creates a page in the browser context that's
+// only used internally, such as for accessing the storage of origins.
+// The page is not one of the pages of the browser context, and it doesn't
+// emit the page events of the browser context.
+func (b *Browser) newInternalPageInContext(id cdp.BrowserContextID) (*Page, error) {
+	if b.context == nil || b.context.id != id {
+		return nil, fmt.Errorf("missing browser context %s, current context is %s", id, b.context.id)
+	}
+
+	b.newPageMu.Lock()
+	defer b.newPageMu.Unlock()
+
+	// buffer of one is for sending the page whether it's waited for or not.
+	internalPage := make(chan *Page, 1)
+	b.internalPageMu.Lock()
+	b.internalPageCtxID, b.internalPage = id, internalPage
+	b.internalPageMu.Unlock()
+	defer func() {
+		b.internalPageMu.Lock()
+		b.internalPage = nil
+		b.internalPageMu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(b.ctx, b.browserOpts.Timeout)
+	defer cancel()
+
+	action := target.CreateTarget(BlankPage).WithBrowserContextID(id)
+	if _, err := action.Do(cdp.WithExecutor(ctx, b.conn)); err != nil {
+		return nil, fmt.Errorf("creating an internal page: %w", err)
+	}
+	select {
+	case p := <-internalPage:
+		return p, nil
+	case <-ctx.Done():
+		return nil, &k6ext.UserFriendlyError{
+			Err:     ctx.Err(),
+			Timeout: b.browserOpts.Timeout,
+		}
+	}
+}
+
 // Close shuts down the browser.
 func (b *Browser) Close() {
 	if b.closed {
@@ -439,6 +533,16 @@ func (b *Browser) Close() {
 	b.logger.Debugf("Browser:Close", "")
 	atomic.CompareAndSwapInt64(&b.state, b.state, BrowserStateClosed)
 
+	// Stop the VU event loop from waiting for the handlers
+	// of the browser contexts, such as the route handlers.
+	for _, bctx := range []*BrowserContext{b.defaultContext, b.context} {
+		if bctx != nil {
+			bctx.closeTaskQueue()
+			bctx.closeVideos()
+			bctx.closeDownloads()
+		}
+	}
+
 	// Signal to the connection and the process that we're gracefully closing.
 	// We ignore any IO errors reading from the WS connection, because the below
 	// CDP Browser.close command ends the connection unexpectedly, which causes
@@ -514,9 +618,36 @@ func (b *Browser) NewContext(opts goja.Value) (api.BrowserContext, error) {
 	}
 	b.context = browserCtx
 
+	if err := browserCtx.initDownloads(); err != nil {
+		return nil, fmt.Errorf("new context: %w", err)
+	}
+
+	if browserCtxOpts.StorageState != nil {
+		if err := browserCtx.setStorageState(browserCtxOpts.StorageState); err != nil {
+			return nil, fmt.Errorf("new context: setting storage state: %w", err)
+		}
+	}
+
 	return browserCtx, nil
 }
 
+// NewBrowserCDPSession returns a new raw CDP session attached to
+// the browser target.
+func (b *Browser) NewBrowserCDPSession() (api.CDPSession, error) {
+	b.logger.Debugf("Browser:NewBrowserCDPSession", "")
+
+	sid, err := target.AttachToBrowserTarget().Do(cdp.WithExecutor(b.ctx, b.conn))
+	if err != nil {
+		return nil, fmt.Errorf("attaching CDP session to browser: %w", err)
+	}
+	s, err := NewCDPSession(b.ctx, b.conn, sid, b.logger)
+	if err != nil {
+		return nil, fmt.Errorf("creating browser CDP session: %w", err)
+	}
+
+	return s, nil
+}
+
 // NewPage creates a new tab in the browser window.
 func (b *Browser) NewPage(opts goja.Value) (api.Page, error) {
 	browserCtx, err := b.NewContext(opts)
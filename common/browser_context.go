@@ -2,8 +2,18 @@ package common
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/grafana/xk6-browser/api"
@@ -44,6 +54,22 @@ type BrowserContext struct {
 	vu              k6modules.VU
 
 	evaluateOnNewDocumentSources []string
+
+	routes   routeHandlers
+	bindings bindings
+
+	// origins are the origins that the pages navigated to,
+	// to export their storage with StorageState.
+	originsMu sync.Mutex
+	origins   map[string]struct{}
+
+	// taskQueue runs the route handlers and the exposed bindings
+	// of the browser context on the VU event loop.
+	taskQueueMu sync.Mutex
+	taskQueue   *k6ext.TaskQueue
+
+	// downloads routes the downloads of the pages, if any.
+	downloads *downloadManager
 }
 
 // NewBrowserContext creates a new browser context.
@@ -130,6 +156,74 @@ func (b *BrowserContext) AddInitScript(script goja.Value, arg goja.Value) error
 	return nil
 }
 
+// hasRoutes returns true if the browser context has route handlers registered.
+func (b *BrowserContext) hasRoutes() bool {
+	return b.routes.count() > 0
+}
+
+// currentTaskQueue returns the task queue of the browser context or nil
+// if the browser context doesn't have any.
+func (b *BrowserContext) currentTaskQueue() *k6ext.TaskQueue {
+	b.taskQueueMu.Lock()
+	defer b.taskQueueMu.Unlock()
+
+	return b.taskQueue
+}
+
+// initDownloads routes the downloads of the pages into the downloads
+// directory of the browser context, which is created in the data
+// directory of the browser. The downloads are canceled unless the
+// acceptDownloads option is set.
+//
+// A remote browser can't accept downloads, as it saves them on its
+// machine, where they can't be read.
+func (b *BrowserContext) initDownloads() error {
+	if b.opts.AcceptDownloads && b.browser.browserOpts.isRemoteBrowser {
+		return errors.New("acceptDownloads is not supported with a remote browser, " +
+			"as the downloads are saved on the machine of the browser")
+	}
+	m, err := newDownloadManager(b.ctx, b, b.browser.browserProc.DataDir(), b.opts.AcceptDownloads, b.logger)
+	if err != nil {
+		return err
+	}
+	b.downloads = m
+
+	return nil
+}
+
+// closeDownloads cancels the unfinished downloads of the browser context,
+// if any, and deletes its downloads directory.
+func (b *BrowserContext) closeDownloads() {
+	if b.downloads != nil {
+		b.downloads.close()
+	}
+}
+
+// closeVideos finishes the video recordings of the pages of the browser
+// context that are still open, as their videos are otherwise finished only
+// when the pages are closed.
+func (b *BrowserContext) closeVideos() {
+	for _, p := range b.browser.getPages() {
+		if p.browserCtx != b {
+			continue
+		}
+		if v := p.getVideo(); v != nil {
+			v.finish()
+		}
+	}
+}
+
+// closeTaskQueue closes the task queue of the browser context, if any,
+// so that the VU event loop doesn't wait for its handlers anymore.
+func (b *BrowserContext) closeTaskQueue() {
+	b.taskQueueMu.Lock()
+	defer b.taskQueueMu.Unlock()
+
+	if b.taskQueue != nil {
+		b.taskQueue.Close()
+	}
+}
+
 func (b *BrowserContext) applyAllInitScripts(p *Page) error {
 	for _, source := range b.evaluateOnNewDocumentSources {
 		if err := p.evaluateOnNewDocument(source); err != nil {
@@ -172,24 +266,101 @@ func (b *BrowserContext) Close() {
 	if b.id == "" {
 		k6ext.Panic(b.ctx, "default browser context can't be closed")
 	}
+	b.closeTaskQueue()
+	b.closeVideos()
 	if err := b.browser.disposeContext(b.id); err != nil {
 		k6ext.Panic(b.ctx, "disposing browser context: %w", err)
 	}
+	b.closeDownloads()
 }
 
-// Cookies is not implemented.
-func (b *BrowserContext) Cookies() ([]any, error) {
-	return nil, fmt.Errorf("BrowserContext.cookies() has not been implemented yet: %w", k6error.ErrFatal)
+// Cookies returns the cookies of this browser context. If URLs are given,
+// only the cookies that the browser would send to any of them are returned.
+// The urls can be a string or an array of strings.
+func (b *BrowserContext) Cookies(urls goja.Value) ([]*api.Cookie, error) {
+	b.logger.Debugf("BrowserContext:Cookies", "bctxid:%v urls:%v", b.id, urls)
+
+	var parsedURLs []*url.URL
+	if gojaValueExists(urls) {
+		var us []string
+		if u, ok := urls.Export().(string); ok {
+			us = []string{u}
+		} else if err := b.vu.Runtime().ExportTo(urls, &us); err != nil {
+			return nil, fmt.Errorf("parsing cookie URLs: %w", err)
+		}
+		for _, u := range us {
+			pu, err := url.Parse(u)
+			if err != nil {
+				return nil, fmt.Errorf("parsing cookie URL %q: %w", u, err)
+			}
+			parsedURLs = append(parsedURLs, pu)
+		}
+	}
+
+	action := storage.GetCookies().WithBrowserContextID(b.id)
+	cookies, err := action.Do(cdp.WithExecutor(b.ctx, b.browser.conn))
+	if err != nil {
+		return nil, fmt.Errorf("getting cookies: %w", err)
+	}
+	filtered := make([]*api.Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		if len(parsedURLs) > 0 && !cookieMatchesAnyURL(c, parsedURLs) {
+			continue
+		}
+		filtered = append(filtered, toAPICookie(c))
+	}
+
+	return filtered, nil
 }
 
-// ExposeBinding is not implemented.
-func (b *BrowserContext) ExposeBinding(name string, callback goja.Callable, opts goja.Value) {
-	k6ext.Panic(b.ctx, "BrowserContext.exposeBinding(name, callback, opts) has not been implemented yet")
+// ExposeBinding adds a function with the name to the global object of every
+// frame of all the pages in the browser context. See Page.ExposeBinding.
+func (b *BrowserContext) ExposeBinding(name string, callback api.BindingCallback, opts goja.Value) {
+	b.logger.Debugf("BrowserContext:ExposeBinding", "bctxid:%v name:%s", b.id, name)
+
+	popts := NewExposeBindingOptions()
+	if err := popts.Parse(b.ctx, opts); err != nil {
+		k6ext.Panic(b.ctx, "parsing expose binding options: %w", err)
+	}
+	if err := b.exposeBinding(newBinding(name, callback, popts.Handle)); err != nil {
+		k6ext.Panic(b.ctx, "exposing binding %q: %w", name, err)
+	}
 }
 
-// ExposeFunction is not implemented.
+// ExposeFunction is like ExposeBinding, but the callback is only
+// called with the arguments.
 func (b *BrowserContext) ExposeFunction(name string, callback goja.Callable) {
-	k6ext.Panic(b.ctx, "BrowserContext.exposeFunction(name, callback) has not been implemented yet")
+	b.logger.Debugf("BrowserContext:ExposeFunction", "bctxid:%v name:%s", b.id, name)
+
+	bi := newBinding(name, functionBinding(b.vu.Runtime(), callback), false)
+	if err := b.exposeBinding(bi); err != nil {
+		k6ext.Panic(b.ctx, "exposing function %q: %w", name, err)
+	}
+}
+
+func (b *BrowserContext) exposeBinding(bi *binding) error {
+	pages := b.browser.getPages()
+	for _, p := range pages {
+		if p.bindings.get(bi.name) != nil {
+			return fmt.Errorf("function %q has been already registered in a page", bi.name)
+		}
+	}
+	if err := b.bindings.add(bi); err != nil {
+		return err
+	}
+	b.taskQueueMu.Lock()
+	if b.taskQueue == nil {
+		b.taskQueue = k6ext.NewTaskQueue(b.vu.RegisterCallback)
+	}
+	b.taskQueueMu.Unlock()
+
+	for _, p := range pages {
+		if err := p.installBinding(bi); err != nil {
+			return fmt.Errorf("installing binding in target ID %s: %w", p.targetID, err)
+		}
+	}
+
+	return nil
 }
 
 // GrantPermissions enables the specified permissions, all others will be disabled.
@@ -237,10 +408,44 @@ func (b *BrowserContext) GrantPermissions(permissions []string, opts goja.Value)
 	}
 }
 
-// NewCDPSession returns a new CDP session attached to this target.
-func (b *BrowserContext) NewCDPSession() api.CDPSession {
-	k6ext.Panic(b.ctx, "BrowserContext.newCDPSession() has not been implemented yet")
-	return nil
+// NewCDPSession returns a new raw CDP session attached to the target
+// of the page or the frame. Only the frames that are out of the process
+// of their page, such as the cross-origin iframes, have their own target.
+func (b *BrowserContext) NewCDPSession(pageOrFrame any) (api.CDPSession, error) {
+	b.logger.Debugf("BrowserContext:NewCDPSession", "bctxid:%v", b.id)
+
+	var (
+		tid target.ID
+		p   *Page
+	)
+	switch t := pageOrFrame.(type) {
+	case *Page:
+		tid, p = t.targetID, t
+	case *Frame:
+		p = t.manager.page
+		if p.getFrameSession(cdp.FrameID(t.ID())) == nil {
+			return nil, fmt.Errorf(
+				"frame %s doesn't have its own target; create a CDP session of its page instead", t.ID())
+		}
+		tid = target.ID(t.ID())
+	default:
+		return nil, errors.New("creating CDP session: target must be a page or a frame")
+	}
+	if p.browserCtx != b {
+		return nil, errors.New("creating CDP session: page or frame doesn't belong to this browser context")
+	}
+
+	action := target.AttachToTarget(tid).WithFlatten(true)
+	sid, err := action.Do(cdp.WithExecutor(b.ctx, b.browser.conn))
+	if err != nil {
+		return nil, fmt.Errorf("attaching CDP session to target %s: %w", tid, err)
+	}
+	s, err := NewCDPSession(b.ctx, b.browser.conn, sid, b.logger)
+	if err != nil {
+		return nil, fmt.Errorf("creating CDP session: %w", err)
+	}
+
+	return s, nil
 }
 
 // NewPage creates a new page inside this browser context.
@@ -276,9 +481,32 @@ func (b *BrowserContext) Pages() []api.Page {
 	return pages
 }
 
-// Route is not implemented.
-func (b *BrowserContext) Route(url goja.Value, handler goja.Callable) {
-	k6ext.Panic(b.ctx, "BrowserContext.route(url, handler) has not been implemented yet")
+// Route registers a handler for the requests of all the pages in the
+// browser context matching the url. The url can be a glob pattern, a RegExp
+// or a predicate function that receives the request URL.
+//
+// The route handlers of a page take precedence over the ones of its
+// browser context. The key identifies the handler for Unroute, such as
+// the JS function that the handler calls.
+func (b *BrowserContext) Route(url, key goja.Value, handler func(api.Route) error) {
+	b.logger.Debugf("BrowserContext:Route", "bctxid:%v url:%v", b.id, url)
+
+	m, err := newURLMatcher(b.vu.Runtime(), url)
+	if err != nil {
+		k6ext.Panic(b.ctx, "adding route: %w", err)
+	}
+	b.taskQueueMu.Lock()
+	if b.taskQueue == nil {
+		b.taskQueue = k6ext.NewTaskQueue(b.vu.RegisterCallback)
+	}
+	b.taskQueueMu.Unlock()
+
+	b.routes.add(m, key, handler)
+	for _, p := range b.browser.getPages() {
+		if err := p.updateRequestInterception(); err != nil {
+			k6ext.Panic(b.ctx, "adding route in target ID %s: %w", p.targetID, err)
+		}
+	}
 }
 
 // SetDefaultNavigationTimeout sets the default navigation timeout in milliseconds.
@@ -349,14 +577,206 @@ func (b *BrowserContext) SetOffline(offline bool) {
 	}
 }
 
-// StorageState is not implemented.
-func (b *BrowserContext) StorageState(opts goja.Value) {
-	k6ext.Panic(b.ctx, "BrowserContext.storageState(opts) has not been implemented yet")
+// StorageState returns the cookies and the local storage of the origins
+// that the pages of this browser context navigated to. If the path option
+// is set, it also writes the storage state to the file as JSON.
+func (b *BrowserContext) StorageState(opts goja.Value) (*api.StorageState, error) {
+	b.logger.Debugf("BrowserContext:StorageState", "bctxid:%v", b.id)
+
+	popts := NewBrowserContextStorageStateOptions()
+	if err := popts.Parse(b.ctx, opts); err != nil {
+		return nil, fmt.Errorf("parsing storage state options: %w", err)
+	}
+	state, err := b.storageState()
+	if err != nil {
+		return nil, fmt.Errorf("getting storage state: %w", err)
+	}
+	if popts.Path == "" {
+		return state, nil
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding storage state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(popts.Path), 0o755); err != nil { //nolint:gosec
+		return nil, fmt.Errorf("creating storage state directory: %w", err)
+	}
+	if err := os.WriteFile(popts.Path, data, 0o644); err != nil { //nolint:gosec
+		return nil, fmt.Errorf("writing storage state to %q: %w", popts.Path, err)
+	}
+
+	return state, nil
 }
 
-// Unroute is not implemented.
-func (b *BrowserContext) Unroute(url goja.Value, handler goja.Callable) {
-	k6ext.Panic(b.ctx, "BrowserContext.unroute(url, handler) has not been implemented yet")
+func (b *BrowserContext) storageState() (*api.StorageState, error) {
+	cookies, err := b.Cookies(nil)
+	if err != nil {
+		return nil, err
+	}
+	state := &api.StorageState{
+		Cookies: cookies,
+		Origins: []*api.OriginStorage{},
+	}
+
+	// The local storage of the origins that are open in a page is read from
+	// the page. The other origins are visited with a temporary page.
+	var (
+		origins = b.visitedOrigins()
+		visited = make(map[string]bool)
+		closed  []string
+	)
+	for _, p := range b.browser.getPages() {
+		for _, f := range p.frameManager.Frames() {
+			origin := originOf(f.URL())
+			if origin == "" || visited[origin] {
+				continue
+			}
+			items, err := f.(*Frame).localStorage()
+			if err != nil {
+				return nil, fmt.Errorf("getting local storage of %q: %w", origin, err)
+			}
+			visited[origin] = true
+			state.Origins = appendOriginStorage(state.Origins, origin, items)
+		}
+	}
+	for _, origin := range origins {
+		if !visited[origin] {
+			closed = append(closed, origin)
+		}
+	}
+	err = b.forEachOrigin(closed, func(origin string, f *Frame) error {
+		items, err := f.localStorage()
+		if err != nil {
+			return fmt.Errorf("getting local storage of %q: %w", origin, err)
+		}
+		state.Origins = appendOriginStorage(state.Origins, origin, items)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// setStorageState sets the cookies and the local storage of the origins
+// in the storage state.
+func (b *BrowserContext) setStorageState(state *api.StorageState) error {
+	if len(state.Cookies) > 0 {
+		params := make([]*network.CookieParam, 0, len(state.Cookies))
+		for _, c := range state.Cookies {
+			params = append(params, toCookieParam(c))
+		}
+		action := storage.SetCookies(params).WithBrowserContextID(b.id)
+		if err := action.Do(cdp.WithExecutor(b.ctx, b.browser.conn)); err != nil {
+			return fmt.Errorf("setting cookies: %w", err)
+		}
+	}
+
+	var (
+		origins []string
+		items   = make(map[string][]*api.NameValue)
+	)
+	for _, o := range state.Origins {
+		if len(o.LocalStorage) == 0 {
+			continue
+		}
+		if _, ok := items[o.Origin]; !ok {
+			origins = append(origins, o.Origin)
+		}
+		items[o.Origin] = append(items[o.Origin], o.LocalStorage...)
+	}
+
+	return b.forEachOrigin(origins, func(origin string, f *Frame) error {
+		b.addOrigin(origin)
+		if err := f.setLocalStorage(items[origin]); err != nil {
+			return fmt.Errorf("setting local storage of %q: %w", origin, err)
+		}
+		return nil
+	})
+}
+
+// forEachOrigin navigates an internal page to each origin and calls fn
+// with the main frame of the page. The requests of the page are fulfilled
+// with an empty document, so the navigations don't reach the servers.
+// The internal page is not one of the pages of the browser context,
+// so it doesn't emit the page events, and it isn't routed.
+func (b *BrowserContext) forEachOrigin(origins []string, fn func(origin string, f *Frame) error) (err error) {
+	if len(origins) == 0 {
+		return nil
+	}
+	p, err := b.browser.newInternalPageInContext(b.id)
+	if err != nil {
+		return fmt.Errorf("creating page for origins: %w", err)
+	}
+	defer func() {
+		if cerr := p.Close(nil); cerr != nil && err == nil {
+			err = fmt.Errorf("closing page for origins: %w", cerr)
+		}
+	}()
+
+	if err := p.mainFrameSession.getNetworkManager().setEmptyResponses(true); err != nil {
+		return fmt.Errorf("intercepting requests of page for origins: %w", err)
+	}
+	f := p.frameManager.MainFrame()
+	for _, origin := range origins {
+		opts := NewFrameGotoOptions("", time.Duration(b.timeoutSettings.navigationTimeout())*time.Second)
+		if _, err := p.frameManager.NavigateFrame(f, origin, opts); err != nil {
+			return fmt.Errorf("navigating to %q: %w", origin, err)
+		}
+		if err := fn(origin, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addOrigin adds the origin of the URL to the visited origins of the
+// browser context. The URLs other than HTTP(S) don't have a storage
+// that can be exported, so they are ignored.
+func (b *BrowserContext) addOrigin(url string) {
+	origin := originOf(url)
+	if origin == "" {
+		return
+	}
+
+	b.originsMu.Lock()
+	defer b.originsMu.Unlock()
+
+	if b.origins == nil {
+		b.origins = make(map[string]struct{})
+	}
+	b.origins[origin] = struct{}{}
+}
+
+// visitedOrigins returns the sorted origins that the pages of the
+// browser context navigated to.
+func (b *BrowserContext) visitedOrigins() []string {
+	b.originsMu.Lock()
+	defer b.originsMu.Unlock()
+
+	origins := make([]string, 0, len(b.origins))
+	for o := range b.origins {
+		origins = append(origins, o)
+	}
+	sort.Strings(origins)
+
+	return origins
+}
+
+// Unroute removes the route handlers registered for the url.
+// The url must be the same value that is passed to Route. If the key
+// is given, only the handler registered with the same key is removed.
+func (b *BrowserContext) Unroute(url, key goja.Value) {
+	b.logger.Debugf("BrowserContext:Unroute", "bctxid:%v url:%v", b.id, url)
+
+	b.routes.remove(url, key)
+	for _, p := range b.browser.getPages() {
+		if err := p.updateRequestInterception(); err != nil {
+			k6ext.Panic(b.ctx, "removing route in target ID %s: %w", p.targetID, err)
+		}
+	}
 }
 
 // WaitForEvent waits for event.
@@ -508,3 +928,134 @@ func (b *BrowserContext) addCookies(cookies goja.Value) error {
 
 	return nil
 }
+
+// toAPICookie converts a CDP cookie to a cookie of the API.
+func toAPICookie(c *network.Cookie) *api.Cookie {
+	expires := c.Expires
+	if c.Session {
+		expires = -1
+	}
+	// the browser leaves out the same site attribute of the cookies
+	// that don't set it, and treats them as Lax cookies.
+	sameSite := c.SameSite.String()
+	if sameSite == "" {
+		sameSite = network.CookieSameSiteLax.String()
+	}
+
+	return &api.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		Expires:  expires,
+		HTTPOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+		SameSite: sameSite,
+	}
+}
+
+// toCookieParam converts a cookie of the API to a CDP cookie parameter.
+func toCookieParam(c *api.Cookie) *network.CookieParam {
+	p := &network.CookieParam{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		HTTPOnly: c.HTTPOnly,
+		Secure:   c.Secure,
+	}
+	if c.SameSite != "" {
+		p.SameSite = network.CookieSameSite(c.SameSite)
+	}
+	if c.Expires > 0 {
+		sec, frac := math.Modf(c.Expires)
+		expires := cdp.TimeSinceEpoch(time.Unix(int64(sec), int64(frac*float64(time.Second))))
+		p.Expires = &expires
+	}
+
+	return p
+}
+
+// cookieMatchesAnyURL returns true if the browser would send the cookie
+// to any of the URLs, i.e. the cookie's domain, path and secure flag
+// match one of the URLs.
+func cookieMatchesAnyURL(c *network.Cookie, urls []*url.URL) bool {
+	for _, u := range urls {
+		if cookieMatchesURL(c, u) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cookieMatchesURL returns true if the browser sends the cookie to the URL.
+// A host-only cookie, whose domain doesn't start with a dot, matches only
+// its host. The domain and the path of the cookie are matched as described
+// in RFC 6265, sections 5.1.3 and 5.1.4.
+func cookieMatchesURL(c *network.Cookie, u *url.URL) bool {
+	host := u.Hostname()
+	if domain := c.Domain; strings.HasPrefix(domain, ".") {
+		if host != domain[1:] && !strings.HasSuffix(host, domain) {
+			return false
+		}
+	} else if host != domain {
+		return false
+	}
+	if !cookiePathMatches(c.Path, u.Path) {
+		return false
+	}
+	if c.Secure && u.Scheme != "https" && !isLocalHost(host) {
+		return false
+	}
+
+	return true
+}
+
+// cookiePathMatches returns true if the request path path-matches the
+// cookie path. The cookie path must be a prefix of the request path that
+// ends at a path segment boundary.
+func cookiePathMatches(cookiePath, path string) bool {
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, cookiePath) {
+		return false
+	}
+
+	return len(path) == len(cookiePath) ||
+		strings.HasSuffix(cookiePath, "/") ||
+		path[len(cookiePath)] == '/'
+}
+
+// isLocalHost returns true if the host is the local host, which browsers
+// treat as a secure context even over HTTP.
+func isLocalHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
+
+// originOf returns the origin of the HTTP(S) URL, or an empty string
+// for the other URLs.
+func originOf(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// appendOriginStorage appends the local storage of the origin to the
+// origins unless it's empty.
+func appendOriginStorage(origins []*api.OriginStorage, origin string, items []*api.NameValue) []*api.OriginStorage {
+	if len(items) == 0 {
+		return origins
+	}
+
+	return append(origins, &api.OriginStorage{Origin: origin, LocalStorage: items})
+}
@@ -2,9 +2,12 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/keyboardlayout"
 
 	"github.com/dop251/goja"
 )
@@ -22,11 +25,14 @@ type BrowserContextOptions struct {
 	IgnoreHTTPSErrors bool              `js:"ignoreHTTPSErrors"`
 	IsMobile          bool              `js:"isMobile"`
 	JavaScriptEnabled bool              `js:"javaScriptEnabled"`
+	KeyboardLayout    string            `js:"keyboardLayout"`
 	Locale            string            `js:"locale"`
 	Offline           bool              `js:"offline"`
 	Permissions       []string          `js:"permissions"`
 	ReducedMotion     ReducedMotion     `js:"reducedMotion"`
 	Screen            *Screen           `js:"screen"`
+	StorageState      *api.StorageState `js:"storageState"`
+	TestIDAttribute   string            `js:"testIdAttribute"`
 	TimezoneID        string            `js:"timezoneID"`
 	UserAgent         string            `js:"userAgent"`
 	VideosPath        string            `js:"videosPath"`
@@ -40,10 +46,12 @@ func NewBrowserContextOptions() *BrowserContextOptions {
 		DeviceScaleFactor: 1.0,
 		ExtraHTTPHeaders:  make(map[string]string),
 		JavaScriptEnabled: true,
+		KeyboardLayout:    DefaultKeyboardLayout,
 		Locale:            DefaultLocale,
 		Permissions:       []string{},
 		ReducedMotion:     ReducedMotionNoPreference,
 		Screen:            &Screen{Width: DefaultScreenWidth, Height: DefaultScreenHeight},
+		TestIDAttribute:   DefaultTestIDAttribute,
 		Viewport:          &Viewport{Width: DefaultScreenWidth, Height: DefaultScreenHeight},
 	}
 }
@@ -94,6 +102,12 @@ func (b *BrowserContextOptions) Parse(ctx context.Context, opts goja.Value) erro
 				b.IsMobile = opts.Get(k).ToBoolean()
 			case "javaScriptEnabled":
 				b.JavaScriptEnabled = opts.Get(k).ToBoolean()
+			case "keyboardLayout":
+				layout := opts.Get(k).String()
+				if !keyboardlayout.Exists(layout) {
+					return fmt.Errorf("unknown keyboard layout: %q", layout)
+				}
+				b.KeyboardLayout = layout
 			case "locale":
 				b.Locale = opts.Get(k).String()
 			case "offline":
@@ -117,10 +131,20 @@ func (b *BrowserContextOptions) Parse(ctx context.Context, opts goja.Value) erro
 					return err
 				}
 				b.Screen = screen
+			case "storageState":
+				state, err := parseStorageState(ctx, opts.Get(k))
+				if err != nil {
+					return fmt.Errorf("parsing storageState option: %w", err)
+				}
+				b.StorageState = state
+			case "testIdAttribute":
+				b.TestIDAttribute = opts.Get(k).String()
 			case "timezoneID":
 				b.TimezoneID = opts.Get(k).String()
 			case "userAgent":
 				b.UserAgent = opts.Get(k).String()
+			case "videosPath":
+				b.VideosPath = opts.Get(k).String()
 			case "viewport":
 				viewport := &Viewport{}
 				if err := viewport.Parse(ctx, opts.Get(k).ToObject(rt)); err != nil {
@@ -132,3 +156,57 @@ func (b *BrowserContextOptions) Parse(ctx context.Context, opts goja.Value) erro
 	}
 	return nil
 }
+
+// parseStorageState parses the storage state that is either a path to
+// a JSON file that BrowserContext.StorageState writes, or an object that
+// it returns. The path is read as open() reads it in the init context.
+func parseStorageState(ctx context.Context, v goja.Value) (*api.StorageState, error) {
+	if !gojaValueExists(v) {
+		return nil, nil //nolint:nilnil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if path, ok := v.Export().(string); ok {
+		if data, err = k6ext.ReadFile(ctx, path); err != nil {
+			return nil, fmt.Errorf("reading storage state from %q: %w", path, err)
+		}
+	} else if data, err = json.Marshal(v.Export()); err != nil {
+		return nil, fmt.Errorf("encoding storage state: %w", err)
+	}
+	var state api.StorageState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decoding storage state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// BrowserContextStorageStateOptions are the options for
+// BrowserContext.StorageState.
+type BrowserContextStorageStateOptions struct {
+	Path string `js:"path"`
+}
+
+// NewBrowserContextStorageStateOptions returns a new
+// BrowserContextStorageStateOptions.
+func NewBrowserContextStorageStateOptions() *BrowserContextStorageStateOptions {
+	return &BrowserContextStorageStateOptions{}
+}
+
+// Parse parses the storage state options.
+func (o *BrowserContextStorageStateOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(k6ext.Runtime(ctx))
+	for _, k := range obj.Keys() {
+		if k == "path" {
+			o.Path = obj.Get(k).String()
+		}
+	}
+
+	return nil
+}
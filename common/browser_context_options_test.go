@@ -1,11 +1,19 @@
 package common
 
 import (
+	"encoding/json"
+	"net/url"
 	"testing"
 
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
 	"github.com/grafana/xk6-browser/k6ext/k6test"
 
+	k6common "go.k6.io/k6/js/common"
+	k6fsext "go.k6.io/k6/lib/fsext"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBrowserContextOptionsPermissions(t *testing.T) {
@@ -21,3 +29,74 @@ func TestBrowserContextOptionsPermissions(t *testing.T) {
 	assert.Len(t, opts.Permissions, 2)
 	assert.Equal(t, opts.Permissions, []string{"camera", "microphone"})
 }
+
+func TestBrowserContextOptionsStorageState(t *testing.T) {
+	t.Parallel()
+
+	want := &api.StorageState{
+		Cookies: []*api.Cookie{
+			{Name: "session", Value: "abc", Domain: "example.com", Path: "/", Expires: -1, SameSite: "Lax"},
+		},
+		Origins: []*api.OriginStorage{
+			{
+				Origin:       "https://example.com",
+				LocalStorage: []*api.NameValue{{Name: "token", Value: "xyz"}},
+			},
+		},
+	}
+
+	t.Run("object", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		state, err := vu.Runtime().RunString(`({
+			cookies: [{ name: "session", value: "abc", domain: "example.com", path: "/", expires: -1, sameSite: "Lax" }],
+			origins: [{ origin: "https://example.com", localStorage: [{ name: "token", value: "xyz" }] }],
+		})`)
+		require.NoError(t, err)
+
+		var opts BrowserContextOptions
+		require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"storageState": state})))
+		assert.Equal(t, want, opts.StorageState)
+	})
+
+	t.Run("go_value", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		var opts BrowserContextOptions
+		require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"storageState": want})))
+		assert.Equal(t, want, opts.StorageState)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(want)
+		require.NoError(t, err)
+		// the files opened in the init context are in the file system
+		// of the init environment, and relative to the script.
+		fs := k6fsext.NewMemMapFs()
+		require.NoError(t, k6fsext.WriteFile(fs, "/scripts/data/state.json", data, 0o600))
+
+		vu := k6test.NewVU(t)
+		ctx := k6ext.WithInitEnv(vu.Context(), &k6common.InitEnvironment{
+			FileSystems: map[string]k6fsext.Fs{"file": fs},
+			CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+		})
+		for _, path := range []string{"data/state.json", "/scripts/data/state.json"} {
+			var opts BrowserContextOptions
+			require.NoError(t, opts.Parse(ctx, vu.ToGojaValue(map[string]any{"storageState": path})))
+			assert.Equal(t, want, opts.StorageState, path)
+		}
+	})
+
+	t.Run("err_path", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		var opts BrowserContextOptions
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"storageState": "/does/not/exist.json"}))
+		require.ErrorContains(t, err, "reading storage state")
+	})
+}
@@ -2,11 +2,17 @@ package common
 
 import (
 	"context"
+	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/target"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/common/js"
 	"github.com/grafana/xk6-browser/k6ext"
 	"github.com/grafana/xk6-browser/k6ext/k6test"
@@ -42,3 +48,145 @@ func TestNewBrowserContext(t *testing.T) {
 		assert.True(t, webVitalInitScriptFound, "WebVitalInitScript was not initialized in the context")
 	})
 }
+
+func TestBrowserContextInitDownloadsRemote(t *testing.T) {
+	t.Parallel()
+
+	opts := NewBrowserContextOptions()
+	opts.AcceptDownloads = true
+	bc := &BrowserContext{
+		browser: &Browser{browserOpts: NewRemoteBrowserOptions()},
+		opts:    opts,
+	}
+	err := bc.initDownloads()
+	assert.ErrorContains(t, err, "acceptDownloads is not supported with a remote browser")
+}
+
+func TestBrowserContextCloseVideos(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := &Browser{pages: make(map[target.ID]*Page)}
+	bc := &BrowserContext{browser: b}
+	other := &BrowserContext{browser: b}
+	newPage := func(id target.ID, bctx *BrowserContext) *Video {
+		t.Helper()
+
+		v, err := NewVideo(ctx, filepath.Join(t.TempDir(), "page.avi"))
+		require.NoError(t, err)
+		p := &Page{targetID: id, browserCtx: bctx}
+		p.setVideo(v)
+		b.pages[id] = p
+		return v
+	}
+	video := newPage("page", bc)
+	otherVideo := newPage("other", other)
+
+	bc.closeVideos()
+	select {
+	case <-video.done:
+	default:
+		t.Fatal("didn't finish the video of the page of the browser context")
+	}
+	select {
+	case <-otherVideo.done:
+		t.Fatal("finished the video of the page of another browser context")
+	default:
+	}
+}
+
+func TestStorageStateCookies(t *testing.T) {
+	t.Parallel()
+
+	c := &network.Cookie{
+		Name:     "session",
+		Value:    "abc",
+		Domain:   "example.com",
+		Path:     "/",
+		Expires:  1700000000.5,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: network.CookieSameSiteLax,
+	}
+	ac := toAPICookie(c)
+	assert.Equal(t, &api.Cookie{
+		Name:     "session",
+		Value:    "abc",
+		Domain:   "example.com",
+		Path:     "/",
+		Expires:  1700000000.5,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: "Lax",
+	}, ac)
+
+	p := toCookieParam(ac)
+	require.NotNil(t, p.Expires)
+	assert.Equal(t, time.Unix(1700000000, int64(500*time.Millisecond)), p.Expires.Time())
+	assert.Equal(t, network.CookieSameSiteLax, p.SameSite)
+	assert.True(t, p.HTTPOnly)
+
+	c.Session = true
+	ac = toAPICookie(c)
+	assert.EqualValues(t, -1, ac.Expires)
+	assert.Nil(t, toCookieParam(ac).Expires, "session cookies must not expire")
+
+	c.SameSite = ""
+	assert.Equal(t, "Lax", toAPICookie(c).SameSite, "must default to Lax when the browser leaves it out")
+	ac.SameSite = ""
+	assert.Empty(t, toCookieParam(ac).SameSite, "must leave it to the browser when it isn't set")
+}
+
+func TestOriginOf(t *testing.T) {
+	t.Parallel()
+
+	for url, want := range map[string]string{
+		"https://example.com/a/b?c=d": "https://example.com",
+		"http://localhost:8080/":      "http://localhost:8080",
+		"about:blank":                 "",
+		"data:text/html,hello":        "",
+		"file:///tmp/index.html":      "",
+	} {
+		assert.Equal(t, want, originOf(url), url)
+	}
+}
+
+func TestCookieMatchesURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		cookie network.Cookie
+		url    string
+		want   bool
+	}{
+		{"host", network.Cookie{Domain: "example.com", Path: "/"}, "https://example.com/a", true},
+		{"host_mismatch", network.Cookie{Domain: "example.com", Path: "/"}, "https://example.org/", false},
+		{"subdomain_of_host_only", network.Cookie{Domain: "example.com", Path: "/"}, "https://www.example.com/", false},
+		{"domain", network.Cookie{Domain: ".example.com", Path: "/"}, "https://www.example.com/", true},
+		{"domain_suffix", network.Cookie{Domain: ".example.com", Path: "/"}, "https://badexample.com/", false},
+		{"domain_host", network.Cookie{Domain: ".example.com", Path: "/"}, "https://example.com/", true},
+		{"path", network.Cookie{Domain: "example.com", Path: "/api"}, "https://example.com/api/users", true},
+		{"path_mismatch", network.Cookie{Domain: "example.com", Path: "/api"}, "https://example.com/", false},
+		{"path_exact", network.Cookie{Domain: "example.com", Path: "/api"}, "https://example.com/api", true},
+		{"path_segment", network.Cookie{Domain: "example.com", Path: "/api"}, "https://example.com/apiv2", false},
+		{"path_trailing_slash", network.Cookie{Domain: "example.com", Path: "/api/"}, "https://example.com/api/users", true},
+		{"empty_path", network.Cookie{Domain: "example.com", Path: "/"}, "https://example.com", true},
+		{"secure", network.Cookie{Domain: "example.com", Path: "/", Secure: true}, "https://example.com/", true},
+		{"secure_http", network.Cookie{Domain: "example.com", Path: "/", Secure: true}, "http://example.com/", false},
+		{"secure_localhost", network.Cookie{Domain: "localhost", Path: "/", Secure: true}, "http://localhost:8080/", true},
+		{"secure_loopback", network.Cookie{Domain: "127.0.0.1", Path: "/", Secure: true}, "http://127.0.0.1/", true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, cookieMatchesURL(&tc.cookie, u))
+		})
+	}
+}
@@ -138,6 +138,12 @@ func (p *BrowserProcess) Pid() int {
 	return p.meta.Pid()
 }
 
+// DataDir returns the path of the browser data directory, or an empty
+// string if this is unknown.
+func (p *BrowserProcess) DataDir() string {
+	return p.meta.DataDir()
+}
+
 // Cleanup cleans up the metadata associated with the browser
 // process, mainly the browser data directory.
 func (p *BrowserProcess) Cleanup() error {
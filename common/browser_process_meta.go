@@ -15,6 +15,7 @@ const (
 // and the associated browser data directory.
 type browserProcessMeta interface {
 	Pid() int
+	DataDir() string
 	Cleanup() error
 }
 
@@ -41,6 +42,11 @@ func (l *localBrowserProcessMeta) Pid() int {
 	return l.process.Pid
 }
 
+// DataDir returns the path of the local user data directory.
+func (l *localBrowserProcessMeta) DataDir() string {
+	return l.userDataDir.Dir
+}
+
 // Cleanup cleans the local user data directory associated
 // with the local browser process.
 func (l *localBrowserProcessMeta) Cleanup() error {
@@ -62,6 +68,12 @@ func (r *remoteBrowserProcessMeta) Pid() int {
 	return unknownProcessPid
 }
 
+// DataDir returns an empty string, as there is no access
+// to the remote browser's user data directory.
+func (r *remoteBrowserProcessMeta) DataDir() string {
+	return ""
+}
+
 // Cleanup does nothing and returns nil, as there is no
 // access to the remote browser's user data directory.
 func (r *remoteBrowserProcessMeta) Cleanup() error {
@@ -182,6 +182,47 @@ func TestBrowserNewPageInContext(t *testing.T) {
 	})
 }
 
+func TestBrowserNewInternalPageInContext(t *testing.T) {
+	t.Parallel()
+
+	const (
+		browserContextID cdp.BrowserContextID = "42"
+		targetID         target.ID            = "84"
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newBrowser(ctx, cancel, nil, NewLocalBrowserOptions(), log.NewNullLogger())
+	ctx = k6ext.WithVU(ctx, k6test.NewVU(t))
+	var err error
+	b.context, err = NewBrowserContext(ctx, b, browserContextID, nil, nil)
+	require.NoError(t, err)
+
+	b.conn = fakeConn{
+		execute: func(context.Context, string, easyjson.Marshaler, easyjson.Unmarshaler) error {
+			// a popup is not the internal page.
+			popup := &target.Info{Type: "page", OpenerID: "1", BrowserContextID: browserContextID}
+			require.Nil(t, b.takeInternalPage(popup))
+
+			// imitate the browser attaching to the internal page.
+			internalPage := b.takeInternalPage(&target.Info{Type: "page", BrowserContextID: browserContextID})
+			require.NotNil(t, internalPage)
+			p := &Page{targetID: targetID, internal: true}
+			b.pages[targetID] = p
+			internalPage <- p
+			return nil
+		},
+	}
+
+	page, err := b.newInternalPageInContext(browserContextID)
+	require.NoError(t, err)
+	require.NotNil(t, page)
+	require.Equal(t, targetID, page.targetID)
+	require.Empty(t, b.getPages(), "internal page must not be one of the pages")
+	require.Nil(t, b.takeInternalPage(&target.Info{Type: "page", BrowserContextID: browserContextID}),
+		"must not take the pages attached after the internal page")
+}
+
 type fakeConn struct {
 	connection
 	execute func(context.Context, string, easyjson.Marshaler, easyjson.Unmarshaler) error
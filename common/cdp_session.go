@@ -0,0 +1,207 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/target"
+	"github.com/dop251/goja"
+	"github.com/mailru/easyjson"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/log"
+)
+
+// Ensure CDPSession implements the api.CDPSession interface.
+var _ api.CDPSession = &CDPSession{}
+
+// CDPSession is a raw CDP session to a target. It sends the protocol
+// commands and receives the protocol events that the module doesn't wrap.
+type CDPSession struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	conn    connection
+	session *Session
+	logger  *log.Logger
+
+	eventHandlers eventHandlers
+	// taskQueue runs the event handlers on the VU event loop.
+	taskQueueMu sync.Mutex
+	taskQueue   *k6ext.TaskQueue
+}
+
+// NewCDPSession returns a new raw CDP session with the session ID
+// of an attached target.
+func NewCDPSession(
+	ctx context.Context, conn connection, id target.SessionID, logger *log.Logger,
+) (*CDPSession, error) {
+	session := conn.getSession(id)
+	if session == nil {
+		return nil, fmt.Errorf("session %s not found", id)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := CDPSession{
+		ctx:     ctx,
+		cancel:  cancel,
+		conn:    conn,
+		session: session,
+		logger:  logger,
+	}
+	s.initEvents()
+
+	return &s, nil
+}
+
+func (s *CDPSession) initEvents() {
+	ch := make(chan Event)
+	s.session.onAll(s.ctx, ch)
+	go func() {
+		defer s.closeTaskQueue()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-s.session.Done():
+				return
+			case event := <-ch:
+				// The command responses are emitted without a type.
+				if event.typ == "" {
+					continue
+				}
+				s.emit(event.typ, event.data)
+			}
+		}
+	}()
+}
+
+func (s *CDPSession) emit(event string, data any) {
+	if !s.eventHandlers.has(event) {
+		return
+	}
+	params, err := eventParams(data)
+	if err != nil {
+		s.logger.Debugf("CDPSession:emit", "sid:%v event:%q err:%v", s.session.ID(), event, err)
+		return
+	}
+	s.eventHandlers.dispatch(s.queueTask, event, params)
+}
+
+// queueTask queues the task on the task queue of the session.
+// It returns false if there is no task queue to run the task.
+func (s *CDPSession) queueTask(task func() error) bool {
+	s.taskQueueMu.Lock()
+	defer s.taskQueueMu.Unlock()
+
+	if s.taskQueue == nil {
+		return false
+	}
+	s.taskQueue.Queue(task)
+
+	return true
+}
+
+// closeTaskQueue closes the task queue, if any, so that the VU event
+// loop doesn't wait for the event handlers of the session anymore.
+func (s *CDPSession) closeTaskQueue() {
+	s.taskQueueMu.Lock()
+	defer s.taskQueueMu.Unlock()
+
+	if s.taskQueue != nil {
+		s.taskQueue.Close()
+	}
+}
+
+// Detach detaches the session from its target. The session can't be
+// used to send commands after that, and its event handlers are removed.
+func (s *CDPSession) Detach() error {
+	s.logger.Debugf("CDPSession:Detach", "sid:%v tid:%v", s.session.ID(), s.session.TargetID())
+
+	defer s.cancel()
+
+	action := target.DetachFromTarget().WithSessionID(s.session.ID())
+	if err := action.Do(cdp.WithExecutor(s.ctx, s.conn)); err != nil {
+		return fmt.Errorf("detaching CDP session: %w", err)
+	}
+
+	return nil
+}
+
+// isDetached returns true if the session is detached from its target.
+func (s *CDPSession) isDetached() bool {
+	return s.ctx.Err() != nil || s.session.Closed()
+}
+
+// On registers a handler that is called on the VU event loop with the
+// parameters of the protocol event each time the target emits it.
+func (s *CDPSession) On(event string, handler func(any) error) error {
+	s.logger.Debugf("CDPSession:On", "sid:%v event:%q", s.session.ID(), event)
+
+	if s.isDetached() {
+		return ErrCDPSessionDetached
+	}
+
+	s.taskQueueMu.Lock()
+	if s.taskQueue == nil {
+		s.taskQueue = k6ext.NewTaskQueue(k6ext.GetVU(s.ctx).RegisterCallback)
+	}
+	s.taskQueueMu.Unlock()
+	s.eventHandlers.add(event, nil, handler)
+
+	return nil
+}
+
+// Send sends the protocol command with the params to the target and
+// returns a promise that resolves to the result of the command.
+func (s *CDPSession) Send(method string, params goja.Value) *goja.Promise {
+	s.logger.Debugf("CDPSession:Send", "sid:%v method:%q", s.session.ID(), method)
+
+	var cmdParams easyjson.Marshaler
+	if gojaValueExists(params) {
+		buf, err := json.Marshal(params.Export())
+		if err != nil {
+			return k6ext.Promise(s.ctx, func() (any, error) {
+				return nil, fmt.Errorf("encoding %s params: %w", method, err)
+			})
+		}
+		raw := easyjson.RawMessage(buf)
+		cmdParams = &raw
+	}
+
+	return k6ext.Promise(s.ctx, func() (any, error) {
+		if s.isDetached() {
+			return nil, fmt.Errorf("sending %s: %w", method, ErrCDPSessionDetached)
+		}
+		var res easyjson.RawMessage
+		if err := s.session.Execute(s.ctx, method, cmdParams, &res); err != nil {
+			return nil, fmt.Errorf("sending %s: %w", method, err)
+		}
+		if len(res) == 0 {
+			return nil, nil
+		}
+		var result any
+		if err := json.Unmarshal(res, &result); err != nil {
+			return nil, fmt.Errorf("decoding %s result: %w", method, err)
+		}
+		return result, nil
+	})
+}
+
+// eventParams returns the parameters of a protocol event as they are
+// sent by the browser.
+func eventParams(data any) (any, error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding event params: %w", err)
+	}
+	var params any
+	if err := json.Unmarshal(buf, &params); err != nil {
+		return nil, fmt.Errorf("decoding event params: %w", err)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"testing"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCDPSessionEventParams(t *testing.T) {
+	t.Parallel()
+
+	params, err := eventParams(&cdpruntime.EventConsoleAPICalled{
+		Type: cdpruntime.APITypeLog,
+		Args: []*cdpruntime.RemoteObject{
+			{Type: cdpruntime.TypeString, Value: []byte(`"hello"`)},
+		},
+		ExecutionContextID: 1,
+	})
+	require.NoError(t, err)
+	m, ok := params.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "log", m["type"])
+	assert.Equal(t, []any{map[string]any{"type": "string", "value": "hello"}}, m["args"])
+	assert.Equal(t, float64(1), m["executionContextId"])
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	cdpruntime "github.com/chromedp/cdproto/runtime"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+// Ensure ConsoleMessage implements the api.ConsoleMessage interface.
+var _ api.ConsoleMessage = &ConsoleMessage{}
+
+// ConsoleMessage represents a message that a page logs with the console API.
+type ConsoleMessage struct {
+	page     *Page
+	typ      string
+	text     string
+	args     []api.JSHandle
+	location api.ConsoleMessageLocation
+}
+
+// NewConsoleMessage creates a new console message of the page.
+func NewConsoleMessage(
+	p *Page, typ, text string, args []api.JSHandle, location api.ConsoleMessageLocation,
+) *ConsoleMessage {
+	return &ConsoleMessage{
+		page:     p,
+		typ:      typ,
+		text:     text,
+		args:     args,
+		location: location,
+	}
+}
+
+// Args returns the arguments of the console call as JS handles.
+func (m *ConsoleMessage) Args() []api.JSHandle {
+	return m.args
+}
+
+// Location returns the location in the code of the page that the message
+// is logged at.
+func (m *ConsoleMessage) Location() api.ConsoleMessageLocation {
+	return m.location
+}
+
+// Page returns the page that logged the message.
+func (m *ConsoleMessage) Page() api.Page {
+	return m.page
+}
+
+// Text returns the text of the message.
+func (m *ConsoleMessage) Text() string {
+	return m.text
+}
+
+// Type returns the type of the console call, such as log or error.
+func (m *ConsoleMessage) Type() string {
+	return m.typ
+}
+
+// consoleMessageText returns the text of the console call arguments,
+// which are separated by spaces as the browser prints them. The objects
+// are described by the browser, e.g. Array(2), and the primitive values
+// are formatted.
+func consoleMessageText(args []*cdpruntime.RemoteObject) string {
+	texts := make([]string, 0, len(args))
+	for _, a := range args {
+		if a.ObjectID != "" && a.Description != "" {
+			texts = append(texts, a.Description)
+			continue
+		}
+		v, err := parseRemoteObject(a)
+		if err != nil {
+			texts = append(texts, a.Description)
+			continue
+		}
+		texts = append(texts, fmt.Sprint(v))
+	}
+
+	return strings.Join(texts, " ")
+}
+
+// consoleMessageLocation returns the location of the top call frame
+// of the console call, if any.
+func consoleMessageLocation(st *cdpruntime.StackTrace) api.ConsoleMessageLocation {
+	if st == nil || len(st.CallFrames) == 0 {
+		return api.ConsoleMessageLocation{}
+	}
+	cf := st.CallFrames[0]
+
+	return api.ConsoleMessageLocation{
+		URL:          cf.URL,
+		LineNumber:   cf.LineNumber,
+		ColumnNumber: cf.ColumnNumber,
+	}
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+func TestConsoleMessageText(t *testing.T) {
+	t.Parallel()
+
+	args := []*runtime.RemoteObject{
+		{Type: runtime.TypeString, Value: easyjson.RawMessage(`"total:"`)},
+		{Type: runtime.TypeNumber, Value: easyjson.RawMessage(`42`)},
+		{Type: runtime.TypeBoolean, Value: easyjson.RawMessage(`true`)},
+		{Type: runtime.TypeUndefined},
+		{Type: runtime.TypeObject, Subtype: runtime.SubtypeArray, ObjectID: "1", Description: "Array(2)"},
+	}
+	assert.Equal(t, "total: 42 true undefined Array(2)", consoleMessageText(args))
+	assert.Empty(t, consoleMessageText(nil))
+}
+
+func TestConsoleMessageLocation(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, api.ConsoleMessageLocation{}, consoleMessageLocation(nil))
+	assert.Equal(t, api.ConsoleMessageLocation{}, consoleMessageLocation(&runtime.StackTrace{}))
+
+	st := &runtime.StackTrace{
+		CallFrames: []*runtime.CallFrame{
+			{URL: "https://example.com/app.js", LineNumber: 10, ColumnNumber: 4},
+			{URL: "https://example.com/lib.js", LineNumber: 1, ColumnNumber: 1},
+		},
+	}
+	assert.Equal(t, api.ConsoleMessageLocation{
+		URL:          "https://example.com/app.js",
+		LineNumber:   10,
+		ColumnNumber: 4,
+	}, consoleMessageLocation(st))
+}
@@ -5,10 +5,12 @@ import "time"
 const (
 	// Defaults
 
-	DefaultLocale       string        = "en-US"
-	DefaultScreenWidth  int64         = 1280
-	DefaultScreenHeight int64         = 720
-	DefaultTimeout      time.Duration = 30 * time.Second
+	DefaultKeyboardLayout  string        = "us"
+	DefaultLocale          string        = "en-US"
+	DefaultScreenWidth     int64         = 1280
+	DefaultScreenHeight    int64         = 720
+	DefaultTestIDAttribute string        = "data-testid"
+	DefaultTimeout         time.Duration = 30 * time.Second
 
 	// Life-cycle consts
 
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/log"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdppage "github.com/chromedp/cdproto/page"
+)
+
+// Ensure Dialog implements the api.Dialog interface.
+var _ api.Dialog = &Dialog{}
+
+// errDialogHandled is returned when a dialog is handled more than once.
+var errDialogHandled = errors.New("dialog is already handled")
+
+// Dialog represents a JavaScript dialog opened by a page.
+// The page is blocked until the dialog is either accepted or dismissed.
+//
+// The dialog handlers run on the VU event loop, so a synchronous call that
+// opens a dialog, such as page.evaluate or page.click, hangs: it blocks the
+// event loop until the dialog is handled, and the handlers never run.
+// Such dialogs must be opened asynchronously, e.g. by the page code.
+type Dialog struct {
+	ctx          context.Context
+	session      session
+	typ          cdppage.DialogType
+	message      string
+	defaultValue string
+	logger       *log.Logger
+
+	handledMu sync.Mutex
+	handled   bool
+}
+
+// NewDialog creates a new dialog from the dialog opening event.
+func NewDialog(ctx context.Context, s session, event *cdppage.EventJavascriptDialogOpening, l *log.Logger) *Dialog {
+	return &Dialog{
+		ctx:          ctx,
+		session:      s,
+		typ:          event.Type,
+		message:      event.Message,
+		defaultValue: event.DefaultPrompt,
+		logger:       l,
+	}
+}
+
+// Accept accepts the dialog. The prompt text is entered into the dialog
+// if it's a prompt.
+func (d *Dialog) Accept(promptText string) {
+	d.logger.Debugf("Dialog:Accept", "sid:%v type:%s", d.session.ID(), d.typ)
+
+	if err := d.handle(true, promptText); err != nil {
+		k6ext.Panic(d.ctx, "accepting dialog: %w", err)
+	}
+}
+
+// DefaultValue returns the default prompt value if the dialog is a prompt,
+// or an empty string otherwise.
+func (d *Dialog) DefaultValue() string {
+	return d.defaultValue
+}
+
+// Dismiss dismisses the dialog.
+func (d *Dialog) Dismiss() {
+	d.logger.Debugf("Dialog:Dismiss", "sid:%v type:%s", d.session.ID(), d.typ)
+
+	if err := d.handle(false, ""); err != nil {
+		k6ext.Panic(d.ctx, "dismissing dialog: %w", err)
+	}
+}
+
+// Message returns the message displayed in the dialog.
+func (d *Dialog) Message() string {
+	return d.message
+}
+
+// Type returns the type of the dialog: alert, beforeunload, confirm or prompt.
+func (d *Dialog) Type() string {
+	return d.typ.String()
+}
+
+// handleByDefault handles the dialog as the page does without dialog
+// handlers, unless it's already handled.
+func (d *Dialog) handleByDefault() error {
+	// Dialog type of beforeunload needs to accept the
+	// dialog, instead of dismissing it. We're unable to
+	// dismiss beforeunload dialog boxes at the moment as
+	// it seems to pause the exec of any other action on
+	// the page. I believe this is an issue in Chromium.
+	err := d.handle(d.typ == cdppage.DialogTypeBeforeunload, "")
+	if errors.Is(err, errDialogHandled) {
+		return nil
+	}
+
+	return err
+}
+
+func (d *Dialog) handle(accept bool, promptText string) error {
+	d.handledMu.Lock()
+	defer d.handledMu.Unlock()
+
+	if d.handled {
+		return errDialogHandled
+	}
+	d.handled = true
+
+	action := cdppage.HandleJavaScriptDialog(accept)
+	if promptText != "" {
+		action = action.WithPromptText(promptText)
+	}
+	if err := action.Do(cdp.WithExecutor(d.ctx, d.session)); err != nil {
+		return fmt.Errorf("handling dialog: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,366 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto"
+	cdpbrowser "github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/log"
+)
+
+// Ensure Download implements the api.Download interface.
+var _ api.Download = &Download{}
+
+// downloadCanceled is the failure of the downloads that are canceled,
+// such as the downloads of a browser context that doesn't accept them.
+const downloadCanceled = "canceled"
+
+// Download is a file downloaded by a page. The file is downloaded into
+// the downloads directory of the browser context, and it's deleted when
+// the browser context is closed.
+type Download struct {
+	ctx               context.Context
+	page              *Page
+	url               string
+	suggestedFilename string
+	// path is the path of the file in the downloads directory.
+	path string
+
+	// done is closed when the download is finished.
+	done     chan struct{}
+	doneOnce sync.Once
+	failure  string
+}
+
+// NewDownload returns a new download of the page into the path.
+func NewDownload(ctx context.Context, p *Page, url, suggestedFilename, path string) *Download {
+	return &Download{
+		ctx:               ctx,
+		page:              p,
+		url:               url,
+		suggestedFilename: suggestedFilename,
+		path:              path,
+		done:              make(chan struct{}),
+	}
+}
+
+// Delete waits for the download to finish and deletes the file.
+func (d *Download) Delete() error {
+	if err := d.wait(); err != nil {
+		return err
+	}
+	if d.failure != "" {
+		return nil
+	}
+	if err := os.Remove(d.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting download: %w", err)
+	}
+
+	return nil
+}
+
+// Failure waits for the download to finish and returns the reason of
+// its failure, or an empty string if it succeeded.
+func (d *Download) Failure() (string, error) {
+	if err := d.wait(); err != nil {
+		return "", err
+	}
+
+	return d.failure, nil
+}
+
+// Page returns the page that started the download.
+func (d *Download) Page() api.Page {
+	return d.page
+}
+
+// Path waits for the download to finish and returns the path of the file.
+// It returns an error if the download failed.
+func (d *Download) Path() (string, error) {
+	if err := d.wait(); err != nil {
+		return "", err
+	}
+	if d.failure != "" {
+		return "", fmt.Errorf("download failed: %s", d.failure)
+	}
+
+	return d.path, nil
+}
+
+// SaveAs waits for the download to finish and copies the file to the path.
+func (d *Download) SaveAs(path string) error {
+	src, err := d.Path()
+	if err != nil {
+		return fmt.Errorf("saving download: %w", err)
+	}
+	if err := copyFile(src, path); err != nil {
+		return fmt.Errorf("saving download: %w", err)
+	}
+
+	return nil
+}
+
+// SuggestedFilename returns the name of the file that the browser
+// suggests for the download.
+func (d *Download) SuggestedFilename() string {
+	return d.suggestedFilename
+}
+
+// URL returns the URL of the download.
+func (d *Download) URL() string {
+	return d.url
+}
+
+func (d *Download) wait() error {
+	// A finished download is preferred to a done context,
+	// so that the download can be used after its context is done.
+	select {
+	case <-d.done:
+		return nil
+	default:
+	}
+	select {
+	case <-d.done:
+		return nil
+	case <-d.ctx.Done():
+		return fmt.Errorf("waiting for download to finish: %w", d.ctx.Err())
+	}
+}
+
+// finish finishes the download with the failure, or with an empty
+// failure if it succeeded.
+func (d *Download) finish(failure string) {
+	d.doneOnce.Do(func() {
+		d.failure = failure
+		close(d.done)
+	})
+}
+
+// copyFile copies the file at src to dst, creating the directory of dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("opening %q: %w", src, err)
+	}
+	defer in.Close() //nolint:errcheck
+
+	dir := filepath.Dir(dst)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating directory %q: %w", dir, err)
+	}
+	out, err := os.Create(dst) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("creating %q: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("copying %q to %q: %w", src, dst, err)
+	}
+
+	return out.Close() //nolint:wrapcheck
+}
+
+// downloadManager routes the downloads of the pages of a browser context
+// into the downloads directory of the browser context, and emits them to
+// their pages.
+type downloadManager struct {
+	ctx        context.Context
+	cancel     context.CancelFunc
+	browserCtx *BrowserContext
+	dir        string
+	logger     *log.Logger
+
+	mu        sync.Mutex
+	downloads map[string]*downloadInProgress
+}
+
+// downloadInProgress is a download with the time that it started at,
+// to measure its duration.
+type downloadInProgress struct {
+	*Download
+	start time.Time
+}
+
+// newDownloadManager creates the downloads directory of the browser
+// context in the data directory, or in the temporary directory if the
+// data directory is unknown, and sets the download behavior of the
+// browser context. The downloads directory is created only if the
+// downloads are accepted, as the denied downloads aren't saved.
+func newDownloadManager(
+	ctx context.Context, bctx *BrowserContext, dataDir string, accept bool, logger *log.Logger,
+) (*downloadManager, error) {
+	var dir string
+	if accept {
+		var err error
+		if dir, err = os.MkdirTemp(dataDir, "xk6-browser-downloads-*"); err != nil {
+			return nil, fmt.Errorf("creating downloads directory: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m := downloadManager{
+		ctx:        ctx,
+		cancel:     cancel,
+		browserCtx: bctx,
+		dir:        dir,
+		logger:     logger,
+		downloads:  make(map[string]*downloadInProgress),
+	}
+	m.initEvents()
+
+	action := cdpbrowser.SetDownloadBehavior(cdpbrowser.SetDownloadBehaviorBehaviorDeny)
+	if accept {
+		action = cdpbrowser.SetDownloadBehavior(cdpbrowser.SetDownloadBehaviorBehaviorAllowAndName).
+			WithDownloadPath(dir)
+	}
+	action = action.WithBrowserContextID(bctx.id).WithEventsEnabled(true)
+	if err := action.Do(cdp.WithExecutor(ctx, bctx.browser.conn)); err != nil {
+		m.close()
+		return nil, fmt.Errorf("setting download behavior: %w", err)
+	}
+
+	return &m, nil
+}
+
+func (m *downloadManager) initEvents() {
+	ch := make(chan Event)
+	m.browserCtx.browser.conn.on(m.ctx, []string{
+		cdproto.EventBrowserDownloadWillBegin,
+		cdproto.EventBrowserDownloadProgress,
+	}, ch)
+	go func() {
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case event := <-ch:
+				switch ev := event.data.(type) {
+				case *cdpbrowser.EventDownloadWillBegin:
+					m.onDownloadWillBegin(ev)
+				case *cdpbrowser.EventDownloadProgress:
+					m.onDownloadProgress(ev)
+				}
+			}
+		}
+	}()
+}
+
+func (m *downloadManager) onDownloadWillBegin(ev *cdpbrowser.EventDownloadWillBegin) {
+	m.logger.Debugf("downloadManager:onDownloadWillBegin", "bctxid:%v fid:%v guid:%s url:%q",
+		m.browserCtx.id, ev.FrameID, ev.GUID, ev.URL)
+
+	p := m.pageOfFrame(ev.FrameID)
+	if p == nil {
+		m.logger.Debugf("downloadManager:onDownloadWillBegin", "bctxid:%v fid:%v: page not found",
+			m.browserCtx.id, ev.FrameID)
+		return
+	}
+	d := NewDownload(m.ctx, p, ev.URL, ev.SuggestedFilename, filepath.Join(m.dir, ev.GUID))
+
+	m.mu.Lock()
+	m.downloads[ev.GUID] = &downloadInProgress{Download: d, start: time.Now()}
+	m.mu.Unlock()
+
+	p.emit(EventPageDownload, d)
+}
+
+func (m *downloadManager) onDownloadProgress(ev *cdpbrowser.EventDownloadProgress) {
+	if ev.State == cdpbrowser.DownloadProgressStateInProgress {
+		return
+	}
+
+	m.mu.Lock()
+	d, ok := m.downloads[ev.GUID]
+	delete(m.downloads, ev.GUID)
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	m.logger.Debugf("downloadManager:onDownloadProgress", "bctxid:%v guid:%s state:%s",
+		m.browserCtx.id, ev.GUID, ev.State)
+
+	if ev.State == cdpbrowser.DownloadProgressStateCanceled {
+		d.finish(downloadCanceled)
+		return
+	}
+	m.emitMetrics(d, ev.ReceivedBytes)
+	d.finish("")
+}
+
+// emitMetrics emits the size and the duration metrics of the download.
+func (m *downloadManager) emitMetrics(d *downloadInProgress, size float64) {
+	vu := m.browserCtx.vu
+	state := vu.State()
+	if state == nil {
+		return
+	}
+	customMetrics := k6ext.GetCustomMetrics(m.ctx)
+
+	now := time.Now()
+	tags := state.Tags.GetCurrentValues().Tags
+	if state.Options.SystemTags.Has(k6metrics.TagURL) {
+		tags = tags.With("url", d.url)
+	}
+	k6metrics.PushIfNotDone(vu.Context(), state.Samples, k6metrics.ConnectedSamples{
+		Samples: []k6metrics.Sample{
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: customMetrics.BrowserDownloadSize, Tags: tags},
+				Value:      size,
+				Time:       now,
+			},
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: customMetrics.BrowserDownloadDuration, Tags: tags},
+				Value:      k6metrics.D(now.Sub(d.start)),
+				Time:       now,
+			},
+		},
+	})
+}
+
+// pageOfFrame returns the page of the browser context that has the frame.
+func (m *downloadManager) pageOfFrame(id cdp.FrameID) *Page {
+	b := m.browserCtx.browser
+	b.pagesMu.RLock()
+	defer b.pagesMu.RUnlock()
+
+	for _, p := range b.pages {
+		if p.browserCtx == m.browserCtx && p.frameManager.getFrameByID(id) != nil {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// close stops routing the downloads, cancels the unfinished ones, and
+// deletes the downloads directory.
+func (m *downloadManager) close() {
+	m.mu.Lock()
+	for guid, d := range m.downloads {
+		d.finish(downloadCanceled)
+		delete(m.downloads, guid)
+	}
+	m.mu.Unlock()
+
+	m.cancel()
+
+	if m.dir == "" {
+		return
+	}
+	if err := os.RemoveAll(m.dir); err != nil {
+		m.logger.Debugf("downloadManager:close", "bctxid:%v removing %q: %v", m.browserCtx.id, m.dir, err)
+	}
+}
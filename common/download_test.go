@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("finished", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "guid")
+		require.NoError(t, os.WriteFile(path, []byte("report"), 0o600))
+
+		d := NewDownload(context.Background(), nil, "http://host/report", "report.csv", path)
+		d.finish("")
+		// a download is finished only once.
+		d.finish(downloadCanceled)
+
+		failure, err := d.Failure()
+		require.NoError(t, err)
+		assert.Empty(t, failure)
+		got, err := d.Path()
+		require.NoError(t, err)
+		assert.Equal(t, path, got)
+		assert.Equal(t, "report.csv", d.SuggestedFilename())
+		assert.Equal(t, "http://host/report", d.URL())
+
+		saved := filepath.Join(dir, "saved", "report.csv")
+		require.NoError(t, d.SaveAs(saved))
+		data, err := os.ReadFile(saved) //nolint:gosec
+		require.NoError(t, err)
+		assert.Equal(t, "report", string(data))
+
+		require.NoError(t, d.Delete())
+		assert.NoFileExists(t, path)
+		// deleting a deleted download is a no-op.
+		require.NoError(t, d.Delete())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+
+		d := NewDownload(context.Background(), nil, "http://host/report", "report.csv", "")
+		d.finish(downloadCanceled)
+
+		failure, err := d.Failure()
+		require.NoError(t, err)
+		assert.Equal(t, downloadCanceled, failure)
+		_, err = d.Path()
+		assert.ErrorContains(t, err, "download failed: canceled")
+		assert.ErrorContains(t, d.SaveAs(filepath.Join(t.TempDir(), "report.csv")), "download failed")
+		assert.NoError(t, d.Delete())
+	})
+
+	t.Run("context_done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		d := NewDownload(ctx, nil, "http://host/report", "report.csv", "")
+		cancel()
+
+		_, err := d.Failure()
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = d.Path()
+		assert.ErrorIs(t, err, context.Canceled)
+
+		// a finished download can be used after its context is done.
+		d.finish("")
+		failure, err := d.Failure()
+		require.NoError(t, err)
+		assert.Empty(t, failure)
+	})
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/chromedp/cdproto"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/input"
+)
+
+// dragDetectionScript listens for the drags that the next mouse move
+// starts in a frame. It leaves a function on the window that removes the
+// listeners and reports whether a drag has started.
+//
+// A drag is only started after a mousemove event, so the dragstart
+// listener is added once the page has handled the mousemove event.
+const dragDetectionScript = `() => {
+	let onMouseMove = null;
+	let onDragStart = null;
+	const didStartDrag = new Promise(resolve => {
+		onDragStart = () => resolve(true);
+		onMouseMove = () => {
+			window.addEventListener("dragstart", onDragStart, { once: true, capture: true });
+			setTimeout(() => resolve(false), 0);
+		};
+		window.addEventListener("mousemove", onMouseMove, { once: true, capture: true });
+	});
+	window.__k6browserCleanupDrag = async () => {
+		const result = await didStartDrag;
+		window.removeEventListener("mousemove", onMouseMove, { capture: true });
+		window.removeEventListener("dragstart", onDragStart, { capture: true });
+		delete window.__k6browserCleanupDrag;
+		return result;
+	};
+}`
+
+// dragCleanupScript removes the listeners of dragDetectionScript and
+// returns whether a drag has started in the frame.
+const dragCleanupScript = `() => window.__k6browserCleanupDrag ? window.__k6browserCleanupDrag() : false`
+
+// dragManager intercepts the HTML5 drags that the mouse starts, and
+// drives them with Input.dispatchDragEvent. The browser can't finish a
+// drag that it receives as synthetic mouse events on its own.
+//
+// The mouse moves that don't start a drag are dispatched as they are,
+// so that the drag and drop libraries that only listen for the mouse
+// events keep working.
+type dragManager struct {
+	ctx     context.Context
+	session session
+	manager *FrameManager
+
+	mu sync.Mutex
+	// data is the data of the drag in progress, if any.
+	data *input.DragData
+}
+
+// newDragManager returns a new drag manager that detects the drags
+// in the frames of the frame manager.
+func newDragManager(ctx context.Context, s session, m *FrameManager) *dragManager {
+	return &dragManager{
+		ctx:     ctx,
+		session: s,
+		manager: m,
+	}
+}
+
+// isDragging returns true if a drag is in progress.
+func (d *dragManager) isDragging() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.data != nil
+}
+
+// interceptDragCausedByMove calls move, which moves the mouse to x and y,
+// and intercepts the drag that the move starts, if any. It dispatches a
+// dragover event instead of calling move if a drag is in progress.
+func (d *dragManager) interceptDragCausedByMove(
+	x, y float64, button input.MouseButton, modifiers input.Modifier, move func() error,
+) error {
+	if d.isDragging() {
+		return d.dispatch(input.DragOver, x, y, modifiers)
+	}
+	// Only a move with the left button pressed can start a drag.
+	if button != input.Left {
+		return move()
+	}
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+
+	ch := make(chan Event, 1)
+	d.session.on(ctx, []string{cdproto.EventInputDragIntercepted}, ch)
+
+	frames := d.frames()
+	for _, f := range frames {
+		if _, err := f.evalInUtilityWorldIfReady(dragDetectionScript); err != nil {
+			return fmt.Errorf("detecting drag in frame %s: %w", f.ID(), err)
+		}
+	}
+	if err := input.SetInterceptDrags(true).Do(cdp.WithExecutor(ctx, d.session)); err != nil {
+		return fmt.Errorf("intercepting drags: %w", err)
+	}
+	defer func() {
+		// The session can be closed at this point, and there is nothing
+		// to stop intercepting then.
+		_ = input.SetInterceptDrags(false).Do(cdp.WithExecutor(d.ctx, d.session))
+	}()
+	if err := move(); err != nil {
+		return err
+	}
+
+	var didStartDrag bool
+	for _, f := range frames {
+		v, err := f.evalInUtilityWorldIfReady(dragCleanupScript)
+		if err != nil {
+			return fmt.Errorf("detecting drag in frame %s: %w", f.ID(), err)
+		}
+		if v != nil && v.ToBoolean() {
+			didStartDrag = true
+		}
+	}
+	if !didStartDrag {
+		return nil
+	}
+
+	var data *input.DragData
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("intercepting drag: %w", ctx.Err())
+	case <-d.session.Done():
+		return fmt.Errorf("intercepting drag: session %s closed", d.session.ID())
+	case event := <-ch:
+		ev, ok := event.data.(*input.EventDragIntercepted)
+		if !ok {
+			return fmt.Errorf("unexpected drag intercepted event type: %T", event.data)
+		}
+		data = ev.Data
+	}
+
+	d.mu.Lock()
+	d.data = data
+	d.mu.Unlock()
+
+	return d.dispatch(input.DragEnter, x, y, modifiers)
+}
+
+// drop drops the dragged data at x and y, which finishes the drag.
+func (d *dragManager) drop(x, y float64, modifiers input.Modifier) error {
+	err := d.dispatch(input.Drop, x, y, modifiers)
+
+	d.mu.Lock()
+	d.data = nil
+	d.mu.Unlock()
+
+	return err
+}
+
+// frames returns the frames to detect the drags in.
+func (d *dragManager) frames() []*Frame {
+	d.manager.framesMu.RLock()
+	defer d.manager.framesMu.RUnlock()
+
+	frames := make([]*Frame, 0, len(d.manager.frames))
+	for _, f := range d.manager.frames {
+		frames = append(frames, f)
+	}
+
+	return frames
+}
+
+func (d *dragManager) dispatch(typ input.DispatchDragEventType, x, y float64, modifiers input.Modifier) error {
+	d.mu.Lock()
+	data := d.data
+	d.mu.Unlock()
+
+	action := input.DispatchDragEvent(typ, x, y, data).WithModifiers(modifiers)
+	if err := action.Do(cdp.WithExecutor(d.ctx, d.session)); err != nil {
+		return fmt.Errorf("dispatching %s drag event: %w", typ, err)
+	}
+
+	return nil
+}
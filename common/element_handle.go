@@ -587,6 +587,27 @@ func (h *ElementHandle) selectOption(apiCtx context.Context, values goja.Value)
 	return result, nil
 }
 
+func (h *ElementHandle) setInputFiles(apiCtx context.Context, files *Files) error {
+	fn := `
+		(node, injected, payloads) => {
+			return injected.setInputFiles(node, payloads);
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := h.evalWithScript(apiCtx, opts, fn, files.Payloads)
+	if err != nil {
+		return err
+	}
+	if result != "done" {
+		return errorFromDOMError(fmt.Sprint(result))
+	}
+
+	return nil
+}
+
 func (h *ElementHandle) selectText(apiCtx context.Context) error {
 	fn := `
 		(node, injected) => {
@@ -639,19 +660,166 @@ func (h *ElementHandle) typ(apiCtx context.Context, text string, opts *KeyboardO
 	return nil
 }
 
-func (h *ElementHandle) waitAndScrollIntoViewIfNeeded(apiCtx context.Context, force, noWaitAfter bool, timeout time.Duration) error {
-	fn := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
-		fn := `
-			(element) => {
-				element.scrollIntoViewIfNeeded(true);
-				return [window.scrollX, window.scrollY];
+// scrollIntoViewIfNeeded scrolls the element into the center of the
+// view, unless it's already entirely visible, and returns the scroll
+// position of the window.
+func (h *ElementHandle) scrollIntoViewIfNeeded(apiCtx context.Context) (any, error) {
+	fn := `
+		(element) => {
+			element.scrollIntoViewIfNeeded(true);
+			return [window.scrollX, window.scrollY];
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	return h.eval(apiCtx, opts, fn)
+}
+
+// maxWheelScrolls is the number of the mouse wheel scrolls after which
+// wheelIntoViewIfNeeded gives up scrolling an element into view.
+const maxWheelScrolls = 10
+
+// wheelIntoViewIfNeeded scrolls the element into view with the mouse
+// wheel, unless it's already entirely visible. Unlike scrollIntoViewIfNeeded,
+// the page receives wheel events, so the pages that load more content on
+// them, such as infinite scroll feeds, work as they do for the users.
+//
+// The scroll containers of the element in its frame are scrolled from the
+// outermost to the innermost, each by moving the mouse over its visible
+// area and turning the wheel until the element is centered in it.
+func (h *ElementHandle) wheelIntoViewIfNeeded(apiCtx context.Context) (any, error) {
+	position, err := h.frame.position()
+	if err != nil {
+		return nil, err
+	}
+	mouse := h.frame.page.Mouse
+	for i := 0; ; i++ {
+		scroll, err := h.nextWheelScroll(apiCtx)
+		if err != nil {
+			return nil, err
+		}
+		if scroll == nil {
+			return nil, nil //nolint:nilnil
+		}
+		if i == maxWheelScrolls {
+			return nil, fmt.Errorf("element is not in view after %d mouse wheel scrolls", maxWheelScrolls)
+		}
+		if err := mouse.move(position.X+scroll.x, position.Y+scroll.y, NewMouseMoveOptions()); err != nil {
+			return nil, fmt.Errorf("moving mouse over scroll container: %w", err)
+		}
+		if err := mouse.wheel(scroll.deltaX, scroll.deltaY); err != nil {
+			return nil, fmt.Errorf("turning mouse wheel: %w", err)
+		}
+	}
+}
+
+// wheelScroll is a mouse wheel scroll at the x and y coordinates
+// of the frame of an element.
+type wheelScroll struct {
+	x, y, deltaX, deltaY float64
+}
+
+// nextWheelScroll waits for the scroll containers of the element to stop
+// scrolling, and returns the next mouse wheel scroll that brings the
+// element into view, or nil if the element doesn't need to be scrolled.
+func (h *ElementHandle) nextWheelScroll(apiCtx context.Context) (*wheelScroll, error) {
+	fn := `
+		async (element) => {
+			const scrollable = (overflow) => ['auto', 'scroll', 'overlay'].includes(overflow);
+			const root = document.scrollingElement || document.documentElement;
+			const containers = [];
+			for (let e = element.parentElement || element.getRootNode().host; e;
+				e = e.parentElement || e.getRootNode().host) {
+				if (e === document.documentElement || e === document.body) {
+					continue;
+				}
+				const style = getComputedStyle(e);
+				if (scrollable(style.overflowX) || scrollable(style.overflowY)) {
+					containers.unshift(e);
+				}
+			}
+			containers.unshift(root);
+
+			const positions = () => containers.map(c => c.scrollLeft + ',' + c.scrollTop).join(';');
+			for (let last = null, i = 0; i < 60; i++) {
+				const current = positions();
+				if (current === last) {
+					break;
+				}
+				last = current;
+				await new Promise(resolve => setTimeout(resolve, 16));
+			}
+
+			const viewport = { left: 0, top: 0, right: root.clientWidth, bottom: root.clientHeight };
+			const delta = (start, end, viewStart, viewEnd, scroll, maxScroll) => {
+				if (start >= viewStart && end <= viewEnd) {
+					return 0;
+				}
+				const d = (start + end) / 2 - (viewStart + viewEnd) / 2;
+				return Math.max(-scroll, Math.min(d, maxScroll - scroll));
+			};
+			const rect = element.getBoundingClientRect();
+			for (const c of containers) {
+				let view = viewport;
+				if (c !== root) {
+					const r = c.getBoundingClientRect();
+					const left = r.left + c.clientLeft, top = r.top + c.clientTop;
+					view = {
+						left: Math.max(left, viewport.left),
+						top: Math.max(top, viewport.top),
+						right: Math.min(left + c.clientWidth, viewport.right),
+						bottom: Math.min(top + c.clientHeight, viewport.bottom),
+					};
+					if (view.left >= view.right || view.top >= view.bottom) {
+						continue;
+					}
+				}
+				const dx = delta(rect.left, rect.right, view.left, view.right, c.scrollLeft, c.scrollWidth - c.clientWidth);
+				const dy = delta(rect.top, rect.bottom, view.top, view.bottom, c.scrollTop, c.scrollHeight - c.clientHeight);
+				if (Math.abs(dx) >= 1 || Math.abs(dy) >= 1) {
+					return [(view.left + view.right) / 2, (view.top + view.bottom) / 2, dx, dy];
+				}
 			}
-		`
-		opts := evalOptions{
-			forceCallable: true,
-			returnByValue: true,
+			return [];
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := h.eval(apiCtx, opts, fn)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := result.(goja.Value)
+	if !ok || !gojaValueExists(v) {
+		return nil, fmt.Errorf("unexpected scroll of element: %v", result)
+	}
+	scroll, ok := v.Export().([]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected scroll of element: %v", v)
+	}
+	if len(scroll) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	var coords [4]float64
+	if len(scroll) != len(coords) {
+		return nil, fmt.Errorf("unexpected scroll of element: %v", scroll)
+	}
+	for i := range coords {
+		if coords[i], ok = scroll[i].(float64); !ok {
+			return nil, fmt.Errorf("unexpected scroll of element: %v", scroll)
 		}
-		return h.eval(apiCtx, opts, fn)
+	}
+
+	return &wheelScroll{x: coords[0], y: coords[1], deltaX: coords[2], deltaY: coords[3]}, nil
+}
+
+func (h *ElementHandle) waitAndScrollIntoViewIfNeeded(apiCtx context.Context, force, noWaitAfter bool, timeout time.Duration) error {
+	fn := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return handle.scrollIntoViewIfNeeded(apiCtx)
 	}
 	actFn := h.newAction([]string{"visible", "stable"}, fn, force, noWaitAfter, timeout)
 	_, err := call(h.ctx, actFn, timeout)
@@ -1245,10 +1413,26 @@ func (h *ElementHandle) SelectText(opts goja.Value) {
 	applySlowMo(h.ctx)
 }
 
-// SetInputFiles is not implemented.
+// SetInputFiles sets the files of the input[type=file] element.
+// The files can be a path, an object with name, mimeType and buffer
+// properties, or an array of them. An empty array clears the files.
 func (h *ElementHandle) SetInputFiles(files goja.Value, opts goja.Value) {
-	// TODO: implement
-	k6ext.Panic(h.ctx, "ElementHandle.setInputFiles() has not been implemented yet")
+	actionOpts := NewElementHandleBaseOptions(h.defaultTimeout())
+	if err := actionOpts.Parse(h.ctx, opts); err != nil {
+		k6ext.Panic(h.ctx, "parsing setInputFiles options: %w", err)
+	}
+	parsedFiles := NewFiles()
+	if err := parsedFiles.Parse(h.ctx, files); err != nil {
+		k6ext.Panic(h.ctx, "parsing setInputFiles files: %w", err)
+	}
+	fn := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.setInputFiles(apiCtx, parsedFiles)
+	}
+	actFn := h.newAction([]string{}, fn, actionOpts.Force, actionOpts.NoWaitAfter, actionOpts.Timeout)
+	if _, err := call(h.ctx, actFn, actionOpts.Timeout); err != nil {
+		k6ext.Panic(h.ctx, "setting input files: %w", err)
+	}
+	applySlowMo(h.ctx)
 }
 
 func (h *ElementHandle) Tap(opts goja.Value) {
@@ -1545,6 +1729,7 @@ func errorFromDOMError(v any) error {
 		"error:notselect":              "element is not a <select> element",
 		"error:notcheckbox":            "not a checkbox or radio button",
 		"error:notmultiplefileinput":   "non-multiple file input can only accept single file",
+		"error:notfileinput":           "node is not an input[type=file] element",
 		"error:strictmodeviolation":    "strict mode violation, multiple elements returned for selector query",
 		"error:notqueryablenode":       "node is not queryable",
 		"error:nthnocapture":           "can't query n-th element in a chained selector with capture",
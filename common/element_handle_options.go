@@ -2,6 +2,10 @@ package common
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -88,6 +92,18 @@ type ElementHandleScreenshotOptions struct {
 	Timeout        time.Duration `json:"timeout"`
 }
 
+// File is a file to set on an input[type=file] element.
+type File struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mimeType"`
+	Buffer   []byte `json:"buffer"` // encoded as base64 in JSON
+}
+
+// Files are the files to set on an input[type=file] element.
+type Files struct {
+	Payloads []*File `json:"payloads"`
+}
+
 type ElementHandleSetCheckedOptions struct {
 	ElementHandleBasePointerOptions
 	Strict bool `json:"strict"`
@@ -488,3 +504,94 @@ func NewElementHandleDispatchEventOptions(defaultTimeout time.Duration) *Element
 		ElementHandleBaseOptions: NewElementHandleBaseOptions(defaultTimeout),
 	}
 }
+
+// NewFiles returns a new Files.
+func NewFiles() *Files {
+	return &Files{
+		Payloads: []*File{},
+	}
+}
+
+// Parse parses the files to set on an input[type=file] element.
+// The files can be a path, an object with name, mimeType and buffer
+// properties, or an array of them. The paths are read as open() reads
+// them in the init context, so the files must be opened there first for
+// them to be in the archive of the test.
+func (f *Files) Parse(ctx context.Context, files goja.Value) error {
+	if !gojaValueExists(files) {
+		return nil
+	}
+	obj, ok := files.(*goja.Object)
+	if !ok || obj.ClassName() != "Array" {
+		return f.addFile(ctx, files)
+	}
+	for i := int64(0); i < obj.Get("length").ToInteger(); i++ {
+		if err := f.addFile(ctx, obj.Get(fmt.Sprint(i))); err != nil {
+			return fmt.Errorf("files[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// addFile adds a file from a path or an object with name, mimeType and
+// buffer properties.
+func (f *Files) addFile(ctx context.Context, file goja.Value) error {
+	if !gojaValueExists(file) {
+		return fmt.Errorf("expected a path or a file object, got %v", file)
+	}
+	if path, ok := file.Export().(string); ok {
+		buffer, err := k6ext.ReadFile(ctx, path)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		f.Payloads = append(f.Payloads, &File{
+			Name:     filepath.Base(path),
+			MimeType: mimeTypeOf(path),
+			Buffer:   buffer,
+		})
+		return nil
+	}
+
+	var (
+		obj     = file.ToObject(k6ext.Runtime(ctx))
+		payload = &File{}
+	)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "name":
+			payload.Name = obj.Get(k).String()
+		case "mimeType":
+			payload.MimeType = obj.Get(k).String()
+		case "buffer":
+			switch v := obj.Get(k).Export().(type) {
+			case []byte:
+				payload.Buffer = v
+			case goja.ArrayBuffer:
+				payload.Buffer = v.Bytes()
+			case string:
+				payload.Buffer = []byte(v)
+			default:
+				return fmt.Errorf("unsupported file buffer type %T", v)
+			}
+		}
+	}
+	if payload.Name == "" {
+		return errors.New("missing file name")
+	}
+	if payload.MimeType == "" {
+		payload.MimeType = mimeTypeOf(payload.Name)
+	}
+	f.Payloads = append(f.Payloads, payload)
+
+	return nil
+}
+
+// mimeTypeOf returns the MIME type of the file name by its extension.
+func mimeTypeOf(name string) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+
+	return "application/octet-stream"
+}
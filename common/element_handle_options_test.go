@@ -0,0 +1,90 @@
+package common
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	k6common "go.k6.io/k6/js/common"
+	k6fsext "go.k6.io/k6/lib/fsext"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("path", func(t *testing.T) {
+		t.Parallel()
+
+		// the files opened in the init context are in the file system
+		// of the init environment, e.g. in the archive of the test.
+		fs := k6fsext.NewMemMapFs()
+		require.NoError(t, k6fsext.WriteFile(fs, "/scripts/file.txt", []byte("hello"), 0o600))
+		vu := k6test.NewVU(t)
+		ctx := k6ext.WithInitEnv(vu.Context(), &k6common.InitEnvironment{
+			FileSystems: map[string]k6fsext.Fs{"file": fs},
+			CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+		})
+
+		files := NewFiles()
+		require.NoError(t, files.Parse(ctx, vu.ToGojaValue("file.txt")))
+
+		require.Len(t, files.Payloads, 1)
+		assert.Equal(t, "file.txt", files.Payloads[0].Name)
+		assert.Contains(t, files.Payloads[0].MimeType, "text/plain")
+		assert.Equal(t, []byte("hello"), files.Payloads[0].Buffer)
+	})
+
+	t.Run("buffers", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		v, err := vu.Runtime().RunString(`[
+			{ name: "a.json", mimeType: "application/x-custom", buffer: new Uint8Array([104, 105]).buffer },
+			{ name: "b.json", buffer: "{}" },
+		]`)
+		require.NoError(t, err)
+
+		files := NewFiles()
+		require.NoError(t, files.Parse(vu.Context(), v))
+
+		require.Len(t, files.Payloads, 2)
+		assert.Equal(t, &File{Name: "a.json", MimeType: "application/x-custom", Buffer: []byte("hi")}, files.Payloads[0])
+		assert.Equal(t, &File{Name: "b.json", MimeType: "application/json", Buffer: []byte("{}")}, files.Payloads[1])
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		files := NewFiles()
+		require.NoError(t, files.Parse(vu.Context(), vu.ToGojaValue([]any{})))
+		assert.Empty(t, files.Payloads)
+	})
+
+	t.Run("err/missing_name", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		files := NewFiles()
+		err := files.Parse(vu.Context(), vu.ToGojaValue([]any{
+			map[string]any{"buffer": "data"},
+		}))
+		assert.EqualError(t, err, "files[0]: missing file name")
+	})
+
+	t.Run("err/missing_file", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		files := NewFiles()
+		err := files.Parse(vu.Context(), vu.ToGojaValue(filepath.Join(t.TempDir(), "missing.txt")))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
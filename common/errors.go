@@ -17,6 +17,7 @@ func (e Error) Error() string {
 // Error types.
 const (
 	ErrUnexpectedRemoteObjectWithID Error = "cannot extract value when remote object ID is given"
+	ErrCDPSessionDetached           Error = "CDP session is detached"
 	ErrChannelClosed                Error = "channel closed"
 	ErrFrameDetached                Error = "frame detached"
 	ErrJSHandleDisposed             Error = "JS handle is disposed"
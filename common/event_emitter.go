@@ -51,6 +51,13 @@ const (
 
 	EventSessionClosed string = "close"
 
+	// WebSocket
+
+	EventWebSocketClose         string = "close"
+	EventWebSocketError         string = "socketerror"
+	EventWebSocketFrameReceived string = "framereceived"
+	EventWebSocketFrameSent     string = "framesent"
+
 	// Worker
 
 	EventWorkerClose string = "close"
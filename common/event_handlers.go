@@ -0,0 +1,105 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dop251/goja"
+)
+
+// eventHandlerEntry is a handler registered for an event with the On method
+// of an object, such as a page. The key identifies the handler to remove it,
+// such as the JS function that the handler calls.
+type eventHandlerEntry struct {
+	key     goja.Value
+	handler func(any) error
+}
+
+// eventHandlers are the handlers registered for the events of an object,
+// which are called on the VU event loop each time the object emits the
+// event. It is safe for concurrent use, and its zero value is ready to use.
+type eventHandlers struct {
+	mu       sync.RWMutex
+	handlers map[string][]eventHandlerEntry
+}
+
+// add registers the handler for the event. Handlers are called in the order
+// they're registered.
+func (eh *eventHandlers) add(event string, key goja.Value, handler func(any) error) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	if eh.handlers == nil {
+		eh.handlers = make(map[string][]eventHandlerEntry)
+	}
+	eh.handlers[event] = append(eh.handlers[event], eventHandlerEntry{key: key, handler: handler})
+}
+
+// remove removes the handlers registered for the event with the key.
+// The handlers that are registered without a key can't be removed.
+func (eh *eventHandlers) remove(event string, key goja.Value) {
+	eh.mu.Lock()
+	defer eh.mu.Unlock()
+
+	if !gojaValueExists(key) || len(eh.handlers[event]) == 0 {
+		return
+	}
+	handlers := make([]eventHandlerEntry, 0, len(eh.handlers[event]))
+	for _, h := range eh.handlers[event] {
+		if !gojaValueExists(h.key) || !h.key.StrictEquals(key) {
+			handlers = append(handlers, h)
+		}
+	}
+	eh.handlers[event] = handlers
+}
+
+// has returns true if there are handlers registered for the event.
+func (eh *eventHandlers) has(event string) bool {
+	eh.mu.RLock()
+	defer eh.mu.RUnlock()
+
+	return len(eh.handlers[event]) > 0
+}
+
+// get returns a copy of the handlers registered for the event.
+func (eh *eventHandlers) get(event string) []eventHandlerEntry {
+	eh.mu.RLock()
+	defer eh.mu.RUnlock()
+
+	handlers := make([]eventHandlerEntry, len(eh.handlers[event]))
+	copy(handlers, eh.handlers[event])
+
+	return handlers
+}
+
+// dispatch queues the handlers registered for the event to be called with
+// the event data on the VU event loop with queueTask.
+func (eh *eventHandlers) dispatch(queueTask func(func() error) bool, event string, data any) {
+	handlers := eh.get(event)
+	if len(handlers) == 0 {
+		return
+	}
+	queueTask(func() error {
+		return callEventHandlers(handlers, event, data)
+	})
+}
+
+// dispatchDeferred is like dispatch, but the handlers are looked up when
+// the task runs on the VU event loop. So, the handlers that the tasks queued
+// before it register, such as the handlers that a page's websocket event
+// handler registers on the WebSocket, are called with the event.
+func (eh *eventHandlers) dispatchDeferred(queueTask func(func() error) bool, event string, data any) {
+	queueTask(func() error {
+		return callEventHandlers(eh.get(event), event, data)
+	})
+}
+
+func callEventHandlers(handlers []eventHandlerEntry, event string, data any) error {
+	for _, h := range handlers {
+		if err := h.handler(data); err != nil {
+			return fmt.Errorf("calling %q event handler: %w", event, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventHandlers(t *testing.T) {
+	t.Parallel()
+
+	rt := goja.New()
+	keyA, err := rt.RunString(`() => {}`)
+	require.NoError(t, err)
+	keyB, err := rt.RunString(`() => {}`)
+	require.NoError(t, err)
+
+	var (
+		eh     eventHandlers
+		called []string
+	)
+	handler := func(name string) func(any) error {
+		return func(data any) error {
+			called = append(called, name+":"+data.(string)) //nolint:forcetypeassert
+			return nil
+		}
+	}
+	dispatch := func(event, data string) {
+		eh.dispatch(func(task func() error) bool {
+			require.NoError(t, task())
+			return true
+		}, event, data)
+	}
+
+	assert.False(t, eh.has("event"))
+	eh.remove("event", keyA)
+
+	eh.add("event", keyA, handler("a"))
+	eh.add("event", keyB, handler("b"))
+	eh.add("event", nil, handler("c"))
+	assert.True(t, eh.has("event"))
+	assert.False(t, eh.has("other"))
+
+	dispatch("event", "1")
+	assert.Equal(t, []string{"a:1", "b:1", "c:1"}, called)
+
+	called = nil
+	eh.remove("event", keyA)
+	eh.remove("event", goja.Undefined())
+	dispatch("event", "2")
+	dispatch("other", "2")
+	assert.Equal(t, []string{"b:2", "c:2"}, called)
+}
+
+func TestEventHandlersDispatchDeferred(t *testing.T) {
+	t.Parallel()
+
+	var (
+		eh     eventHandlers
+		tasks  []func() error
+		called []any
+	)
+	queueTask := func(task func() error) bool {
+		tasks = append(tasks, task)
+		return true
+	}
+	eh.dispatchDeferred(queueTask, "event", "1")
+	eh.dispatch(queueTask, "event", "2")
+	// the handler is registered after the events are dispatched,
+	// but before the tasks run on the event loop.
+	eh.add("event", nil, func(data any) error {
+		called = append(called, data)
+		return nil
+	})
+	for _, task := range tasks {
+		require.NoError(t, task())
+	}
+	assert.Equal(t, []any{"1"}, called)
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"github.com/dop251/goja"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+// Ensure FileChooser implements the api.FileChooser interface.
+var _ api.FileChooser = &FileChooser{}
+
+// FileChooser represents a file chooser that a page opens to select the
+// files of an input[type=file] element. The file chooser is intercepted
+// instead of opening the file dialog of the browser, so the files are
+// set with SetFiles.
+type FileChooser struct {
+	page       *Page
+	element    *ElementHandle
+	isMultiple bool
+}
+
+// NewFileChooser creates a new file chooser of the page for the element.
+func NewFileChooser(p *Page, h *ElementHandle, isMultiple bool) *FileChooser {
+	return &FileChooser{
+		page:       p,
+		element:    h,
+		isMultiple: isMultiple,
+	}
+}
+
+// Element returns the input element that the file chooser is opened for.
+func (fc *FileChooser) Element() api.ElementHandle {
+	return fc.element
+}
+
+// IsMultiple returns true if the file chooser accepts multiple files.
+func (fc *FileChooser) IsMultiple() bool {
+	return fc.isMultiple
+}
+
+// Page returns the page that opened the file chooser.
+func (fc *FileChooser) Page() api.Page {
+	return fc.page
+}
+
+// SetFiles sets the files of the input element.
+func (fc *FileChooser) SetFiles(files goja.Value, opts goja.Value) {
+	fc.element.SetInputFiles(files, opts)
+}
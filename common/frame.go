@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
@@ -39,8 +40,10 @@ type Frame struct {
 	manager     *FrameManager
 	parentFrame *Frame
 
+	// childFrames are in the order that they're attached in,
+	// which is the document order for the frames of the document.
 	childFramesMu sync.RWMutex
-	childFrames   map[api.Frame]bool
+	childFrames   []*Frame
 
 	propertiesMu sync.RWMutex
 	id           cdp.FrameID
@@ -94,7 +97,6 @@ func NewFrame(
 		page:              m.page,
 		manager:           m,
 		parentFrame:       parentFrame,
-		childFrames:       make(map[api.Frame]bool),
 		id:                frameID,
 		vu:                k6ext.GetVU(ctx),
 		lifecycleEvents:   make(map[LifecycleEvent]bool),
@@ -113,7 +115,7 @@ func (f *Frame) addChildFrame(child *Frame) {
 	f.childFramesMu.Lock()
 	defer f.childFramesMu.Unlock()
 
-	f.childFrames[child] = true
+	f.childFrames = append(f.childFrames, child)
 }
 
 func (f *Frame) addRequest(id network.RequestID) {
@@ -354,7 +356,12 @@ func (f *Frame) removeChildFrame(child *Frame) {
 	f.childFramesMu.Lock()
 	defer f.childFramesMu.Unlock()
 
-	delete(f.childFrames, child)
+	for i, c := range f.childFrames {
+		if c == child {
+			f.childFrames = append(f.childFrames[:i:i], f.childFrames[i+1:]...)
+			break
+		}
+	}
 }
 
 func (f *Frame) requestByID(reqID network.RequestID) *Request {
@@ -473,16 +480,119 @@ func (f *Frame) waitFor(selector string, opts *FrameWaitForSelectorOptions) erro
 	return err
 }
 
-// AddScriptTag is not implemented.
-func (f *Frame) AddScriptTag(opts goja.Value) {
-	k6ext.Panic(f.ctx, "Frame.AddScriptTag() has not been implemented yet")
+// addScriptTagScript adds a script element with the url or the content
+// to the document, and resolves to the element once the script loads.
+const addScriptTagScript = `async (url, content, type) => {
+	const script = document.createElement("script");
+	if (type) {
+		script.type = type;
+	}
+	if (url) {
+		script.src = url;
+		const loaded = new Promise((resolve, reject) => {
+			script.onload = resolve;
+			script.onerror = () => reject(new Error("loading script from " + url));
+		});
+		document.head.appendChild(script);
+		await loaded;
+		return script;
+	}
+	script.text = content;
+	document.head.appendChild(script);
+	return script;
+}`
+
+// addStyleTagScript adds a link element with the url or a style element
+// with the content to the document, and resolves to the element once the
+// stylesheet loads.
+const addStyleTagScript = `async (url, content) => {
+	let style;
+	if (url) {
+		style = document.createElement("link");
+		style.rel = "stylesheet";
+		style.href = url;
+	} else {
+		style = document.createElement("style");
+		style.type = "text/css";
+		style.appendChild(document.createTextNode(content));
+	}
+	const loaded = new Promise((resolve, reject) => {
+		style.onload = resolve;
+		style.onerror = () => reject(new Error(url ? "loading stylesheet from " + url : "adding style"));
+	});
+	document.head.appendChild(style);
+	if (url) {
+		await loaded;
+	}
+	return style;
+}`
+
+// AddScriptTag adds a script tag with the URL, the content of the file
+// at the path, or the content to the frame, and returns the added element
+// once the script is loaded.
+func (f *Frame) AddScriptTag(opts goja.Value) (api.ElementHandle, error) {
+	f.log.Debugf("Frame:AddScriptTag", "fid:%s furl:%q", f.ID(), f.URL())
+
+	popts := NewFrameAddScriptTagOptions()
+	if err := popts.Parse(f.ctx, opts); err != nil {
+		return nil, fmt.Errorf("parsing add script tag options: %w", err)
+	}
+	h, err := f.addTag(addScriptTagScript, popts.URL, popts.Content, popts.Type)
+	if err != nil {
+		return nil, fmt.Errorf("adding script tag: %w", err)
+	}
+
 	applySlowMo(f.ctx)
+
+	return h, nil
 }
 
-// AddStyleTag is not implemented.
-func (f *Frame) AddStyleTag(opts goja.Value) {
-	k6ext.Panic(f.ctx, "Frame.AddStyleTag() has not been implemented yet")
+// AddStyleTag adds a link tag with the URL, or a style tag with the content
+// of the file at the path or the content to the frame, and returns the
+// added element once the stylesheet is loaded.
+func (f *Frame) AddStyleTag(opts goja.Value) (api.ElementHandle, error) {
+	f.log.Debugf("Frame:AddStyleTag", "fid:%s furl:%q", f.ID(), f.URL())
+
+	popts := NewFrameAddStyleTagOptions()
+	if err := popts.Parse(f.ctx, opts); err != nil {
+		return nil, fmt.Errorf("parsing add style tag options: %w", err)
+	}
+	h, err := f.addTag(addStyleTagScript, popts.URL, popts.Content)
+	if err != nil {
+		return nil, fmt.Errorf("adding style tag: %w", err)
+	}
+
 	applySlowMo(f.ctx)
+
+	return h, nil
+}
+
+// addTag evaluates the script that adds a tag to the document of the
+// frame in the main world, and returns the added element.
+func (f *Frame) addTag(js string, args ...any) (*ElementHandle, error) {
+	f.waitForExecutionContext(mainWorld)
+
+	f.executionContextMu.RLock()
+	ec := f.executionContexts[mainWorld]
+	f.executionContextMu.RUnlock()
+	if ec == nil {
+		return nil, fmt.Errorf("execution context %q not found", mainWorld)
+	}
+
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: false,
+	}
+	result, err := ec.eval(f.ctx, opts, js, args...)
+	if err != nil {
+		return nil, err
+	}
+	h, ok := result.(*ElementHandle)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T", result)
+	}
+
+	return h, nil
 }
 
 // ChildFrames returns a list of child frames.
@@ -491,12 +601,26 @@ func (f *Frame) ChildFrames() []api.Frame {
 	defer f.childFramesMu.RUnlock()
 
 	l := make([]api.Frame, 0, len(f.childFrames))
-	for child := range f.childFrames {
+	for _, child := range f.childFrames {
 		l = append(l, child)
 	}
 	return l
 }
 
+// frameTree returns the frame and its descendant frames in document order.
+func (f *Frame) frameTree() []*Frame {
+	f.childFramesMu.RLock()
+	children := make([]*Frame, len(f.childFrames))
+	copy(children, f.childFrames)
+	f.childFramesMu.RUnlock()
+
+	frames := []*Frame{f}
+	for _, child := range children {
+		frames = append(frames, child.frameTree()...)
+	}
+	return frames
+}
+
 // Click clicks the first element found that matches selector.
 func (f *Frame) Click(selector string, opts goja.Value) error {
 	f.log.Debugf("Frame:Click", "fid:%s furl:%q sel:%q", f.ID(), f.URL(), selector)
@@ -709,6 +833,59 @@ func (f *Frame) dispatchEvent(selector, typ string, eventInit goja.Value, opts *
 	return nil
 }
 
+// DragAndDrop drags the element matching the source selector and
+// drops it onto the element matching the target selector.
+func (f *Frame) DragAndDrop(source, target string, opts goja.Value) {
+	f.log.Debugf("Frame:DragAndDrop", "fid:%s furl:%q src:%q tgt:%q", f.ID(), f.URL(), source, target)
+
+	popts := NewFrameDragAndDropOptions(f.defaultTimeout())
+	if err := popts.Parse(f.ctx, opts); err != nil {
+		k6ext.Panic(f.ctx, "parsing drag and drop options: %w", err)
+	}
+	if err := f.dragAndDrop(source, target, popts); err != nil {
+		k6ext.Panic(f.ctx, "dragging %q to %q: %w", source, target, err)
+	}
+	applySlowMo(f.ctx)
+}
+
+// dragAndDrop is like DragAndDrop but takes parsed options and neither throws
+// an error, or applies slow motion.
+//
+// It presses the mouse button over the source element, and releases it
+// over the target element. The mouse moves in between start a drag that
+// is either handled by the page with the mouse events, or intercepted
+// and dropped onto the target as an HTML5 drag.
+func (f *Frame) dragAndDrop(source, target string, opts *FrameDragAndDropOptions) error {
+	mouse := f.page.Mouse
+	press := func(apiCtx context.Context, _ *ElementHandle, p *Position) (any, error) {
+		if err := mouse.move(p.X, p.Y, NewMouseMoveOptions()); err != nil {
+			return nil, err
+		}
+		return nil, mouse.down(p.X, p.Y, NewMouseDownUpOptions())
+	}
+	act := f.newPointerAction(
+		source, DOMElementStateAttached, opts.Strict, press, opts.pointerOptions(opts.SourcePosition),
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return fmt.Errorf("pressing on the source: %w", errorFromDOMError(err))
+	}
+
+	release := func(apiCtx context.Context, _ *ElementHandle, p *Position) (any, error) {
+		if err := mouse.move(p.X, p.Y, NewMouseMoveOptions()); err != nil {
+			return nil, err
+		}
+		return nil, mouse.up(p.X, p.Y, NewMouseDownUpOptions())
+	}
+	act = f.newPointerAction(
+		target, DOMElementStateAttached, opts.Strict, release, opts.pointerOptions(opts.TargetPosition),
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return fmt.Errorf("releasing on the target: %w", errorFromDOMError(err))
+	}
+
+	return nil
+}
+
 // Evaluate will evaluate provided page function within an execution context.
 func (f *Frame) Evaluate(pageFunc goja.Value, args ...goja.Value) any {
 	f.log.Debugf("Frame:Evaluate", "fid:%s furl:%q", f.ID(), f.URL())
@@ -1261,6 +1438,90 @@ func (f *Frame) isVisible(selector string, opts *FrameIsVisibleOptions) (bool, e
 	return bv, nil
 }
 
+// GetByAltText creates and returns a new locator for the elements
+// with the given alt text.
+func (f *Frame) GetByAltText(text goja.Value, opts goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByAltText", "fid:%s furl:%q text:%q opts:%+v", f.ID(), f.URL(), text, opts)
+
+	selector, err := getByAttributeSelector(f.ctx, "alt", text, opts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by alt text %q: %w", text, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// GetByLabel creates and returns a new locator for the elements that
+// are labelled with the given text.
+func (f *Frame) GetByLabel(text goja.Value, opts goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByLabel", "fid:%s furl:%q text:%q opts:%+v", f.ID(), f.URL(), text, opts)
+
+	selector, err := getByLabelSelector(f.ctx, text, opts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by label %q: %w", text, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// GetByPlaceholder creates and returns a new locator for the input
+// elements with the given placeholder text.
+func (f *Frame) GetByPlaceholder(text goja.Value, opts goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByPlaceholder", "fid:%s furl:%q text:%q opts:%+v", f.ID(), f.URL(), text, opts)
+
+	selector, err := getByAttributeSelector(f.ctx, "placeholder", text, opts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by placeholder %q: %w", text, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// GetByRole creates and returns a new locator for the elements with
+// the given ARIA role.
+func (f *Frame) GetByRole(role string, opts goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByRole", "fid:%s furl:%q role:%q opts:%+v", f.ID(), f.URL(), role, opts)
+
+	selector, err := getByRoleSelector(f.ctx, role, opts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by role %q: %w", role, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// GetByTestID creates and returns a new locator for the elements with
+// the given test ID. The test ID attribute is set with the testIdAttribute
+// option of the browser context, and defaults to data-testid.
+func (f *Frame) GetByTestID(testID goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByTestID", "fid:%s furl:%q testID:%q", f.ID(), f.URL(), testID)
+
+	selector, err := getByTestIDSelector(f.ctx, f.testIDAttribute(), testID)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by test ID %q: %w", testID, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// GetByTitle creates and returns a new locator for the elements with
+// the given title.
+func (f *Frame) GetByTitle(text goja.Value, opts goja.Value) api.Locator {
+	f.log.Debugf("Frame:GetByTitle", "fid:%s furl:%q text:%q opts:%+v", f.ID(), f.URL(), text, opts)
+
+	selector, err := getByAttributeSelector(f.ctx, "title", text, opts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "getting by title %q: %w", text, err)
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log)
+}
+
+// testIDAttribute returns the attribute that GetByTestID uses.
+func (f *Frame) testIDAttribute() string {
+	return f.manager.page.browserCtx.opts.TestIDAttribute
+}
+
 // ID returns the frame id.
 func (f *Frame) ID() string {
 	f.propertiesMu.RLock()
@@ -1273,7 +1534,30 @@ func (f *Frame) ID() string {
 func (f *Frame) Locator(selector string, opts goja.Value) api.Locator {
 	f.log.Debugf("Frame:Locator", "fid:%s furl:%q selector:%q opts:%+v", f.ID(), f.URL(), selector, opts)
 
-	return NewLocator(f.ctx, selector, f, f.log)
+	lopts := NewLocatorFilterOptions()
+	if err := lopts.Parse(f.ctx, opts); err != nil {
+		k6ext.Panic(f.ctx, "parsing locator options: %w", err)
+	}
+	l, err := f.locator(selector, lopts)
+	if err != nil {
+		k6ext.Panic(f.ctx, "creating locator for %q: %w", selector, err)
+	}
+
+	return l
+}
+
+// locator is like Locator but takes parsed options and does not throw
+// an error.
+func (f *Frame) locator(selector string, opts *LocatorFilterOptions) (*Locator, error) {
+	if opts.Has != nil && opts.Has.frame != f {
+		return nil, errors.New("inner has locator must belong to the same frame")
+	}
+	selector, err := opts.selector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocator(f.ctx, selector, f, f.log), nil
 }
 
 // LoaderID returns the ID of the frame that loaded this frame.
@@ -1315,6 +1599,130 @@ func (f *Frame) QueryAll(selector string) ([]api.ElementHandle, error) {
 	return document.QueryAll(selector)
 }
 
+// count returns the number of elements matching the selector
+// in the document tree.
+func (f *Frame) count(selector string) (int, error) {
+	parsedSelector, err := NewSelector(selector)
+	if err != nil {
+		return 0, fmt.Errorf("parsing selector %q: %w", selector, err)
+	}
+	document, err := f.document()
+	if err != nil {
+		return 0, fmt.Errorf("getting document: %w", err)
+	}
+	fn := `
+		(node, injected, selector) => {
+			return injected.querySelectorAll(selector, node || document).length;
+		}
+	`
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := document.evalWithScript(f.ctx, opts, fn, parsedSelector)
+	if err != nil {
+		return 0, fmt.Errorf("counting elements: %w", err)
+	}
+	v, ok := result.(goja.Value)
+	if !ok {
+		return 0, fmt.Errorf("counting elements: unexpected type %T", result)
+	}
+
+	return int(v.ToInteger()), nil
+}
+
+// localStorage returns the items of the local storage of the frame's origin.
+func (f *Frame) localStorage() ([]*api.NameValue, error) {
+	fn := `() => JSON.stringify(Object.keys(localStorage).map(name => ({
+		name, value: localStorage.getItem(name),
+	})))`
+	v, err := f.evalInMainWorld(fn)
+	if err != nil {
+		return nil, err
+	}
+	var items []*api.NameValue
+	if err := json.Unmarshal([]byte(v.String()), &items); err != nil {
+		return nil, fmt.Errorf("decoding local storage: %w", err)
+	}
+
+	return items, nil
+}
+
+// setLocalStorage sets the items of the local storage of the frame's origin.
+func (f *Frame) setLocalStorage(items []*api.NameValue) error {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("encoding local storage: %w", err)
+	}
+	fn := `(items) => {
+		for (const { name, value } of JSON.parse(items)) {
+			localStorage.setItem(name, value);
+		}
+	}`
+	_, err = f.evalInMainWorld(fn, string(data))
+
+	return err
+}
+
+// evalInMainWorld evaluates the function in the main execution context
+// of the frame and returns its result by value.
+func (f *Frame) evalInMainWorld(fn string, args ...any) (goja.Value, error) {
+	f.waitForExecutionContext(mainWorld)
+
+	f.executionContextMu.RLock()
+	ec := f.executionContexts[mainWorld]
+	f.executionContextMu.RUnlock()
+	if ec == nil {
+		return nil, fmt.Errorf("execution context %q not found", mainWorld)
+	}
+
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := ec.eval(f.ctx, opts, fn, args...)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := result.(goja.Value)
+	if !ok && result != nil {
+		return nil, fmt.Errorf("unexpected type %T", result)
+	}
+	if v == nil {
+		v = goja.Undefined()
+	}
+
+	return v, nil
+}
+
+// evalInUtilityWorldIfReady evaluates the function in the utility
+// execution context of the frame and returns its result by value.
+// Unlike evalInMainWorld, it doesn't wait for the execution context,
+// and returns nil if the frame doesn't have one yet.
+func (f *Frame) evalInUtilityWorldIfReady(fn string, args ...any) (goja.Value, error) {
+	f.executionContextMu.RLock()
+	ec := f.executionContexts[utilityWorld]
+	f.executionContextMu.RUnlock()
+	if ec == nil {
+		return nil, nil
+	}
+
+	opts := evalOptions{
+		forceCallable: true,
+		returnByValue: true,
+	}
+	result, err := ec.eval(f.ctx, opts, fn, args...)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := result.(goja.Value)
+	if !ok && result != nil {
+		return nil, fmt.Errorf("unexpected type %T", result)
+	}
+
+	return v, nil
+}
+
 // Page returns page that owns frame.
 func (f *Frame) Page() api.Page {
 	return f.manager.page
@@ -1355,6 +1763,24 @@ func (f *Frame) press(selector, key string, opts *FramePressOptions) error {
 	return nil
 }
 
+// scrollIntoViewIfNeeded waits for the element matching the selector to
+// be visible and stable, and scrolls it into view with the mouse wheel
+// unless it's already entirely visible.
+func (f *Frame) scrollIntoViewIfNeeded(selector string, opts *FrameScrollIntoViewIfNeededOptions) error {
+	scroll := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return handle.wheelIntoViewIfNeeded(apiCtx)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, opts.Strict, scroll, []string{"visible", "stable"},
+		opts.Force, opts.NoWaitAfter, opts.Timeout,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
+}
+
 // SelectOption selects the given options and returns the array of
 // option values of the first element found that matches the selector.
 func (f *Frame) SelectOption(selector string, values goja.Value, opts goja.Value) []string {
@@ -1440,10 +1866,39 @@ func (f *Frame) SetContent(html string, opts goja.Value) {
 	applySlowMo(f.ctx)
 }
 
-// SetInputFiles is not implemented.
+// SetInputFiles sets the files of the first input[type=file] element
+// found that matches the selector.
 func (f *Frame) SetInputFiles(selector string, files goja.Value, opts goja.Value) {
-	k6ext.Panic(f.ctx, "Frame.setInputFiles(selector, files, opts) has not been implemented yet")
-	// TODO: needs slowMo
+	f.log.Debugf("Frame:SetInputFiles", "fid:%s furl:%q sel:%q", f.ID(), f.URL(), selector)
+
+	popts := NewFrameSetInputFilesOptions(f.defaultTimeout())
+	if err := popts.Parse(f.ctx, opts); err != nil {
+		k6ext.Panic(f.ctx, "parsing set input files options: %w", err)
+	}
+	pfiles := NewFiles()
+	if err := pfiles.Parse(f.ctx, files); err != nil {
+		k6ext.Panic(f.ctx, "parsing set input files: %w", err)
+	}
+	if err := f.setInputFiles(selector, pfiles, popts); err != nil {
+		k6ext.Panic(f.ctx, "setting input files on %q: %w", selector, err)
+	}
+
+	applySlowMo(f.ctx)
+}
+
+func (f *Frame) setInputFiles(selector string, files *Files, opts *FrameSetInputFilesOptions) error {
+	setInputFiles := func(apiCtx context.Context, handle *ElementHandle) (any, error) {
+		return nil, handle.setInputFiles(apiCtx, files)
+	}
+	act := f.newAction(
+		selector, DOMElementStateAttached, opts.Strict, setInputFiles,
+		[]string{}, opts.Force, opts.NoWaitAfter, opts.Timeout,
+	)
+	if _, err := call(f.ctx, act, opts.Timeout); err != nil {
+		return errorFromDOMError(err)
+	}
+
+	return nil
 }
 
 // Tap the first element that matches the selector.
@@ -313,10 +313,10 @@ func (m *FrameManager) frameNavigated(frameID cdp.FrameID, parentFrameID cdp.Fra
 	frame.clearLifecycle()
 	frame.emit(EventFrameNavigation, &NavigationEvent{url: url, name: name, newDocument: frame.currentDocument})
 
-	// TODO: when we add API support for storage we need to track origins
-	// if !initial {
-	// 	//f.page.frameNavigatedToNewDocument(f)
-	// }
+	if !initial && m.page != nil && m.page.browserCtx != nil {
+		// Track the origins to export their storage with StorageState.
+		m.page.browserCtx.addOrigin(url)
+	}
 
 	// Restore pending if any (see comments above about keepPending).
 	frame.pendingDocument = keepPending
@@ -508,6 +508,12 @@ func (m *FrameManager) requestReceivedResponse(res *Response) {
 	m.page.emit(EventPageResponse, res)
 }
 
+func (m *FrameManager) webSocketCreated(ws *WebSocket) {
+	m.logger.Debugf("FrameManager:webSocketCreated", "fmid:%d wsurl:%s", m.ID(), ws.URL())
+
+	m.page.emit(EventPageWebSocket, ws)
+}
+
 func (m *FrameManager) requestStarted(req *Request) {
 	m.logger.Debugf("FrameManager:requestStarted", "fmid:%d rurl:%s", m.ID(), req.URL())
 
@@ -542,6 +548,30 @@ func (m *FrameManager) Frames() []api.Frame {
 	return frames
 }
 
+// frame returns the first frame that matches the options, or nil if
+// no frame matches. The frames are matched in the document order of the
+// frame tree: the main frame first, then its child frames recursively.
+func (m *FrameManager) frame(opts *PageFrameOptions) (*Frame, error) {
+	mainFrame := m.MainFrame()
+	if mainFrame == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	// The frames are matched outside of the locks, as the URL predicates
+	// are JS functions that can take a while or call back into the page.
+	for _, f := range mainFrame.frameTree() {
+		ok, err := opts.matches(f)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return f, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
 // MainFrame returns the main frame of the page.
 func (m *FrameManager) MainFrame() *Frame {
 	m.mainFrameMu.RLock()
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
@@ -11,6 +12,30 @@ import (
 	"github.com/grafana/xk6-browser/k6ext"
 )
 
+// FrameAddScriptTagOptions are the options for adding a script tag
+// to a frame. One of URL, Path or Content is required.
+type FrameAddScriptTagOptions struct {
+	// URL is the URL of the script to load.
+	URL string `json:"url"`
+	// Path is the path of a file whose content is added as the script.
+	Path string `json:"path"`
+	// Content is the JS source of the script.
+	Content string `json:"content"`
+	// Type is the type of the script, e.g. module.
+	Type string `json:"type"`
+}
+
+// FrameAddStyleTagOptions are the options for adding a style tag
+// to a frame. One of URL, Path or Content is required.
+type FrameAddStyleTagOptions struct {
+	// URL is the URL of the stylesheet to load.
+	URL string `json:"url"`
+	// Path is the path of a file whose content is added as the style.
+	Path string `json:"path"`
+	// Content is the CSS source of the style.
+	Content string `json:"content"`
+}
+
 type FrameBaseOptions struct {
 	Timeout time.Duration `json:"timeout"`
 	Strict  bool          `json:"strict"`
@@ -31,6 +56,22 @@ type FrameDblclickOptions struct {
 	Strict bool `json:"strict"`
 }
 
+// FrameDragAndDropOptions are the options for dragging an element
+// and dropping it onto another one.
+type FrameDragAndDropOptions struct {
+	ElementHandleBaseOptions
+	// SourcePosition is the point relative to the top-left corner of
+	// the source element to start the drag at. It defaults to a visible
+	// point of the element.
+	SourcePosition *Position `json:"sourcePosition"`
+	// TargetPosition is the point relative to the top-left corner of
+	// the target element to drop at. It defaults to a visible point of
+	// the element.
+	TargetPosition *Position `json:"targetPosition"`
+	Trial          bool      `json:"trial"`
+	Strict         bool      `json:"strict"`
+}
+
 type FrameFillOptions struct {
 	ElementHandleBaseOptions
 	Strict bool `json:"strict"`
@@ -88,11 +129,22 @@ type FramePressOptions struct {
 	Strict bool `json:"strict"`
 }
 
+type FrameScrollIntoViewIfNeededOptions struct {
+	ElementHandleBaseOptions
+	Strict bool `json:"strict"`
+}
+
 type FrameSelectOptionOptions struct {
 	ElementHandleBaseOptions
 	Strict bool `json:"strict"`
 }
 
+// FrameSetInputFilesOptions are the options for Frame.SetInputFiles.
+type FrameSetInputFilesOptions struct {
+	ElementHandleBaseOptions
+	Strict bool `json:"strict"`
+}
+
 type FrameSetContentOptions struct {
 	Timeout   time.Duration  `json:"timeout"`
 	WaitUntil LifecycleEvent `json:"waitUntil" js:"waitUntil"`
@@ -140,6 +192,81 @@ type FrameWaitForSelectorOptions struct {
 	Timeout time.Duration   `json:"timeout"`
 }
 
+// NewFrameAddScriptTagOptions returns a new FrameAddScriptTagOptions.
+func NewFrameAddScriptTagOptions() *FrameAddScriptTagOptions {
+	return &FrameAddScriptTagOptions{}
+}
+
+// Parse parses the add script tag options. The content of the file at
+// the path is read as the content of the script. The path is read as
+// open() reads it in the init context, so the file must be opened there
+// first for it to be in the archive of the test.
+func (o *FrameAddScriptTagOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if gojaValueExists(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			switch k {
+			case "url":
+				o.URL = opts.Get(k).String()
+			case "path":
+				o.Path = opts.Get(k).String()
+			case "content":
+				o.Content = opts.Get(k).String()
+			case "type":
+				o.Type = opts.Get(k).String()
+			}
+		}
+	}
+	if o.URL == "" && o.Path == "" && o.Content == "" {
+		return errors.New("provide an object with a url, path or content property")
+	}
+	if o.Path != "" && o.Content == "" {
+		content, err := k6ext.ReadFile(ctx, o.Path)
+		if err != nil {
+			return fmt.Errorf("reading script from %q: %w", o.Path, err)
+		}
+		o.Content = string(content) + "\n//# sourceURL=" + o.Path
+	}
+
+	return nil
+}
+
+// NewFrameAddStyleTagOptions returns a new FrameAddStyleTagOptions.
+func NewFrameAddStyleTagOptions() *FrameAddStyleTagOptions {
+	return &FrameAddStyleTagOptions{}
+}
+
+// Parse parses the add style tag options. The content of the file at
+// the path is read as the content of the style. The path is read as
+// open() reads it in the init context.
+func (o *FrameAddStyleTagOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if gojaValueExists(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			switch k {
+			case "url":
+				o.URL = opts.Get(k).String()
+			case "path":
+				o.Path = opts.Get(k).String()
+			case "content":
+				o.Content = opts.Get(k).String()
+			}
+		}
+	}
+	if o.URL == "" && o.Path == "" && o.Content == "" {
+		return errors.New("provide an object with a url, path or content property")
+	}
+	if o.Path != "" && o.Content == "" {
+		content, err := k6ext.ReadFile(ctx, o.Path)
+		if err != nil {
+			return fmt.Errorf("reading style from %q: %w", o.Path, err)
+		}
+		o.Content = string(content) + "\n/*# sourceURL=" + o.Path + "*/"
+	}
+
+	return nil
+}
+
 func NewFrameBaseOptions(defaultTimeout time.Duration) *FrameBaseOptions {
 	return &FrameBaseOptions{
 		Timeout: defaultTimeout,
@@ -235,6 +362,60 @@ func (o *FrameDblclickOptions) Parse(ctx context.Context, opts goja.Value) error
 	return nil
 }
 
+// NewFrameDragAndDropOptions returns a new FrameDragAndDropOptions.
+func NewFrameDragAndDropOptions(defaultTimeout time.Duration) *FrameDragAndDropOptions {
+	return &FrameDragAndDropOptions{
+		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
+	}
+}
+
+// Parse parses the drag and drop options.
+func (o *FrameDragAndDropOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	rt := k6ext.Runtime(ctx)
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		if !gojaValueExists(v) {
+			continue
+		}
+		switch k {
+		case "sourcePosition", "targetPosition":
+			var p map[string]float64
+			if err := rt.ExportTo(v, &p); err != nil {
+				return fmt.Errorf("parsing %s option: %w", k, err)
+			}
+			pos := &Position{X: p["x"], Y: p["y"]}
+			if k == "sourcePosition" {
+				o.SourcePosition = pos
+			} else {
+				o.TargetPosition = pos
+			}
+		case "trial":
+			o.Trial = v.ToBoolean()
+		case "strict":
+			o.Strict = v.ToBoolean()
+		}
+	}
+
+	return nil
+}
+
+// pointerOptions returns the pointer action options of the source or
+// the target element.
+func (o *FrameDragAndDropOptions) pointerOptions(position *Position) *ElementHandleBasePointerOptions {
+	return &ElementHandleBasePointerOptions{
+		ElementHandleBaseOptions: o.ElementHandleBaseOptions,
+		Position:                 position,
+		Trial:                    o.Trial,
+	}
+}
+
 func NewFrameFillOptions(defaultTimeout time.Duration) *FrameFillOptions {
 	return &FrameFillOptions{
 		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
@@ -442,6 +623,30 @@ func (o *FramePressOptions) ToKeyboardOptions() *KeyboardOptions {
 	return o2
 }
 
+func NewFrameScrollIntoViewIfNeededOptions(defaultTimeout time.Duration) *FrameScrollIntoViewIfNeededOptions {
+	return &FrameScrollIntoViewIfNeededOptions{
+		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
+		Strict:                   false,
+	}
+}
+
+func (o *FrameScrollIntoViewIfNeededOptions) Parse(ctx context.Context, opts goja.Value) error {
+	rt := k6ext.Runtime(ctx)
+	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
+	if opts != nil && !goja.IsUndefined(opts) && !goja.IsNull(opts) {
+		opts := opts.ToObject(rt)
+		for _, k := range opts.Keys() {
+			switch k {
+			case "strict":
+				o.Strict = opts.Get(k).ToBoolean()
+			}
+		}
+	}
+	return nil
+}
+
 func NewFrameSelectOptionOptions(defaultTimeout time.Duration) *FrameSelectOptionOptions {
 	return &FrameSelectOptionOptions{
 		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
@@ -466,6 +671,32 @@ func (o *FrameSelectOptionOptions) Parse(ctx context.Context, opts goja.Value) e
 	return nil
 }
 
+// NewFrameSetInputFilesOptions returns a new FrameSetInputFilesOptions.
+func NewFrameSetInputFilesOptions(defaultTimeout time.Duration) *FrameSetInputFilesOptions {
+	return &FrameSetInputFilesOptions{
+		ElementHandleBaseOptions: *NewElementHandleBaseOptions(defaultTimeout),
+		Strict:                   false,
+	}
+}
+
+// Parse parses the set input files options.
+func (o *FrameSetInputFilesOptions) Parse(ctx context.Context, opts goja.Value) error {
+	rt := k6ext.Runtime(ctx)
+	if err := o.ElementHandleBaseOptions.Parse(ctx, opts); err != nil {
+		return err
+	}
+	if opts != nil && !goja.IsUndefined(opts) && !goja.IsNull(opts) {
+		opts := opts.ToObject(rt)
+		for _, k := range opts.Keys() {
+			switch k {
+			case "strict":
+				o.Strict = opts.Get(k).ToBoolean()
+			}
+		}
+	}
+	return nil
+}
+
 func NewFrameSetContentOptions(defaultTimeout time.Duration) *FrameSetContentOptions {
 	return &FrameSetContentOptions{
 		Timeout:   defaultTimeout,
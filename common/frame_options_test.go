@@ -1,11 +1,16 @@
 package common
 
 import (
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/grafana/xk6-browser/k6ext"
 	"github.com/grafana/xk6-browser/k6ext/k6test"
 
+	k6common "go.k6.io/k6/js/common"
+	k6fsext "go.k6.io/k6/lib/fsext"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -119,3 +124,73 @@ func TestFrameWaitForNavigationOptionsParse(t *testing.T) {
 				`load, domcontentloaded, networkidle`)
 	})
 }
+
+func TestFrameDragAndDropOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	opts := vu.ToGojaValue(map[string]any{
+		"force":          true,
+		"sourcePosition": map[string]any{"x": 1, "y": 2.5},
+		"targetPosition": map[string]any{"x": 3, "y": 4},
+		"strict":         true,
+		"timeout":        500,
+		"trial":          true,
+	})
+	dndOpts := NewFrameDragAndDropOptions(time.Second)
+	require.NoError(t, dndOpts.Parse(vu.Context(), opts))
+
+	assert.True(t, dndOpts.Force)
+	assert.Equal(t, &Position{X: 1, Y: 2.5}, dndOpts.SourcePosition)
+	assert.Equal(t, &Position{X: 3, Y: 4}, dndOpts.TargetPosition)
+	assert.True(t, dndOpts.Strict)
+	assert.Equal(t, 500*time.Millisecond, dndOpts.Timeout)
+	assert.True(t, dndOpts.Trial)
+
+	po := dndOpts.pointerOptions(dndOpts.TargetPosition)
+	assert.Equal(t, dndOpts.TargetPosition, po.Position)
+	assert.True(t, po.Force)
+	assert.True(t, po.Trial)
+
+	dndOpts = NewFrameDragAndDropOptions(time.Second)
+	require.NoError(t, dndOpts.Parse(vu.Context(), nil))
+	assert.Nil(t, dndOpts.SourcePosition)
+	assert.Equal(t, time.Second, dndOpts.Timeout)
+}
+
+func TestFrameAddTagOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	// the files opened in the init context are in the file system
+	// of the init environment, e.g. in the archive of the test.
+	const path = "tag"
+	fs := k6fsext.NewMemMapFs()
+	require.NoError(t, k6fsext.WriteFile(fs, "/scripts/tag", []byte("content"), 0o600))
+	vu := k6test.NewVU(t)
+	ctx := k6ext.WithInitEnv(vu.Context(), &k6common.InitEnvironment{
+		FileSystems: map[string]k6fsext.Fs{"file": fs},
+		CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+	})
+
+	scriptOpts := NewFrameAddScriptTagOptions()
+	require.NoError(t, scriptOpts.Parse(ctx, vu.ToGojaValue(map[string]any{
+		"path": path,
+		"type": "module",
+	})))
+	assert.Equal(t, "content\n//# sourceURL="+path, scriptOpts.Content)
+	assert.Equal(t, "module", scriptOpts.Type)
+
+	styleOpts := NewFrameAddStyleTagOptions()
+	require.NoError(t, styleOpts.Parse(ctx, vu.ToGojaValue(map[string]any{"path": path})))
+	assert.Equal(t, "content\n/*# sourceURL="+path+"*/", styleOpts.Content)
+
+	styleOpts = NewFrameAddStyleTagOptions()
+	require.NoError(t, styleOpts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"url": "https://example.com/a.css"})))
+	assert.Equal(t, "https://example.com/a.css", styleOpts.URL)
+	assert.Empty(t, styleOpts.Content)
+
+	err := NewFrameAddScriptTagOptions().Parse(vu.Context(), nil)
+	assert.EqualError(t, err, "provide an object with a url, path or content property")
+	err = NewFrameAddStyleTagOptions().Parse(ctx, vu.ToGojaValue(map[string]any{"path": "missing"}))
+	assert.ErrorContains(t, err, "reading style from")
+}
@@ -2,9 +2,11 @@ package common
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -150,6 +152,14 @@ func NewFrameSession(
 
 		return nil, err
 	}
+	if err = fs.initBindings(); err != nil {
+		l.Debugf(
+			"NewFrameSession:initBindings",
+			"sid:%v tid:%v err:%v",
+			s.ID(), tid, err)
+
+		return nil, err
+	}
 
 	return &fs, nil
 }
@@ -196,6 +206,45 @@ func (fs *FrameSession) initDomains() error {
 	return nil
 }
 
+// initBindings adds the runtime binding of the exposed bindings, and the
+// scripts that install the exposed bindings of the page and its browser
+// context in the new documents.
+func (fs *FrameSession) initBindings() error {
+	fs.logger.Debugf("NewFrameSession:initBindings",
+		"sid:%v tid:%v", fs.session.ID(), fs.targetID)
+
+	action := cdpruntime.AddBinding(exposedBinding)
+	if err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
+		return fmt.Errorf("adding exposed binding: %w", err)
+	}
+	bindings := fs.page.bindings.all()
+	if !fs.page.internal {
+		bindings = append(fs.page.browserCtx.bindings.all(), bindings...)
+	}
+	for _, b := range bindings {
+		if err := fs.addBindingScript(b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addBindingScript adds the script that installs the binding
+// in the new documents.
+func (fs *FrameSession) addBindingScript(b *binding) error {
+	source, err := b.initScript()
+	if err != nil {
+		return err
+	}
+	action := cdppage.AddScriptToEvaluateOnNewDocument(source)
+	if _, err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
+		return fmt.Errorf("adding script of binding %q: %w", b.name, err)
+	}
+
+	return nil
+}
+
 func (fs *FrameSession) initEvents() {
 	fs.logger.Debugf("NewFrameSession:initEvents",
 		"sid:%v tid:%v", fs.session.ID(), fs.targetID)
@@ -264,8 +313,12 @@ func (fs *FrameSession) initEvents() {
 					fs.onDetachedFromTarget(ev)
 				case *cdppage.EventJavascriptDialogOpening:
 					fs.onEventJavascriptDialogOpening(ev)
+				case *cdppage.EventFileChooserOpened:
+					fs.onFileChooserOpened(ev)
 				case *cdpruntime.EventBindingCalled:
 					fs.onEventBindingCalled(ev)
+				case *cdppage.EventScreencastFrame:
+					fs.onScreencastFrame(ev)
 				}
 			}
 		}
@@ -277,6 +330,20 @@ func (fs *FrameSession) onEventBindingCalled(event *cdpruntime.EventBindingCalle
 		"sid:%v tid:%v name:%s payload:%s",
 		fs.session.ID(), fs.targetID, event.Name, event.Payload)
 
+	if event.Name == exposedBinding {
+		fs.contextIDToContextMu.Lock()
+		ec := fs.contextIDToContext[event.ExecutionContextID]
+		fs.contextIDToContextMu.Unlock()
+		if ec == nil {
+			fs.logger.Debugf("FrameSession:onEventBindingCalled",
+				"sid:%v tid:%v ectxid:%d execution context not found",
+				fs.session.ID(), fs.targetID, event.ExecutionContextID)
+			return
+		}
+		fs.page.onBindingCalled(ec, event.Payload)
+		return
+	}
+
 	err := fs.parseAndEmitWebVitalMetric(event.Payload)
 	if err != nil {
 		fs.logger.Errorf("FrameSession:onEventBindingCalled", "failed to emit web vital metric: %v", err)
@@ -333,23 +400,107 @@ func (fs *FrameSession) parseAndEmitWebVitalMetric(object string) error {
 	return nil
 }
 
+func (fs *FrameSession) onFileChooserOpened(event *cdppage.EventFileChooserOpened) {
+	fs.logger.Debugf("FrameSession:onFileChooserOpened",
+		"sid:%v tid:%v fid:%v mode:%s",
+		fs.session.ID(), fs.targetID, event.FrameID, event.Mode)
+
+	// The interception might have been removed since
+	// the file chooser was opened.
+	if !fs.page.interceptsFileChooser() {
+		return
+	}
+	frame := fs.manager.getFrameByID(event.FrameID)
+	if frame == nil {
+		fs.logger.Debugf("FrameSession:onFileChooserOpened:return",
+			"sid:%v tid:%v fid:%v: frame not found",
+			fs.session.ID(), fs.targetID, event.FrameID)
+		return
+	}
+	h, err := frame.adoptBackendNodeID(mainWorld, event.BackendNodeID)
+	if err != nil {
+		fs.logger.Debugf("FrameSession:onFileChooserOpened:return",
+			"sid:%v tid:%v fid:%v: adopting element: %v",
+			fs.session.ID(), fs.targetID, event.FrameID, err)
+		return
+	}
+
+	isMultiple := event.Mode == cdppage.FileChooserOpenedModeSelectMultiple
+	fs.page.emit(EventPageFilechooser, NewFileChooser(fs.page, h, isMultiple))
+}
+
 func (fs *FrameSession) onEventJavascriptDialogOpening(event *cdppage.EventJavascriptDialogOpening) {
 	fs.logger.Debugf("FrameSession:onEventJavascriptDialogOpening",
 		"sid:%v tid:%v url:%v dialogType:%s",
 		fs.session.ID(), fs.targetID, event.URL, event.Type)
 
-	// Dialog type of beforeunload needs to accept the
-	// dialog, instead of dismissing it. We're unable to
-	// dismiss beforeunload dialog boxes at the moment as
-	// it seems to pause the exec of any other action on
-	// the page. I believe this is an issue in Chromium.
-	action := cdppage.HandleJavaScriptDialog(false)
-	if event.Type == cdppage.DialogTypeBeforeunload {
-		action = cdppage.HandleJavaScriptDialog(true)
+	// Let the dialog handlers of the page decide what to do with the
+	// dialog. Otherwise, fall back to the default behavior.
+	dialog := NewDialog(fs.ctx, fs.session, event, fs.logger)
+	if fs.page.hasEventHandlers(EventPageDialog) {
+		fs.page.emit(EventPageDialog, dialog)
+		return
+	}
+	if err := dialog.handleByDefault(); err != nil {
+		fs.logger.Errorf("FrameSession:onEventJavascriptDialogOpening", "failed to dismiss dialog box: %v", err)
+	}
+}
+
+// startVideoRecording starts the screencast of the page and records
+// its frames into a video under the videos path.
+func (fs *FrameSession) startVideoRecording() error {
+	fs.logger.Debugf("NewFrameSession:startVideoRecording",
+		"sid:%v tid:%v", fs.session.ID(), fs.targetID)
+
+	opts := fs.page.browserCtx.opts
+	path := filepath.Join(opts.VideosPath, string(fs.targetID)+".avi")
+	video, err := NewVideo(fs.ctx, path)
+	if err != nil {
+		return fmt.Errorf("starting video recording: %w", err)
 	}
+	fs.page.setVideo(video)
 
+	width, height := int64(DefaultScreenWidth), int64(DefaultScreenHeight)
+	if opts.Viewport != nil {
+		width, height = opts.Viewport.Width, opts.Viewport.Height
+	}
+	action := cdppage.StartScreencast().
+		WithFormat(cdppage.ScreencastFormatJpeg).
+		WithQuality(90).
+		WithMaxWidth(width).
+		WithMaxHeight(height).
+		WithEveryNthFrame(1)
 	if err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
-		fs.logger.Errorf("FrameSession:onEventJavascriptDialogOpening", "failed to dismiss dialog box: %v", err)
+		return fmt.Errorf("starting screencast: %w", err)
+	}
+
+	return nil
+}
+
+func (fs *FrameSession) onScreencastFrame(event *cdppage.EventScreencastFrame) {
+	fs.logger.Debugf("FrameSession:onScreencastFrame",
+		"sid:%v tid:%v frame:%d", fs.session.ID(), fs.targetID, event.SessionID)
+
+	action := cdppage.ScreencastFrameAck(event.SessionID)
+	if err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
+		fs.logger.Debugf("FrameSession:onScreencastFrame", "acknowledging frame: %v", err)
+	}
+
+	video := fs.page.getVideo()
+	if video == nil {
+		return
+	}
+	frame, err := base64.StdEncoding.DecodeString(event.Data)
+	if err != nil {
+		fs.logger.Errorf("FrameSession:onScreencastFrame", "decoding frame: %v", err)
+		return
+	}
+	ts := time.Now()
+	if event.Metadata != nil && event.Metadata.Timestamp != nil {
+		ts = event.Metadata.Timestamp.Time()
+	}
+	if err := video.addFrame(frame, ts); err != nil {
+		fs.logger.Errorf("FrameSession:onScreencastFrame", "recording frame: %v", err)
 	}
 }
 
@@ -438,7 +589,6 @@ func (fs *FrameSession) initOptions() error {
 	var (
 		opts       = fs.manager.page.browserCtx.opts
 		optActions = []Action{}
-		state      = fs.vu.State()
 	)
 
 	if fs.isMainFrame() {
@@ -480,12 +630,10 @@ func (fs *FrameSession) initOptions() error {
 	}
 	fs.updateExtraHTTPHeaders(true)
 
-	var reqIntercept bool
-	if state.Options.BlockedHostnames.Trie != nil ||
-		len(state.Options.BlacklistIPs) > 0 {
-		reqIntercept = true
+	if err := fs.updateRequestInterception(); err != nil {
+		return err
 	}
-	if err := fs.updateRequestInterception(reqIntercept); err != nil {
+	if err := fs.updateFileChooserInterception(true); err != nil {
 		return err
 	}
 
@@ -495,8 +643,11 @@ func (fs *FrameSession) initOptions() error {
 		return err
 	}
 
-	// if (screencastOptions)
-	//   promises.push(this._startVideoRecording(screencastOptions));
+	if fs.isMainFrame() && opts.VideosPath != "" && !fs.page.internal {
+		if err := fs.startVideoRecording(); err != nil {
+			return err
+		}
+	}
 
 	/*for (const source of this._crPage._browserContext._evaluateOnNewDocumentSources)
 	      promises.push(this._evaluateOnNewDocument(source, 'main'));
@@ -538,6 +689,7 @@ func (fs *FrameSession) initRendererEvents() {
 		cdproto.EventTargetAttachedToTarget,
 		cdproto.EventTargetDetachedFromTarget,
 		cdproto.EventRuntimeBindingCalled,
+		cdproto.EventPageScreencastFrame,
 	}
 	fs.session.on(fs.ctx, events, fs.eventCh)
 }
@@ -608,13 +760,61 @@ func (fs *FrameSession) onConsoleAPICalled(event *cdpruntime.EventConsoleAPICall
 		l.Warn()
 	case "error":
 		l.Error()
+		fs.emitErrorMetric(fs.k6Metrics.BrowserConsoleErrors)
 	default:
 		l.Debug()
 	}
+
+	fs.page.emit(EventPageConsole, fs.newConsoleMessage(event))
+}
+
+// newConsoleMessage returns the console message of the console call,
+// whose arguments are JS handles in the execution context of the call.
+func (fs *FrameSession) newConsoleMessage(event *cdpruntime.EventConsoleAPICalled) *ConsoleMessage {
+	fs.contextIDToContextMu.Lock()
+	ec := fs.contextIDToContext[event.ExecutionContextID]
+	fs.contextIDToContextMu.Unlock()
+
+	args := make([]api.JSHandle, 0, len(event.Args))
+	// The execution context might have been destroyed since the call.
+	if ec != nil {
+		for _, robj := range event.Args {
+			args = append(args, NewJSHandle(fs.ctx, fs.session, ec, ec.frame, robj, fs.logger))
+		}
+	}
+
+	return NewConsoleMessage(
+		fs.page,
+		event.Type.String(),
+		consoleMessageText(event.Args),
+		args,
+		consoleMessageLocation(event.StackTrace),
+	)
 }
 
 func (fs *FrameSession) onExceptionThrown(event *cdpruntime.EventExceptionThrown) {
-	fs.page.emit(EventPageError, event.ExceptionDetails)
+	fs.emitErrorMetric(fs.k6Metrics.BrowserPageErrors)
+	fs.page.emit(EventPageError, newPageError(event.ExceptionDetails))
+}
+
+// emitErrorMetric counts an error of the page, which is tagged
+// with the URL of the page.
+func (fs *FrameSession) emitErrorMetric(metric *k6metrics.Metric) {
+	state := fs.vu.State()
+	tags := state.Tags.GetCurrentValues().Tags
+	if mf := fs.manager.MainFrame(); mf != nil && state.Options.SystemTags.Has(k6metrics.TagURL) {
+		tags = tags.With("url", mf.URL())
+	}
+
+	k6metrics.PushIfNotDone(fs.vu.Context(), state.Samples, k6metrics.ConnectedSamples{
+		Samples: []k6metrics.Sample{
+			{
+				TimeSeries: k6metrics.TimeSeries{Metric: metric, Tags: tags},
+				Value:      1,
+				Time:       time.Now(),
+			},
+		},
+	})
 }
 
 func (fs *FrameSession) onExecutionContextCreated(event *cdpruntime.EventExecutionContextCreated) {
@@ -912,12 +1112,12 @@ func (fs *FrameSession) attachIFrameToTarget(ti *target.Info, sid target.Session
 
 // attachWorkerToTarget attaches a Worker target to a given session.
 func (fs *FrameSession) attachWorkerToTarget(ti *target.Info, sid target.SessionID) error {
-	w, err := NewWorker(fs.ctx, fs.page.browserCtx.getSession(sid), ti.TargetID, ti.URL)
+	w, err := NewWorker(fs.ctx, fs.page.browserCtx.getSession(sid), fs.page, ti.TargetID, ti.URL, fs.logger)
 	if err != nil {
 		return fmt.Errorf("attaching worker target ID %v to session ID %v: %w",
 			ti.TargetID, sid, err)
 	}
-	fs.page.workers[sid] = w
+	fs.page.addWorker(sid, w)
 
 	return nil
 }
@@ -1022,13 +1222,42 @@ func (fs *FrameSession) updateOffline(initial bool) {
 	}
 }
 
-func (fs *FrameSession) updateRequestInterception(enable bool) error {
+// updateRequestInterception enables the request interception if there are
+// blocked hostnames or IPs to check, or if the page has route handlers.
+func (fs *FrameSession) updateRequestInterception() error {
+	state := fs.vu.State()
+	enable := state.Options.BlockedHostnames.Trie != nil ||
+		len(state.Options.BlacklistIPs) > 0 ||
+		fs.page.hasRoutes()
+
 	fs.logger.Debugf("NewFrameSession:updateRequestInterception",
 		"sid:%v tid:%v on:%v",
 		fs.session.ID(),
 		fs.targetID, enable)
 
-	return fs.networkManager.setRequestInterception(enable || fs.page.hasRoutes())
+	return fs.networkManager.setRequestInterception(enable)
+}
+
+// updateFileChooserInterception intercepts the file choosers if the page
+// has file chooser handlers or waiters, so that they are emitted to them
+// instead of opening the file dialog of the browser.
+func (fs *FrameSession) updateFileChooserInterception(initial bool) error {
+	enable := fs.page.interceptsFileChooser()
+
+	fs.logger.Debugf("NewFrameSession:updateFileChooserInterception",
+		"sid:%v tid:%v on:%v",
+		fs.session.ID(),
+		fs.targetID, enable)
+
+	if initial && !enable {
+		return nil
+	}
+	action := cdppage.SetInterceptFileChooserDialog(enable)
+	if err := action.Do(cdp.WithExecutor(fs.ctx, fs.session)); err != nil {
+		return fmt.Errorf("setting file chooser interception: %w", err)
+	}
+
+	return nil
 }
 
 func (fs *FrameSession) updateViewport() error {
@@ -98,6 +98,39 @@ func TestFrameManagerFrameAbortedNavigationShouldEmitANonNilPendingDocument(t *t
 	require.Nil(t, frame.pendingDocument)
 }
 
+func TestFrameManagerFrameInDocumentOrder(t *testing.T) {
+	t.Parallel()
+
+	ctx, log := context.Background(), log.NewNullLogger()
+	fm := NewFrameManager(ctx, nil, nil, NewTimeoutSettings(nil), log)
+	newFrame := func(parent *Frame, id, name string) *Frame {
+		f := NewFrame(ctx, fm, parent, cdp.FrameID(id), log)
+		f.name = name
+		if parent != nil {
+			parent.addChildFrame(f)
+		}
+		return f
+	}
+	main := newFrame(nil, "main", "")
+	fm.mainFrame = main
+	first := newFrame(main, "1", "first")
+	firstChild := newFrame(first, "1.1", "child")
+	removed := newFrame(main, "2", "child")
+	second := newFrame(main, "3", "child")
+	main.removeChildFrame(removed)
+
+	require.Equal(t, []*Frame{main, first, firstChild, second}, main.frameTree())
+
+	for i := 0; i < 10; i++ {
+		f, err := fm.frame(&PageFrameOptions{Name: "child"})
+		require.NoError(t, err)
+		require.Same(t, firstChild, f, "must match the frames in document order")
+	}
+	f, err := fm.frame(&PageFrameOptions{Name: "missing"})
+	require.NoError(t, err)
+	require.Nil(t, f)
+}
+
 type executionContextTestStub struct {
 	ExecutionContext
 	evalFn func(
@@ -106,6 +106,420 @@ function oneLine(s) {
   return s.replace(/\n/g, "↵").replace(/\t/g, "⇆");
 }
 
+function elementText(element) {
+  return normalizeWhiteSpace(element.textContent || "");
+}
+
+// createTextMatcher returns a function that matches a text against the body
+// of a text filter. The body is either a JSON string or a regular expression
+// in the /source/flags form. A string matches as a case insensitive substring,
+// or, if exact is true, as the whole text.
+function createTextMatcher(body, exact) {
+  if (body.startsWith("/")) {
+    const lastSlash = body.lastIndexOf("/");
+    const re = new RegExp(body.substring(1, lastSlash), body.substring(lastSlash + 1));
+    return (text) => {
+      re.lastIndex = 0;
+      return re.test(text);
+    };
+  }
+  const search = normalizeWhiteSpace(JSON.parse(body));
+  if (exact) {
+    return (text) => normalizeWhiteSpace(text) === search;
+  }
+  const lowerSearch = search.toLowerCase();
+  return (text) => normalizeWhiteSpace(text).toLowerCase().includes(lowerSearch);
+}
+
+function normalizeWhiteSpace(text) {
+  return text.replace(/\s+/g, " ").trim();
+}
+
+// kImplicitRoles maps the element names to their implicit ARIA roles.
+// The roles that depend on the element's attributes are computed
+// by getImplicitAriaRole.
+const kImplicitRoles = {
+  ARTICLE: "article",
+  ASIDE: "complementary",
+  BLOCKQUOTE: "blockquote",
+  BUTTON: "button",
+  CAPTION: "caption",
+  CODE: "code",
+  DATALIST: "listbox",
+  DD: "definition",
+  DEL: "deletion",
+  DETAILS: "group",
+  DFN: "term",
+  DIALOG: "dialog",
+  DT: "term",
+  EM: "emphasis",
+  FIELDSET: "group",
+  FIGURE: "figure",
+  FORM: "form",
+  H1: "heading",
+  H2: "heading",
+  H3: "heading",
+  H4: "heading",
+  H5: "heading",
+  H6: "heading",
+  HR: "separator",
+  HTML: "document",
+  INS: "insertion",
+  LI: "listitem",
+  MAIN: "main",
+  MARK: "mark",
+  MATH: "math",
+  MENU: "list",
+  METER: "meter",
+  NAV: "navigation",
+  OL: "list",
+  OPTGROUP: "group",
+  OPTION: "option",
+  OUTPUT: "status",
+  P: "paragraph",
+  PROGRESS: "progressbar",
+  STRONG: "strong",
+  SUB: "subscript",
+  SUP: "superscript",
+  TABLE: "table",
+  TBODY: "rowgroup",
+  TD: "cell",
+  TEXTAREA: "textbox",
+  TFOOT: "rowgroup",
+  TH: "columnheader",
+  THEAD: "rowgroup",
+  TIME: "time",
+  TR: "row",
+  UL: "list",
+};
+
+const kInputTypeRoles = {
+  button: "button",
+  checkbox: "checkbox",
+  email: "textbox",
+  image: "button",
+  number: "spinbutton",
+  radio: "radio",
+  range: "slider",
+  reset: "button",
+  search: "searchbox",
+  submit: "button",
+  tel: "textbox",
+  text: "textbox",
+  url: "textbox",
+};
+
+// kNameFromContentRoles are the roles that take their accessible
+// name from their content.
+const kNameFromContentRoles = new Set([
+  "button",
+  "cell",
+  "checkbox",
+  "columnheader",
+  "gridcell",
+  "heading",
+  "link",
+  "menuitem",
+  "menuitemcheckbox",
+  "menuitemradio",
+  "option",
+  "radio",
+  "row",
+  "rowheader",
+  "switch",
+  "tab",
+  "tooltip",
+  "treeitem",
+]);
+
+function getImplicitAriaRole(element) {
+  switch (element.nodeName) {
+    case "A":
+    case "AREA":
+      return element.hasAttribute("href") ? "link" : null;
+    case "FOOTER":
+      return element.closest("article, aside, main, nav, section")
+        ? null
+        : "contentinfo";
+    case "HEADER":
+      return element.closest("article, aside, main, nav, section")
+        ? null
+        : "banner";
+    case "IMG":
+      return element.getAttribute("alt") === "" && !element.hasAttribute("title")
+        ? "presentation"
+        : "img";
+    case "INPUT": {
+      const type = (element.getAttribute("type") || "text").toLowerCase();
+      if (type === "hidden") {
+        return null;
+      }
+      if (element.hasAttribute("list") && ["email", "search", "tel", "text", "url"].includes(type)) {
+        return "combobox";
+      }
+      return kInputTypeRoles[type] || "textbox";
+    }
+    case "SECTION":
+      return element.hasAttribute("aria-label") || element.hasAttribute("aria-labelledby")
+        ? "region"
+        : null;
+    case "SELECT":
+      return element.multiple || element.size > 1 ? "listbox" : "combobox";
+    case "TH":
+      return element.getAttribute("scope") === "row" ? "rowheader" : "columnheader";
+  }
+  return kImplicitRoles[element.nodeName] || null;
+}
+
+function getAriaRole(element) {
+  const explicit = (element.getAttribute("role") || "").trim().split(/\s+/)[0];
+  if (explicit && explicit !== "none" && explicit !== "presentation") {
+    return explicit;
+  }
+  return getImplicitAriaRole(element);
+}
+
+function isAriaHidden(element) {
+  for (let e = element; e; e = e.parentElement) {
+    if (e.getAttribute("aria-hidden") === "true" || e.hidden) {
+      return true;
+    }
+  }
+  return !isVisible(element);
+}
+
+function getElementsByIDs(element, ids) {
+  const root = element.getRootNode();
+  return ids
+    .trim()
+    .split(/\s+/)
+    .map((id) => root.getElementById(id))
+    .filter(Boolean);
+}
+
+// getLabelTexts returns the texts of the labels of the element, i.e. its
+// aria-labelledby elements, its aria-label attribute and its label elements.
+function getLabelTexts(element) {
+  const texts = [];
+  const labelledBy = element.getAttribute("aria-labelledby");
+  if (labelledBy) {
+    texts.push(
+      getElementsByIDs(element, labelledBy)
+        .map((e) => getTextAlternative(e, new Set()))
+        .join(" ")
+    );
+  }
+  const label = element.getAttribute("aria-label");
+  if (label) {
+    texts.push(label);
+  }
+  for (const l of element.labels || []) {
+    texts.push(getTextAlternative(l, new Set()));
+  }
+  return texts.map(normalizeWhiteSpace).filter(Boolean);
+}
+
+// getTextAlternative computes the text of an element that is used as
+// a part of an accessible name, following a simplified version of
+// the accessible name computation algorithm.
+function getTextAlternative(node, visited) {
+  if (node.nodeType === 3 /*Node.TEXT_NODE*/) {
+    return node.textContent;
+  }
+  if (node.nodeType !== 1 /*Node.ELEMENT_NODE*/ || visited.has(node)) {
+    return "";
+  }
+  visited.add(node);
+  const label = node.getAttribute("aria-label");
+  if (label && label.trim()) {
+    return label;
+  }
+  if (node.nodeName === "IMG" || (node.nodeName === "INPUT" && node.type === "image")) {
+    return node.getAttribute("alt") || "";
+  }
+  if (node.nodeName === "INPUT" && ["button", "reset", "submit"].includes(node.type)) {
+    return node.value;
+  }
+  const parts = [];
+  for (const child of node.shadowRoot ? node.shadowRoot.childNodes : node.childNodes) {
+    if (child.nodeType === 1 && child.getAttribute("aria-hidden") === "true") {
+      continue;
+    }
+    const text = getTextAlternative(child, visited);
+    parts.push(child.nodeType === 1 && isBlock(child) ? ` ${text} ` : text);
+  }
+  return parts.join("");
+}
+
+function isBlock(element) {
+  const style = element.ownerDocument.defaultView.getComputedStyle(element);
+  return !!style && style.display !== "inline";
+}
+
+function getAccessibleName(element, role) {
+  const labelledBy = element.getAttribute("aria-labelledby");
+  if (labelledBy) {
+    const name = getElementsByIDs(element, labelledBy)
+      .map((e) => getTextAlternative(e, new Set()))
+      .join(" ");
+    if (name.trim()) {
+      return normalizeWhiteSpace(name);
+    }
+  }
+  const label = element.getAttribute("aria-label");
+  if (label && label.trim()) {
+    return normalizeWhiteSpace(label);
+  }
+  if (element.labels && element.labels.length) {
+    const name = [...element.labels]
+      .map((l) => getTextAlternative(l, new Set([element])))
+      .join(" ");
+    if (name.trim()) {
+      return normalizeWhiteSpace(name);
+    }
+  }
+  if (element.nodeName === "IMG" || element.nodeName === "AREA" || (element.nodeName === "INPUT" && element.type === "image")) {
+    const alt = element.getAttribute("alt");
+    if (alt) {
+      return normalizeWhiteSpace(alt);
+    }
+  }
+  if (element.nodeName === "INPUT" && ["button", "reset", "submit"].includes(element.type)) {
+    const value = element.value || { reset: "Reset", submit: "Submit" }[element.type] || "";
+    return normalizeWhiteSpace(value);
+  }
+  if (["FIELDSET", "TABLE", "FIGURE"].includes(element.nodeName)) {
+    const caption = element.querySelector(":scope > legend, :scope > caption, :scope > figcaption");
+    if (caption) {
+      return normalizeWhiteSpace(getTextAlternative(caption, new Set()));
+    }
+  }
+  if (kNameFromContentRoles.has(role)) {
+    const name = normalizeWhiteSpace(getTextAlternative(element, new Set()));
+    if (name) {
+      return name;
+    }
+  }
+  return normalizeWhiteSpace(
+    element.getAttribute("title") || element.getAttribute("placeholder") || ""
+  );
+}
+
+function getAriaChecked(element) {
+  if (element.nodeName === "INPUT" && ["checkbox", "radio"].includes(element.type)) {
+    return element.indeterminate ? "mixed" : element.checked;
+  }
+  const checked = element.getAttribute("aria-checked");
+  if (checked === "mixed") {
+    return "mixed";
+  }
+  return checked === "true";
+}
+
+function getAriaDisabled(element) {
+  for (let e = element; e; e = e.parentElement) {
+    if (e.getAttribute("aria-disabled") === "true") {
+      return true;
+    }
+  }
+  return element.matches(":disabled");
+}
+
+function getAriaBoolean(element, name) {
+  return element.getAttribute(name) === "true";
+}
+
+function getAriaLevel(element) {
+  const level = Number(element.getAttribute("aria-level"));
+  if (level > 0) {
+    return level;
+  }
+  const match = /^H([1-6])$/.exec(element.nodeName);
+  return match ? Number(match[1]) : 0;
+}
+
+function getAriaSelected(element) {
+  if (element.nodeName === "OPTION") {
+    return element.selected;
+  }
+  return getAriaBoolean(element, "aria-selected");
+}
+
+// RoleQueryEngine queries the elements by their ARIA role and accessible name.
+// The selector is a JSON object with the role and the optional filters.
+class RoleQueryEngine {
+  queryAll(root, selector) {
+    const opts = JSON.parse(selector);
+    const matchesName = opts.name !== undefined ? createTextMatcher(opts.name, opts.exact) : null;
+    const result = [];
+    for (const element of root.querySelectorAll("*")) {
+      if (getAriaRole(element) !== opts.role) {
+        continue;
+      }
+      if (!opts.includeHidden && isAriaHidden(element)) {
+        continue;
+      }
+      if (opts.checked !== undefined && getAriaChecked(element) !== opts.checked) {
+        continue;
+      }
+      if (opts.disabled !== undefined && getAriaDisabled(element) !== opts.disabled) {
+        continue;
+      }
+      if (opts.expanded !== undefined && getAriaBoolean(element, "aria-expanded") !== opts.expanded) {
+        continue;
+      }
+      if (opts.level !== undefined && getAriaLevel(element) !== opts.level) {
+        continue;
+      }
+      if (opts.pressed !== undefined && getAriaBoolean(element, "aria-pressed") !== opts.pressed) {
+        continue;
+      }
+      if (opts.selected !== undefined && getAriaSelected(element) !== opts.selected) {
+        continue;
+      }
+      if (matchesName && !matchesName(getAccessibleName(element, opts.role))) {
+        continue;
+      }
+      result.push(element);
+    }
+    return result;
+  }
+}
+
+// LabelQueryEngine queries the elements by the texts of their labels.
+// The selector is a JSON object with the text and whether it must
+// match exactly.
+class LabelQueryEngine {
+  queryAll(root, selector) {
+    const opts = JSON.parse(selector);
+    const matches = createTextMatcher(opts.text, opts.exact);
+    const result = [];
+    for (const element of root.querySelectorAll("*")) {
+      if (getLabelTexts(element).some(matches)) {
+        result.push(element);
+      }
+    }
+    return result;
+  }
+}
+
+// AttributeQueryEngine queries the elements by the value of an attribute.
+// The selector is a JSON object with the attribute name, the text to match
+// the attribute value against, and whether it must match exactly.
+class AttributeQueryEngine {
+  queryAll(root, selector) {
+    const opts = JSON.parse(selector);
+    const matches = createTextMatcher(opts.text, opts.exact);
+    const result = [];
+    for (const element of root.querySelectorAll(`[${CSS.escape(opts.name)}]`)) {
+      if (matches(element.getAttribute(opts.name))) {
+        result.push(element);
+      }
+    }
+    return result;
+  }
+}
+
 class CSSQueryEngine {
   queryAll(root, selector) {
     return root.querySelectorAll(selector);
@@ -151,6 +565,9 @@ class InjectedScript {
       css: new CSSQueryEngine(),
       text: new TextQueryEngine(),
       xpath: new XPathQueryEngine(),
+      "internal:attr": new AttributeQueryEngine(),
+      "internal:label": new LabelQueryEngine(),
+      "internal:role": new RoleQueryEngine(),
     };
   }
 
@@ -176,7 +593,7 @@ class InjectedScript {
         if (typeof selector.capture === "number") {
           return "error:nthnocapture";
         }
-        const nth = part.body;
+        const nth = Number(part.body);
         const set = new Set();
         for (const root of roots) {
           set.add(root.element);
@@ -193,6 +610,27 @@ class InjectedScript {
       );
     }
 
+    if (part.name === "internal:has-text") {
+      const matches = createTextMatcher(part.body, false);
+      return this._querySelectorRecursively(
+        roots.filter((match) => matches(elementText(match.element))),
+        selector,
+        index + 1,
+        queryCache
+      );
+    }
+
+    if (part.name === "internal:has") {
+      return this._querySelectorRecursively(
+        roots.filter(
+          (match) => this.querySelectorAll(part.nested, match.element).length > 0
+        ),
+        selector,
+        index + 1,
+        queryCache
+      );
+    }
+
     if (part.name === "visible") {
       const visible = Boolean(part.body);
       return roots.filter((match) => visible === isVisible(match.element));
@@ -697,6 +1135,34 @@ class InjectedScript {
     return selectedOptions.map((option) => option.value);
   }
 
+  setInputFiles(node, payloads) {
+    if (node.nodeType !== Node.ELEMENT_NODE) {
+      return "error:notelement";
+    }
+    if (node.nodeName.toLowerCase() !== "input") {
+      return "error:notinput";
+    }
+    const input = node;
+    const type = (input.getAttribute("type") || "").toLowerCase();
+    if (type !== "file") {
+      return "error:notfileinput";
+    }
+    if (payloads.length > 1 && !input.multiple) {
+      return "error:notmultiplefileinput";
+    }
+    const dt = new DataTransfer();
+    for (const payload of payloads) {
+      const bytes = Uint8Array.from(atob(payload.buffer || ""), (c) =>
+        c.charCodeAt(0)
+      );
+      dt.items.add(new File([bytes], payload.name, { type: payload.mimeType }));
+    }
+    input.files = dt.files;
+    input.dispatchEvent(new Event("input", { bubbles: true }));
+    input.dispatchEvent(new Event("change", { bubbles: true }));
+    return "done";
+  }
+
   selectText(node) {
     const element = this._retarget(node, "follow-label");
     if (!element) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext"
@@ -47,6 +48,17 @@ func NewKeyboard(ctx context.Context, s session) *Keyboard {
 	}
 }
 
+// setLayout sets the keyboard layout registered with name.
+func (k *Keyboard) setLayout(name string) error {
+	if !keyboardlayout.Exists(name) {
+		return fmt.Errorf("unknown keyboard layout: %q", name)
+	}
+	k.layoutName = name
+	k.layout = keyboardlayout.GetKeyboardLayout(name)
+
+	return nil
+}
+
 // Down sends a key down message to a session target.
 func (k *Keyboard) Down(key string) {
 	if err := k.down(key); err != nil {
@@ -86,7 +98,8 @@ func (k *Keyboard) InsertText(text string) {
 // It delays the action if `Delay` option is specified.
 //
 // It sends an insertText message if a character is not among
-// valid characters in the keyboard's layout.
+// valid characters in the keyboard's layout, e.g. the characters
+// that are typed with AltGraph, or in another script.
 func (k *Keyboard) Type(text string, opts goja.Value) {
 	kbdOpts := NewKeyboardOptions()
 	if err := kbdOpts.Parse(k.ctx, opts); err != nil {
@@ -186,7 +199,7 @@ func (k *Keyboard) keyDefinitionFromKey(key keyboardlayout.KeyInput) keyboardlay
 	if srcKeyDef.Key != "" {
 		keyDef.Key = srcKeyDef.Key
 	}
-	if len(srcKeyDef.Key) == 1 {
+	if utf8.RuneCountInString(srcKeyDef.Key) == 1 {
 		keyDef.Text = srcKeyDef.Key
 	}
 	if shift != 0 && srcKeyDef.ShiftKeyCode != 0 {
@@ -296,7 +309,6 @@ func (k *Keyboard) press(key string, opts *KeyboardOptions) error {
 }
 
 func (k *Keyboard) typ(text string, opts *KeyboardOptions) error {
-	layout := keyboardlayout.GetKeyboardLayout(k.layoutName)
 	for _, c := range text {
 		if opts.Delay > 0 {
 			if err := wait(k.ctx, opts.Delay); err != nil {
@@ -304,7 +316,7 @@ func (k *Keyboard) typ(text string, opts *KeyboardOptions) error {
 			}
 		}
 		keyInput := keyboardlayout.KeyInput(c)
-		if _, ok := layout.ValidKeys[keyInput]; ok {
+		if _, ok := k.layout.ValidKeys[keyInput]; ok {
 			if err := k.press(string(c), opts); err != nil {
 				return fmt.Errorf("pressing key: %w", err)
 			}
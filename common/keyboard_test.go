@@ -7,6 +7,7 @@ import (
 	"github.com/grafana/xk6-browser/keyboardlayout"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSplit(t *testing.T) {
@@ -184,3 +185,66 @@ func TestKeyDefinitionCode(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyboardLayouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		layout   string
+		key      keyboardlayout.KeyInput
+		wantCode string
+		wantKey  string
+		wantText string
+		keyCode  int64
+	}{
+		{layout: "de", key: "ä", wantCode: "Quote", wantKey: "ä", wantText: "ä", keyCode: 222},
+		{layout: "de", key: "Ü", wantCode: "BracketLeft", wantKey: "Ü", wantText: "Ü", keyCode: 186},
+		{layout: "de", key: "z", wantCode: "KeyY", wantKey: "z", wantText: "z", keyCode: 90},
+		{layout: "de", key: "KeyZ", wantCode: "KeyZ", wantKey: "y", wantText: "y", keyCode: 89},
+		{layout: "fr", key: "a", wantCode: "KeyQ", wantKey: "a", wantText: "a", keyCode: 65},
+		{layout: "fr", key: "1", wantCode: "Digit1", wantKey: "1", wantText: "1", keyCode: 49},
+		{layout: "fr", key: "é", wantCode: "Digit2", wantKey: "é", wantText: "é", keyCode: 50},
+		{layout: "uk", key: "£", wantCode: "Digit3", wantKey: "£", wantText: "£", keyCode: 51},
+		{layout: "uk", key: "@", wantCode: "Quote", wantKey: "@", wantText: "@", keyCode: 192},
+		{layout: "us", key: "Shift", wantCode: "ShiftLeft", wantKey: "Shift", wantText: "", keyCode: 160},
+		{layout: "generic", key: "Enter", wantCode: "Enter", wantKey: "Enter", wantText: "\r", keyCode: 13},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.layout+"/"+string(tt.key), func(t *testing.T) {
+			t.Parallel()
+
+			vu := k6test.NewVU(t)
+			k := NewKeyboard(vu.Context(), nil)
+			require.NoError(t, k.setLayout(tt.layout))
+
+			kd := k.keyDefinitionFromKey(tt.key)
+			assert.Equal(t, tt.wantCode, kd.Code)
+			assert.Equal(t, tt.wantKey, kd.Key)
+			assert.Equal(t, tt.wantText, kd.Text)
+			assert.Equal(t, tt.keyCode, kd.KeyCode)
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		k := NewKeyboard(vu.Context(), nil)
+		assert.EqualError(t, k.setLayout("xx"), `unknown keyboard layout: "xx"`)
+	})
+
+	t.Run("altgraph_and_non_latin_are_not_valid_keys", func(t *testing.T) {
+		t.Parallel()
+
+		for _, tt := range []struct {
+			layout string
+			key    keyboardlayout.KeyInput
+		}{
+			{"de", "@"}, {"de", "€"}, {"fr", "@"}, {"generic", "a"}, {"generic", "я"},
+		} {
+			_, ok := keyboardlayout.GetKeyboardLayout(tt.layout).ValidKeys[tt.key]
+			assert.Falsef(t, ok, "%q on layout %q", tt.key, tt.layout)
+		}
+	})
+}
@@ -2,8 +2,11 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext"
 	"github.com/grafana/xk6-browser/log"
 
@@ -477,6 +480,31 @@ func (l *Locator) selectOption(values goja.Value, opts *FrameSelectOptionOptions
 	return l.frame.selectOption(l.selector, values, opts)
 }
 
+// SetInputFiles sets the files of the input[type=file] element found that
+// matches the locator's selector (with strict mode on).
+func (l *Locator) SetInputFiles(files goja.Value, opts goja.Value) {
+	l.log.Debugf("Locator:SetInputFiles", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	copts := NewFrameSetInputFilesOptions(l.frame.defaultTimeout())
+	if err := copts.Parse(l.ctx, opts); err != nil {
+		k6ext.Panic(l.ctx, "parsing set input files options: %w", err)
+	}
+	cfiles := NewFiles()
+	if err := cfiles.Parse(l.ctx, files); err != nil {
+		k6ext.Panic(l.ctx, "parsing set input files: %w", err)
+	}
+	if err := l.setInputFiles(cfiles, copts); err != nil {
+		k6ext.Panic(l.ctx, "setting input files on %q: %w", l.selector, err)
+	}
+
+	applySlowMo(l.ctx)
+}
+
+func (l *Locator) setInputFiles(files *Files, opts *FrameSetInputFilesOptions) error {
+	opts.Strict = true
+	return l.frame.setInputFiles(l.selector, files, opts)
+}
+
 // Press the given key on the element found that matches the locator's
 // selector with strict mode on.
 func (l *Locator) Press(key string, opts goja.Value) {
@@ -605,6 +633,67 @@ func (l *Locator) dispatchEvent(typ string, eventInit goja.Value, opts *FrameDis
 	return l.frame.dispatchEvent(l.selector, typ, eventInit, opts)
 }
 
+// ScrollIntoViewIfNeeded scrolls the element matching the locator's
+// selector into view with the mouse wheel with strict mode on, unless
+// it's already visible.
+func (l *Locator) ScrollIntoViewIfNeeded(opts goja.Value) {
+	l.log.Debugf(
+		"Locator:ScrollIntoViewIfNeeded", "fid:%s furl:%q sel:%q opts:%+v",
+		l.frame.ID(), l.frame.URL(), l.selector, opts,
+	)
+
+	var err error
+	defer func() { panicOrSlowMo(l.ctx, err) }()
+
+	popts := NewFrameScrollIntoViewIfNeededOptions(l.frame.defaultTimeout())
+	if err = popts.Parse(l.ctx, opts); err != nil {
+		err = fmt.Errorf("parsing scroll into view options: %w", err)
+		return
+	}
+	if err = l.scrollIntoViewIfNeeded(popts); err != nil {
+		err = fmt.Errorf("scrolling %q into view: %w", l.selector, err)
+		return
+	}
+}
+
+func (l *Locator) scrollIntoViewIfNeeded(opts *FrameScrollIntoViewIfNeededOptions) error {
+	opts.Strict = true
+	return l.frame.scrollIntoViewIfNeeded(l.selector, opts)
+}
+
+// DragTo drags the element matching the locator's selector and drops
+// it onto the element matching the target locator's selector, both
+// with strict mode on.
+func (l *Locator) DragTo(target api.Locator, opts goja.Value) {
+	l.log.Debugf("Locator:DragTo", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	var err error
+	defer func() { panicOrSlowMo(l.ctx, err) }()
+
+	tl, ok := target.(*Locator)
+	if !ok {
+		err = fmt.Errorf("unexpected target locator type: %T", target)
+		return
+	}
+	popts := NewFrameDragAndDropOptions(l.frame.defaultTimeout())
+	if err = popts.Parse(l.ctx, opts); err != nil {
+		err = fmt.Errorf("parsing drag and drop options: %w", err)
+		return
+	}
+	if err = l.dragTo(tl, popts); err != nil {
+		err = fmt.Errorf("dragging %q to %q: %w", l.selector, tl.selector, err)
+		return
+	}
+}
+
+func (l *Locator) dragTo(target *Locator, opts *FrameDragAndDropOptions) error {
+	if target.frame != l.frame {
+		return errors.New("target locator must be in the same frame")
+	}
+	opts.Strict = true
+	return l.frame.dragAndDrop(l.selector, target.selector, opts)
+}
+
 // WaitFor waits for the element matching the locator's selector with strict mode on.
 func (l *Locator) WaitFor(opts goja.Value) {
 	l.log.Debugf("Locator:WaitFor", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
@@ -622,3 +711,167 @@ func (l *Locator) waitFor(opts *FrameWaitForSelectorOptions) error {
 	opts.Strict = true
 	return l.frame.waitFor(l.selector, opts)
 }
+
+// Locator creates and returns a new locator that finds the elements
+// matching the selector within the elements of this locator.
+func (l *Locator) Locator(selector string, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:Locator", "fid:%s furl:%q sel:%q sub:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, selector, opts)
+
+	lopts := NewLocatorFilterOptions()
+	if err := lopts.Parse(l.ctx, opts); err != nil {
+		k6ext.Panic(l.ctx, "parsing locator options: %w", err)
+	}
+	lo, err := l.frame.locator(l.selector+" >> "+selector, lopts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "creating locator for %q in %q: %w", selector, l.selector, err)
+	}
+
+	return lo
+}
+
+// Filter creates and returns a new locator that narrows down the
+// elements of this locator with the given options.
+func (l *Locator) Filter(opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:Filter", "fid:%s furl:%q sel:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, opts)
+
+	lopts := NewLocatorFilterOptions()
+	if err := lopts.Parse(l.ctx, opts); err != nil {
+		k6ext.Panic(l.ctx, "parsing filter options: %w", err)
+	}
+	lo, err := l.frame.locator(l.selector, lopts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "filtering %q: %w", l.selector, err)
+	}
+
+	return lo
+}
+
+// First returns a locator for the first element of this locator.
+func (l *Locator) First() api.Locator {
+	return l.nth(0)
+}
+
+// Last returns a locator for the last element of this locator.
+func (l *Locator) Last() api.Locator {
+	return l.nth(-1)
+}
+
+// Nth returns a locator for the n-th element of this locator.
+// The index is zero-based.
+func (l *Locator) Nth(index int) api.Locator {
+	return l.nth(index)
+}
+
+func (l *Locator) nth(index int) *Locator {
+	l.log.Debugf("Locator:Nth", "fid:%s furl:%q sel:%q nth:%d", l.frame.ID(), l.frame.URL(), l.selector, index)
+
+	return NewLocator(l.ctx, l.selector+" >> nth="+strconv.Itoa(index), l.frame, l.log)
+}
+
+// Count returns the number of elements matching the locator's selector.
+func (l *Locator) Count() int {
+	l.log.Debugf("Locator:Count", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	n, err := l.frame.count(l.selector)
+	if err != nil {
+		k6ext.Panic(l.ctx, "counting %q: %w", l.selector, err)
+	}
+
+	return n
+}
+
+// All returns a locator for each element matching the locator's selector.
+// Unlike the other locators, it doesn't wait for the elements to appear,
+// and returns the locators of the elements that match at the time of calling.
+func (l *Locator) All() []api.Locator {
+	l.log.Debugf("Locator:All", "fid:%s furl:%q sel:%q", l.frame.ID(), l.frame.URL(), l.selector)
+
+	n, err := l.frame.count(l.selector)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting all %q: %w", l.selector, err)
+	}
+	all := make([]api.Locator, n)
+	for i := range all {
+		all[i] = l.nth(i)
+	}
+
+	return all
+}
+
+// GetByAltText creates and returns a new locator for the elements with
+// the given alt text within the elements of this locator.
+func (l *Locator) GetByAltText(text goja.Value, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByAltText", "fid:%s furl:%q sel:%q text:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, text, opts)
+
+	selector, err := getByAttributeSelector(l.ctx, "alt", text, opts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by alt text %q in %q: %w", text, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
+
+// GetByLabel creates and returns a new locator for the elements that are
+// labelled with the given text within the elements of this locator.
+func (l *Locator) GetByLabel(text goja.Value, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByLabel", "fid:%s furl:%q sel:%q text:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, text, opts)
+
+	selector, err := getByLabelSelector(l.ctx, text, opts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by label %q in %q: %w", text, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
+
+// GetByPlaceholder creates and returns a new locator for the input elements
+// with the given placeholder text within the elements of this locator.
+func (l *Locator) GetByPlaceholder(text goja.Value, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByPlaceholder", "fid:%s furl:%q sel:%q text:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, text, opts)
+
+	selector, err := getByAttributeSelector(l.ctx, "placeholder", text, opts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by placeholder %q in %q: %w", text, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
+
+// GetByRole creates and returns a new locator for the elements with the
+// given ARIA role within the elements of this locator.
+func (l *Locator) GetByRole(role string, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByRole", "fid:%s furl:%q sel:%q role:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, role, opts)
+
+	selector, err := getByRoleSelector(l.ctx, role, opts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by role %q in %q: %w", role, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
+
+// GetByTestID creates and returns a new locator for the elements with the
+// given test ID within the elements of this locator.
+func (l *Locator) GetByTestID(testID goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByTestID", "fid:%s furl:%q sel:%q testID:%q", l.frame.ID(), l.frame.URL(), l.selector, testID)
+
+	selector, err := getByTestIDSelector(l.ctx, l.frame.testIDAttribute(), testID)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by test ID %q in %q: %w", testID, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
+
+// GetByTitle creates and returns a new locator for the elements with the
+// given title within the elements of this locator.
+func (l *Locator) GetByTitle(text goja.Value, opts goja.Value) api.Locator {
+	l.log.Debugf("Locator:GetByTitle", "fid:%s furl:%q sel:%q text:%q opts:%+v", l.frame.ID(), l.frame.URL(), l.selector, text, opts)
+
+	selector, err := getByAttributeSelector(l.ctx, "title", text, opts)
+	if err != nil {
+		k6ext.Panic(l.ctx, "getting by title %q in %q: %w", text, l.selector, err)
+	}
+
+	return NewLocator(l.ctx, l.selector+" >> "+selector, l.frame, l.log)
+}
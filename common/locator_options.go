@@ -0,0 +1,289 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dop251/goja"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// LocatorFilterOptions are the options for narrowing down the elements
+// that a locator matches.
+type LocatorFilterOptions struct {
+	// Has matches the elements that contain an element matching
+	// the selector of the given locator.
+	Has *Locator
+	// HasText matches the elements that contain the given text
+	// somewhere inside, possibly in a child or a descendant element.
+	// It's the body of the internal:has-text selector engine, either a
+	// JSON string, or a regular expression in the /source/flags form.
+	HasText string
+}
+
+// NewLocatorFilterOptions returns a new LocatorFilterOptions.
+func NewLocatorFilterOptions() *LocatorFilterOptions {
+	return &LocatorFilterOptions{}
+}
+
+// Parse parses the locator filter options.
+func (o *LocatorFilterOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(k6ext.Runtime(ctx))
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		if !gojaValueExists(v) {
+			continue
+		}
+		switch k {
+		case "has":
+			l, ok := v.Export().(*Locator)
+			if !ok {
+				return errors.New("has option must be a locator")
+			}
+			o.Has = l
+		case "hasText":
+			text, err := textMatcherBody(v)
+			if err != nil {
+				return fmt.Errorf("parsing hasText option: %w", err)
+			}
+			o.HasText = text
+		}
+	}
+
+	return nil
+}
+
+// selector returns the selector that narrows down the elements matched
+// by the given selector with the filter options.
+func (o *LocatorFilterOptions) selector(selector string) (string, error) {
+	if o.HasText != "" {
+		selector += " >> internal:has-text=" + o.HasText
+	}
+	if o.Has != nil {
+		has, err := quoteSelectorBody(o.Has.selector)
+		if err != nil {
+			return "", fmt.Errorf("encoding has selector: %w", err)
+		}
+		selector += " >> internal:has=" + has
+	}
+
+	return selector, nil
+}
+
+// GetByOptions are the options for locating elements by a text,
+// such as their label or placeholder.
+type GetByOptions struct {
+	// Exact matches the whole text case sensitively, rather than
+	// as a case insensitive substring. It has no effect on regular
+	// expressions.
+	Exact bool
+}
+
+// NewGetByOptions returns a new GetByOptions.
+func NewGetByOptions() *GetByOptions {
+	return &GetByOptions{}
+}
+
+// Parse parses the get by options.
+func (o *GetByOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(k6ext.Runtime(ctx))
+	for _, k := range obj.Keys() {
+		if k == "exact" {
+			o.Exact = obj.Get(k).ToBoolean()
+		}
+	}
+
+	return nil
+}
+
+// GetByRoleOptions are the options for locating elements by their
+// ARIA role. The unset options don't filter the elements.
+type GetByRoleOptions struct {
+	Checked       *bool  `json:"checked,omitempty"`
+	Disabled      *bool  `json:"disabled,omitempty"`
+	Exact         bool   `json:"exact,omitempty"`
+	Expanded      *bool  `json:"expanded,omitempty"`
+	IncludeHidden bool   `json:"includeHidden,omitempty"`
+	Level         *int64 `json:"level,omitempty"`
+	// Name is the accessible name of the elements in the same form as
+	// LocatorFilterOptions.HasText.
+	Name     *string `json:"name,omitempty"`
+	Pressed  *bool   `json:"pressed,omitempty"`
+	Selected *bool   `json:"selected,omitempty"`
+}
+
+// NewGetByRoleOptions returns a new GetByRoleOptions.
+func NewGetByRoleOptions() *GetByRoleOptions {
+	return &GetByRoleOptions{}
+}
+
+// Parse parses the get by role options.
+func (o *GetByRoleOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(k6ext.Runtime(ctx))
+	boolOpt := func(k string) *bool {
+		b := obj.Get(k).ToBoolean()
+		return &b
+	}
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		if !gojaValueExists(v) {
+			continue
+		}
+		switch k {
+		case "checked":
+			o.Checked = boolOpt(k)
+		case "disabled":
+			o.Disabled = boolOpt(k)
+		case "exact":
+			o.Exact = v.ToBoolean()
+		case "expanded":
+			o.Expanded = boolOpt(k)
+		case "includeHidden":
+			o.IncludeHidden = v.ToBoolean()
+		case "level":
+			level := v.ToInteger()
+			o.Level = &level
+		case "name":
+			name, err := textMatcherBody(v)
+			if err != nil {
+				return fmt.Errorf("parsing name option: %w", err)
+			}
+			o.Name = &name
+		case "pressed":
+			o.Pressed = boolOpt(k)
+		case "selected":
+			o.Selected = boolOpt(k)
+		}
+	}
+
+	return nil
+}
+
+// getByRoleSelector returns the selector of the elements with the ARIA role.
+func getByRoleSelector(ctx context.Context, role string, opts goja.Value) (string, error) {
+	popts := NewGetByRoleOptions()
+	if err := popts.Parse(ctx, opts); err != nil {
+		return "", fmt.Errorf("parsing get by role options: %w", err)
+	}
+	body, err := quoteSelectorBody(struct {
+		Role string `json:"role"`
+		*GetByRoleOptions
+	}{role, popts})
+	if err != nil {
+		return "", fmt.Errorf("encoding role selector: %w", err)
+	}
+
+	return "internal:role=" + body, nil
+}
+
+// getByTestIDSelector returns the selector of the elements whose test ID
+// attribute matches the test ID. Unlike the other texts, string test IDs
+// always match exactly.
+func getByTestIDSelector(ctx context.Context, attribute string, testID goja.Value) (string, error) {
+	opts := k6ext.Runtime(ctx).ToValue(map[string]any{"exact": true})
+	return getByAttributeSelector(ctx, attribute, testID, opts)
+}
+
+// getByLabelSelector returns the selector of the elements that are
+// labelled with the text.
+func getByLabelSelector(ctx context.Context, text, opts goja.Value) (string, error) {
+	return getByTextSelector(ctx, "internal:label", "", text, opts)
+}
+
+// getByAttributeSelector returns the selector of the elements with
+// the attribute whose value matches the text.
+func getByAttributeSelector(ctx context.Context, name string, text, opts goja.Value) (string, error) {
+	return getByTextSelector(ctx, "internal:attr", name, text, opts)
+}
+
+func getByTextSelector(ctx context.Context, engine, name string, text, opts goja.Value) (string, error) {
+	popts := NewGetByOptions()
+	if err := popts.Parse(ctx, opts); err != nil {
+		return "", fmt.Errorf("parsing get by options: %w", err)
+	}
+	if !gojaValueExists(text) {
+		return "", errors.New("missing text")
+	}
+	matcher, err := textMatcherBody(text)
+	if err != nil {
+		return "", err
+	}
+	body, err := quoteSelectorBody(struct {
+		Name  string `json:"name,omitempty"`
+		Text  string `json:"text"`
+		Exact bool   `json:"exact"`
+	}{name, matcher, popts.Exact})
+	if err != nil {
+		return "", fmt.Errorf("encoding %s selector: %w", engine, err)
+	}
+
+	return engine + "=" + body, nil
+}
+
+// textMatcherBody returns the text matcher body of the value for the
+// selector engines: a RegExp in the /source/flags form, or otherwise
+// the value as a JSON string.
+func textMatcherBody(v goja.Value) (string, error) {
+	if re, ok := v.(*goja.Object); ok && re.ClassName() == "RegExp" {
+		return escapeRegexpForSelector(re.Get("source").String(), re.Get("flags").String()), nil
+	}
+
+	return quoteSelectorBody(v.String())
+}
+
+// escapeRegexpForSelector returns the RegExp in the /source/flags form,
+// escaping the quotes and the >> in its source so that they don't open
+// a quote or split the selector when it's parsed.
+//
+// The unicode RegExps don't allow escaping the characters that don't need
+// it, so they are returned as is.
+func escapeRegexpForSelector(source, flags string) string {
+	if strings.ContainsAny(flags, "uv") {
+		return "/" + source + "/" + flags
+	}
+
+	var (
+		b       strings.Builder
+		escaped bool
+	)
+	for _, r := range source {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"' || r == '\'' || r == '`':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return "/" + strings.ReplaceAll(b.String(), ">>", `\>\>`) + "/" + flags
+}
+
+// quoteSelectorBody returns v encoded as JSON. Unlike json.Marshal, it
+// keeps the HTML characters as is, so that the selectors stay readable
+// in the logs and the errors.
+func quoteSelectorBody(v any) (string, error) {
+	var buf strings.Builder
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
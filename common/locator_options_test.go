@@ -0,0 +1,181 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocatorFilterOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("has_text", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewLocatorFilterOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"hasText": `Buy "now"`,
+		}))
+		require.NoError(t, err)
+
+		sel, err := opts.selector("li")
+		require.NoError(t, err)
+		assert.Equal(t, `li >> internal:has-text="Buy \"now\""`, sel)
+	})
+
+	t.Run("has_text_regexp", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		re, err := vu.Runtime().RunString(`({ hasText: /buy\s+now/i })`)
+		require.NoError(t, err)
+		opts := NewLocatorFilterOptions()
+		require.NoError(t, opts.Parse(vu.Context(), re))
+
+		sel, err := opts.selector("li")
+		require.NoError(t, err)
+		assert.Equal(t, `li >> internal:has-text=/buy\s+now/i`, sel)
+	})
+
+	t.Run("has", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		has := NewLocator(vu.Context(), "button >> text='Buy'", nil, nil)
+		opts := NewLocatorFilterOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"has":     has,
+			"hasText": "Shoes",
+		}))
+		require.NoError(t, err)
+		assert.Same(t, has, opts.Has)
+
+		sel, err := opts.selector("li")
+		require.NoError(t, err)
+		assert.Equal(t, `li >> internal:has-text="Shoes" >> internal:has="button >> text='Buy'"`, sel)
+
+		parsed, err := NewSelector(sel)
+		require.NoError(t, err)
+		require.Len(t, parsed.Parts, 3)
+		nested := parsed.Parts[2].Nested
+		require.NotNil(t, nested)
+		assert.Equal(t, []*SelectorPart{
+			{Name: "css", Body: "button"},
+			{Name: "text", Body: "'Buy'"},
+		}, nested.Parts)
+	})
+
+	t.Run("err/has", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewLocatorFilterOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"has": "button",
+		}))
+		require.ErrorContains(t, err, "has option must be a locator")
+	})
+}
+
+func TestGetBySelectors(t *testing.T) {
+	t.Parallel()
+
+	// the subtests share the runtime, so they don't run in parallel.
+	vu := k6test.NewVU(t)
+	rt := vu.Runtime()
+	ctx := vu.Context()
+
+	t.Run("role", func(t *testing.T) {
+		sel, err := getByRoleSelector(ctx, "button", nil)
+		require.NoError(t, err)
+		assert.Equal(t, `internal:role={"role":"button"}`, sel)
+	})
+
+	t.Run("role_options", func(t *testing.T) {
+		opts, err := rt.RunString(`({ name: /submit|send/i, checked: false, level: 2, exact: true })`)
+		require.NoError(t, err)
+		sel, err := getByRoleSelector(ctx, "heading", opts)
+		require.NoError(t, err)
+		assert.Equal(t,
+			`internal:role={"role":"heading","checked":false,"exact":true,"level":2,"name":"/submit|send/i"}`,
+			sel,
+		)
+
+		parsed, err := NewSelector("form >> " + sel)
+		require.NoError(t, err)
+		require.Len(t, parsed.Parts, 2)
+		assert.Equal(t, "internal:role", parsed.Parts[1].Name)
+	})
+
+	t.Run("label", func(t *testing.T) {
+		sel, err := getByLabelSelector(ctx, rt.ToValue("Password >>"), vu.ToGojaValue(map[string]any{"exact": true}))
+		require.NoError(t, err)
+		assert.Equal(t, `internal:label={"text":"\"Password >>\"","exact":true}`, sel)
+
+		parsed, err := NewSelector(sel)
+		require.NoError(t, err)
+		require.Len(t, parsed.Parts, 1)
+	})
+
+	t.Run("attribute", func(t *testing.T) {
+		sel, err := getByAttributeSelector(ctx, "placeholder", rt.ToValue("name@example.com"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, `internal:attr={"name":"placeholder","text":"\"name@example.com\"","exact":false}`, sel)
+	})
+
+	t.Run("test_id", func(t *testing.T) {
+		sel, err := getByTestIDSelector(ctx, "data-qa", rt.ToValue("login"))
+		require.NoError(t, err)
+		assert.Equal(t, `internal:attr={"name":"data-qa","text":"\"login\"","exact":true}`, sel)
+	})
+
+	t.Run("err_missing_text", func(t *testing.T) {
+		_, err := getByLabelSelector(ctx, nil, nil)
+		require.ErrorContains(t, err, "missing text")
+	})
+}
+
+func TestEscapeRegexpForSelector(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name, re, want string
+		// asIs is true for the RegExps that are returned as is, and that
+		// can't be parsed back if they have quotes or >>.
+		asIs bool
+	}{
+		{"plain", `/buy\s+now/i`, `/buy\s+now/i`, false},
+		{"quotes", `/"a" 'b' ` + "`c`" + `/`, `/\"a\" \'b\' \` + "`c\\`" + `/`, false},
+		{"escaped_quote", `/a\"b/`, `/a\"b/`, false},
+		{"escaped_backslash", `/a\\"b/`, `/a\\\"b/`, false},
+		{"adjacent_quotes", `/""/`, `/\"\"/`, false},
+		{"next", `/a >> b/`, `/a \>\> b/`, false},
+		{"unicode", `/"a" >> b/u`, `/"a" >> b/u`, true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			vu := k6test.NewVU(t)
+			re, err := vu.Runtime().RunString(tc.re)
+			require.NoError(t, err)
+			body, err := textMatcherBody(re)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, body)
+			if tc.asIs {
+				return
+			}
+
+			// the selector is not split, and its quotes are closed.
+			parsed, err := NewSelector("internal:has-text=" + body + " >> span")
+			require.NoError(t, err)
+			require.Len(t, parsed.Parts, 2)
+			assert.Equal(t, body, parsed.Parts[0].Body)
+		})
+	}
+}
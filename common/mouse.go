@@ -22,6 +22,7 @@ type Mouse struct {
 	frame           *Frame
 	timeoutSettings *TimeoutSettings
 	keyboard        *Keyboard
+	drag            *dragManager
 	x               float64
 	y               float64
 	button          input.MouseButton
@@ -35,6 +36,7 @@ func NewMouse(ctx context.Context, s session, f *Frame, ts *TimeoutSettings, k *
 		frame:           f,
 		timeoutSettings: ts,
 		keyboard:        k,
+		drag:            newDragManager(ctx, s, f.manager),
 		button:          input.None,
 	}
 }
@@ -87,6 +89,10 @@ func (m *Mouse) dblClick(x float64, y float64, opts *MouseDblClickOptions) error
 
 func (m *Mouse) down(x float64, y float64, opts *MouseDownUpOptions) error {
 	m.button = input.MouseButton(opts.Button)
+	// The button is already pressed for the drag in progress.
+	if m.drag.isDragging() {
+		return nil
+	}
 	action := input.DispatchMouseEvent(input.MousePressed, m.x, m.y).
 		WithButton(input.MouseButton(opts.Button)).
 		WithModifiers(input.Modifier(m.keyboard.modifiers)).
@@ -102,23 +108,39 @@ func (m *Mouse) move(x float64, y float64, opts *MouseMoveOptions) error {
 	var fromY float64 = m.y
 	m.x = x
 	m.y = y
+	modifiers := input.Modifier(m.keyboard.modifiers)
 	for i := int64(1); i <= opts.Steps; i++ {
-		x := fromX + (m.x-fromX)*float64(i/opts.Steps)
-		y := fromY + (m.y-fromY)*float64(i/opts.Steps)
-		action := input.DispatchMouseEvent(input.MouseMoved, x, y).
-			WithButton(m.button).
-			WithModifiers(input.Modifier(m.keyboard.modifiers))
-		if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
+		x := fromX + (m.x-fromX)*float64(i)/float64(opts.Steps)
+		y := fromY + (m.y-fromY)*float64(i)/float64(opts.Steps)
+		move := func() error {
+			action := input.DispatchMouseEvent(input.MouseMoved, x, y).
+				WithButton(m.button).
+				WithModifiers(modifiers)
+			return action.Do(cdp.WithExecutor(m.ctx, m.session))
+		}
+		if err := m.drag.interceptDragCausedByMove(x, y, m.button, modifiers, move); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (m *Mouse) wheel(deltaX float64, deltaY float64) error {
+	action := input.DispatchMouseEvent(input.MouseWheel, m.x, m.y).
+		WithModifiers(input.Modifier(m.keyboard.modifiers)).
+		WithDeltaX(deltaX).
+		WithDeltaY(deltaY)
+	return action.Do(cdp.WithExecutor(m.ctx, m.session))
+}
+
 func (m *Mouse) up(x float64, y float64, opts *MouseDownUpOptions) error {
 	var button input.MouseButton = input.Left
 	var clickCount int64 = 1
 	m.button = input.None
+	// The drag in progress ends with a drop rather than a mouse release.
+	if m.drag.isDragging() {
+		return m.drag.drop(m.x, m.y, input.Modifier(m.keyboard.modifiers))
+	}
 	action := input.DispatchMouseEvent(input.MouseReleased, m.x, m.y).
 		WithButton(button).
 		WithModifiers(input.Modifier(m.keyboard.modifiers)).
@@ -163,11 +185,11 @@ func (m *Mouse) Down(x float64, y float64, opts goja.Value) {
 
 // Move will trigger a MouseMoved event in the browser.
 func (m *Mouse) Move(x float64, y float64, opts goja.Value) {
-	mouseOpts := NewMouseDownUpOptions()
+	mouseOpts := NewMouseMoveOptions()
 	if err := mouseOpts.Parse(m.ctx, opts); err != nil {
 		k6ext.Panic(m.ctx, "parsing mouse move options: %w", err)
 	}
-	if err := m.down(x, y, mouseOpts); err != nil {
+	if err := m.move(x, y, mouseOpts); err != nil {
 		k6ext.Panic(m.ctx, "moving the mouse pointer to x:%f y:%f: %w", x, y, err)
 	}
 }
@@ -183,28 +205,11 @@ func (m *Mouse) Up(x float64, y float64, opts goja.Value) {
 	}
 }
 
-// Wheel will trigger a MouseWheel event in the browser
-/*func (m *Mouse) Wheel(opts goja.Value) {
-	var deltaX float64 = 0.0
-	var deltaY float64 = 0.0
-
-	if opts != nil && !goja.IsUndefined(opts) && !goja.IsNull(opts) {
-		opts := opts.ToObject(rt)
-		for _, k := range opts.Keys() {
-			switch k {
-			case "deltaX":
-				deltaX = opts.Get(k).ToFloat()
-			case "deltaY":
-				deltaY = opts.Get(k).ToFloat()
-			}
-		}
-	}
-
-	action := input.DispatchMouseEvent(input.MouseWheel, m.x, m.y).
-		WithModifiers(input.Modifier(m.keyboard.modifiers)).
-		WithDeltaX(deltaX).
-		WithDeltaY(deltaY)
-	if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
-		k6Throw(m.ctx, "mouse down: %w", err)
+// Wheel will trigger a MouseWheel event in the browser at the current
+// position of the mouse pointer.
+func (m *Mouse) Wheel(deltaX float64, deltaY float64) {
+	if err := m.wheel(deltaX, deltaY); err != nil {
+		k6ext.Panic(m.ctx, "scrolling the mouse wheel by x:%f y:%f: %w", deltaX, deltaY, err)
 	}
-}*/
+	applySlowMo(m.ctx)
+}
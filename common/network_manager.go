@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/grafana/xk6-browser/log"
@@ -51,11 +53,21 @@ type NetworkManager struct {
 
 	attemptedAuth map[fetch.RequestID]bool
 
+	// webSockets are the WebSockets that are opened in the session
+	// and aren't closed yet.
+	webSockets   map[network.RequestID]*WebSocket
+	webSocketsMu sync.RWMutex
+
 	extraHTTPHeaders               map[string]string
 	offline                        bool
 	userCacheDisabled              bool
 	userReqInterceptionEnabled     bool
 	protocolReqInterceptionEnabled bool
+
+	// emptyResponses fulfills the requests with an empty document instead
+	// of sending them to the servers, and doesn't emit metrics for them.
+	// It's used by the internal pages that access the storage of origins.
+	emptyResponses atomic.Bool
 }
 
 // NewNetworkManager creates a new network manager.
@@ -84,6 +96,7 @@ func NewNetworkManager(
 		customMetrics:    customMetrics,
 		reqIDToRequest:   make(map[network.RequestID]*Request),
 		attemptedAuth:    make(map[fetch.RequestID]bool),
+		webSockets:       make(map[network.RequestID]*WebSocket),
 		extraHTTPHeaders: make(map[string]string),
 	}
 	m.initEvents()
@@ -145,6 +158,9 @@ func (m *NetworkManager) deleteRequestByID(reqID network.RequestID) {
 }
 
 func (m *NetworkManager) emitRequestMetrics(req *Request) {
+	if m.emptyResponses.Load() {
+		return
+	}
 	state := m.vu.State()
 
 	tags := state.Tags.GetCurrentValues().Tags
@@ -167,6 +183,9 @@ func (m *NetworkManager) emitRequestMetrics(req *Request) {
 }
 
 func (m *NetworkManager) emitResponseMetrics(resp *Response, req *Request) {
+	if m.emptyResponses.Load() {
+		return
+	}
 	state := m.vu.State()
 
 	// In some scenarios we might not receive a ResponseReceived CDP event, in
@@ -249,6 +268,30 @@ func (m *NetworkManager) emitResponseMetrics(resp *Response, req *Request) {
 	}
 }
 
+// emitWebSocketMetrics emits the values of the WebSocket metrics,
+// which are tagged with the URL of the WebSocket.
+func (m *NetworkManager) emitWebSocketMetrics(ws *WebSocket, values map[*k6metrics.Metric]float64) {
+	state := m.vu.State()
+
+	tags := state.Tags.GetCurrentValues().Tags
+	if state.Options.SystemTags.Has(k6metrics.TagURL) {
+		tags = tags.With("url", ws.URL())
+	}
+
+	now := time.Now()
+	samples := make([]k6metrics.Sample, 0, len(values))
+	for metric, value := range values {
+		samples = append(samples, k6metrics.Sample{
+			TimeSeries: k6metrics.TimeSeries{Metric: metric, Tags: tags},
+			Value:      value,
+			Time:       now,
+		})
+	}
+	k6metrics.PushIfNotDone(m.vu.Context(), state.Samples, k6metrics.ConnectedSamples{
+		Samples: samples,
+	})
+}
+
 func (m *NetworkManager) handleRequestRedirect(req *Request, redirectResponse *network.Response, timestamp *cdp.MonotonicTime) {
 	resp := NewHTTPResponse(m.ctx, req, redirectResponse, timestamp)
 	req.responseMu.Lock()
@@ -293,6 +336,11 @@ func (m *NetworkManager) initEvents() {
 		cdproto.EventNetworkRequestWillBeSent,
 		cdproto.EventNetworkRequestServedFromCache,
 		cdproto.EventNetworkResponseReceived,
+		cdproto.EventNetworkWebSocketCreated,
+		cdproto.EventNetworkWebSocketFrameSent,
+		cdproto.EventNetworkWebSocketFrameReceived,
+		cdproto.EventNetworkWebSocketFrameError,
+		cdproto.EventNetworkWebSocketClosed,
 		cdproto.EventFetchRequestPaused,
 		cdproto.EventFetchAuthRequired,
 	}, chHandler)
@@ -324,6 +372,16 @@ func (m *NetworkManager) handleEvents(in <-chan Event) bool {
 			m.onRequestServedFromCache(ev)
 		case *network.EventResponseReceived:
 			m.onResponseReceived(ev)
+		case *network.EventWebSocketCreated:
+			m.onWebSocketCreated(ev)
+		case *network.EventWebSocketFrameSent:
+			m.onWebSocketFrame(ev.RequestID, EventWebSocketFrameSent, ev.Response)
+		case *network.EventWebSocketFrameReceived:
+			m.onWebSocketFrame(ev.RequestID, EventWebSocketFrameReceived, ev.Response)
+		case *network.EventWebSocketFrameError:
+			m.onWebSocketFrameError(ev)
+		case *network.EventWebSocketClosed:
+			m.onWebSocketClosed(ev)
 		case *fetch.EventRequestPaused:
 			m.onRequestPaused(ev)
 		case *fetch.EventAuthRequired:
@@ -377,6 +435,68 @@ func (m *NetworkManager) onLoadingFinished(event *network.EventLoadingFinished)
 	m.frameManager.requestFinished(req)
 }
 
+func (m *NetworkManager) webSocketFromID(id network.RequestID) *WebSocket {
+	m.webSocketsMu.RLock()
+	defer m.webSocketsMu.RUnlock()
+
+	return m.webSockets[id]
+}
+
+func (m *NetworkManager) onWebSocketCreated(event *network.EventWebSocketCreated) {
+	ws := NewWebSocket(m.ctx, m.frameManager.page, event.RequestID, event.URL, m.logger)
+
+	m.webSocketsMu.Lock()
+	m.webSockets[event.RequestID] = ws
+	m.webSocketsMu.Unlock()
+
+	m.emitWebSocketMetrics(ws, map[*k6metrics.Metric]float64{
+		m.customMetrics.BrowserWSSessions: 1,
+	})
+	m.frameManager.webSocketCreated(ws)
+}
+
+func (m *NetworkManager) onWebSocketFrame(id network.RequestID, event string, frame *network.WebSocketFrame) {
+	ws := m.webSocketFromID(id)
+	if ws == nil {
+		return
+	}
+	f, err := ws.onFrame(event, frame)
+	if err != nil {
+		m.logger.Debugf("NetworkManager:onWebSocketFrame", "url:%q event:%q err:%v", ws.URL(), event, err)
+		return
+	}
+
+	msgs, data := m.customMetrics.BrowserWSMsgsSent, m.customMetrics.BrowserWSDataSent
+	if event == EventWebSocketFrameReceived {
+		msgs, data = m.customMetrics.BrowserWSMsgsReceived, m.customMetrics.BrowserWSDataReceived
+	}
+	m.emitWebSocketMetrics(ws, map[*k6metrics.Metric]float64{
+		msgs: 1,
+		data: float64(len(f.Payload)),
+	})
+}
+
+func (m *NetworkManager) onWebSocketFrameError(event *network.EventWebSocketFrameError) {
+	if ws := m.webSocketFromID(event.RequestID); ws != nil {
+		ws.onError(event.ErrorMessage)
+	}
+}
+
+func (m *NetworkManager) onWebSocketClosed(event *network.EventWebSocketClosed) {
+	m.webSocketsMu.Lock()
+	ws := m.webSockets[event.RequestID]
+	delete(m.webSockets, event.RequestID)
+	m.webSocketsMu.Unlock()
+	if ws == nil {
+		return
+	}
+
+	ws.onClose()
+	m.emitWebSocketMetrics(ws, map[*k6metrics.Metric]float64{
+		m.customMetrics.BrowserWSSessionDuration: k6metrics.D(time.Since(ws.start)),
+	})
+}
+
 func isInternalURL(u *url.URL) bool {
 	return u.Scheme == "data" || u.Scheme == "blob"
 }
@@ -449,6 +569,13 @@ func (m *NetworkManager) onRequestPaused(event *fetch.EventRequestPaused) {
 				return
 			}
 		}
+		if m.emptyResponses.Load() {
+			m.fulfillEmptyResponse(event)
+			return
+		}
+		if m.routeRequest(event) {
+			return
+		}
 		action := fetch.ContinueRequest(event.RequestID)
 		if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
 			m.logger.Errorf("NetworkManager:onRequestPaused",
@@ -487,6 +614,57 @@ func (m *NetworkManager) onRequestPaused(event *fetch.EventRequestPaused) {
 	failErr = checkBlockedIPs(ip, state.Options.BlacklistIPs)
 }
 
+// routeRequest hands the paused request over to the route handlers of the
+// page. It returns false if the request should be continued as is.
+func (m *NetworkManager) routeRequest(event *fetch.EventRequestPaused) bool {
+	if m.frameManager == nil || m.frameManager.page == nil {
+		return false
+	}
+	p := m.frameManager.page
+	if !p.hasRoutes() {
+		return false
+	}
+
+	req := m.requestFromID(event.NetworkID)
+	if req == nil {
+		// The request is paused before Network.requestWillBeSent is received.
+		var err error
+		req, err = m.newRequestFromPaused(event)
+		if err != nil {
+			m.logger.Errorf("NetworkManager:routeRequest", "creating request: %s", err)
+			return false
+		}
+	}
+
+	return p.routeRequest(NewRoute(m.ctx, m.session, req, event.RequestID, m.logger))
+}
+
+// newRequestFromPaused creates a new request from a paused request event.
+func (m *NetworkManager) newRequestFromPaused(event *fetch.EventRequestPaused) (*Request, error) {
+	var frame *Frame
+	if event.FrameID != "" {
+		frame = m.frameManager.getFrameByID(event.FrameID)
+	}
+	now := time.Now()
+	ts := cdp.MonotonicTime(now)
+	wt := cdp.TimeSinceEpoch(now)
+
+	return NewRequest(m.ctx, NewRequestParams{
+		event: &network.EventRequestWillBeSent{
+			RequestID: event.NetworkID,
+			Request:   event.Request,
+			Timestamp: &ts,
+			WallTime:  &wt,
+			Type:      event.ResourceType,
+			FrameID:   event.FrameID,
+		},
+		frame:             frame,
+		redirectChain:     make([]*Request, 0),
+		interceptionID:    event.RequestID.String(),
+		allowInterception: true,
+	})
+}
+
 func checkBlockedHosts(host string, blockedHosts *k6types.HostnameTrie) error {
 	if blockedHosts == nil {
 		return nil
@@ -571,6 +749,26 @@ func (m *NetworkManager) requestFromID(reqID network.RequestID) *Request {
 	return m.reqIDToRequest[reqID]
 }
 
+// fulfillEmptyResponse fulfills the paused request with an empty document.
+func (m *NetworkManager) fulfillEmptyResponse(event *fetch.EventRequestPaused) {
+	action := fetch.FulfillRequest(event.RequestID, http.StatusOK).
+		WithResponseHeaders([]*fetch.HeaderEntry{
+			{Name: "Content-Type", Value: "text/html"},
+		}).
+		WithBody("")
+	if err := action.Do(cdp.WithExecutor(m.ctx, m.session)); err != nil {
+		m.logger.Errorf("NetworkManager:fulfillEmptyResponse",
+			"fulfilling request %s: %s", event.Request.URL, err)
+	}
+}
+
+// setEmptyResponses enables or disables fulfilling the requests
+// with an empty document. See emptyResponses.
+func (m *NetworkManager) setEmptyResponses(value bool) error {
+	m.emptyResponses.Store(value)
+	return m.setRequestInterception(value)
+}
+
 func (m *NetworkManager) setRequestInterception(value bool) error {
 	m.userReqInterceptionEnabled = value
 	return m.updateProtocolRequestInterception()
@@ -589,7 +787,7 @@ func (m *NetworkManager) updateProtocolCacheDisabled() error {
 }
 
 func (m *NetworkManager) updateProtocolRequestInterception() error {
-	enabled := m.userReqInterceptionEnabled
+	enabled := m.userReqInterceptionEnabled || m.credentials != nil
 	if enabled == m.protocolReqInterceptionEnabled {
 		return nil
 	}
@@ -2,8 +2,11 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -32,6 +35,27 @@ var (
 	_ api.Page     = &Page{}
 )
 
+// pageEvents are the events that can be subscribed to with Page.On.
+var pageEvents = map[string]struct{}{ //nolint:gochecknoglobals
+	EventPageClose:           {},
+	EventPageConsole:         {},
+	EventPageCrash:           {},
+	EventPageDialog:          {},
+	EventPageDownload:        {},
+	EventPageFilechooser:     {},
+	EventPageFrameAttached:   {},
+	EventPageFrameDetached:   {},
+	EventPageFrameNavigated:  {},
+	EventPageError:           {},
+	EventPagePopup:           {},
+	EventPageRequest:         {},
+	EventPageRequestFailed:   {},
+	EventPageRequestFinished: {},
+	EventPageResponse:        {},
+	EventPageWebSocket:       {},
+	EventPageWorker:          {},
+}
+
 // Page stores Page/tab related context.
 type Page struct {
 	BaseEventEmitter
@@ -68,14 +92,36 @@ type Page struct {
 	extraHTTPHeaders map[string]string
 
 	backgroundPage bool
+	// internal is true for the pages that are only used internally, such
+	// as for accessing the storage of origins. They are not one of the pages
+	// of the browser, and they don't get the init scripts and the exposed
+	// bindings of the browser context.
+	internal bool
 
 	mainFrameSession *FrameSession
 	// TODO: FrameSession changes by attachFrameSession (mutex?)
 	frameSessions map[cdp.FrameID]*FrameSession
+	workersMu     sync.RWMutex
 	workers       map[target.SessionID]*Worker
-	routes        []api.Route
+	routes        routeHandlers
+	bindings      bindings
 	vu            k6modules.VU
 
+	// taskQueue runs the JS handlers, such as the route and event
+	// handlers, on the VU event loop.
+	taskQueueMu sync.Mutex
+	taskQueue   *k6ext.TaskQueue
+
+	// video is the video recording of the page, if any.
+	videoMu sync.RWMutex
+	video   *Video
+
+	eventHandlers eventHandlers
+	// fileChooserWaiters is the number of the file chooser events
+	// that are waited for with WaitForEvent.
+	fileChooserWaitersMu sync.Mutex
+	fileChooserWaiters   int
+
 	logger *log.Logger
 }
 
@@ -88,6 +134,21 @@ func NewPage(
 	opener *Page,
 	bp bool,
 	logger *log.Logger,
+) (*Page, error) {
+	return newPage(ctx, s, bctx, tid, opener, bp, false, logger)
+}
+
+// newPage creates a new page that's internal if internal is true.
+// See Page.internal.
+func newPage(
+	ctx context.Context,
+	s session,
+	bctx *BrowserContext,
+	tid target.ID,
+	opener *Page,
+	bp bool,
+	internal bool,
+	logger *log.Logger,
 ) (*Page, error) {
 	p := Page{
 		BaseEventEmitter: NewBaseEventEmitter(ctx),
@@ -97,6 +158,7 @@ func NewPage(
 		targetID:         tid,
 		opener:           opener,
 		backgroundPage:   bp,
+		internal:         internal,
 		mediaType:        MediaTypeScreen,
 		colorScheme:      bctx.opts.ColorScheme,
 		reducedMotion:    bctx.opts.ReducedMotion,
@@ -106,7 +168,6 @@ func NewPage(
 		jsEnabled:        true,
 		frameSessions:    make(map[cdp.FrameID]*FrameSession),
 		workers:          make(map[target.SessionID]*Worker),
-		routes:           make([]api.Route, 0),
 		vu:               k6ext.GetVU(ctx),
 		logger:           logger,
 	}
@@ -114,6 +175,12 @@ func NewPage(
 	p.logger.Debugf("Page:NewPage", "sid:%v tid:%v backgroundPage:%t",
 		p.sessionID(), tid, bp)
 
+	if layout := bctx.opts.KeyboardLayout; layout != "" {
+		if err := p.Keyboard.setLayout(layout); err != nil {
+			return nil, err
+		}
+	}
+
 	// We need to init viewport and screen size before initializing the main frame session,
 	// as that's where the emulation is activated.
 	if bctx.opts.Viewport != nil {
@@ -143,6 +210,9 @@ func NewPage(
 		return nil, fmt.Errorf("internal error while adding binding to page: %w", err)
 	}
 
+	if internal {
+		return &p, nil
+	}
 	if err := bctx.applyAllInitScripts(&p); err != nil {
 		return nil, fmt.Errorf("internal error while applying init scripts to page: %w", err)
 	}
@@ -153,10 +223,29 @@ func NewPage(
 func (p *Page) closeWorker(sessionID target.SessionID) {
 	p.logger.Debugf("Page:closeWorker", "sid:%v", sessionID)
 
-	if worker, ok := p.workers[sessionID]; ok {
-		worker.didClose()
-		delete(p.workers, sessionID)
+	p.workersMu.RLock()
+	worker, ok := p.workers[sessionID]
+	p.workersMu.RUnlock()
+	if !ok {
+		return
 	}
+	worker.didClose()
+
+	p.workersMu.Lock()
+	delete(p.workers, sessionID)
+	p.workersMu.Unlock()
+}
+
+// addWorker adds the worker that is attached with the session ID
+// and emits the worker event.
+func (p *Page) addWorker(sessionID target.SessionID, w *Worker) {
+	p.logger.Debugf("Page:addWorker", "sid:%v wsid:%v", p.sessionID(), sessionID)
+
+	p.workersMu.Lock()
+	p.workers[sessionID] = w
+	p.workersMu.Unlock()
+
+	p.emit(EventPageWorker, w)
 }
 
 func (p *Page) defaultTimeout() time.Duration {
@@ -173,6 +262,11 @@ func (p *Page) didClose() {
 	p.closedMu.Unlock()
 
 	p.emit(EventPageClose, p)
+	p.closeTaskQueue()
+
+	if v := p.getVideo(); v != nil {
+		v.finish()
+	}
 }
 
 func (p *Page) didCrash() {
@@ -284,8 +378,274 @@ func (p *Page) getFrameSession(frameID cdp.FrameID) *FrameSession {
 	return p.frameSessions[frameID]
 }
 
+// emit emits the event to the listeners of the page, and queues the
+// handlers registered with On to run on the VU event loop.
+func (p *Page) emit(event string, data any) {
+	p.BaseEventEmitter.emit(event, data)
+	p.dispatchEvent(event, data)
+}
+
+func (p *Page) getVideo() *Video {
+	p.videoMu.RLock()
+	defer p.videoMu.RUnlock()
+
+	return p.video
+}
+
+func (p *Page) setVideo(v *Video) {
+	p.videoMu.Lock()
+	defer p.videoMu.Unlock()
+
+	p.video = v
+}
+
+// hasEventHandlers returns true if there are handlers registered
+// for the event with On.
+func (p *Page) hasEventHandlers(event string) bool {
+	return p.eventHandlers.has(event)
+}
+
+// interceptsFileChooser returns true if the file choosers of the page are
+// emitted to its file chooser handlers or waiters instead of opening the
+// file dialog of the browser.
+func (p *Page) interceptsFileChooser() bool {
+	p.fileChooserWaitersMu.Lock()
+	defer p.fileChooserWaitersMu.Unlock()
+
+	return p.eventHandlers.has(EventPageFilechooser) || p.fileChooserWaiters > 0
+}
+
+// dispatchEvent queues the handlers registered for the event to be
+// called with the event data on the VU event loop.
+func (p *Page) dispatchEvent(event string, data any) {
+	p.eventHandlers.dispatch(p.queueTask, event, data)
+}
+
+// getTaskQueue returns the task queue of the page, and creates it if
+// it doesn't exist yet. It must be called on the VU event loop.
+func (p *Page) getTaskQueue() *k6ext.TaskQueue {
+	p.taskQueueMu.Lock()
+	defer p.taskQueueMu.Unlock()
+
+	if p.taskQueue == nil {
+		p.taskQueue = k6ext.NewTaskQueue(p.vu.RegisterCallback)
+	}
+
+	return p.taskQueue
+}
+
+// closeTaskQueue closes the task queue of the page, if any, so that
+// the VU event loop doesn't wait for the page's handlers anymore.
+func (p *Page) closeTaskQueue() {
+	p.taskQueueMu.Lock()
+	defer p.taskQueueMu.Unlock()
+
+	if p.taskQueue != nil {
+		p.taskQueue.Close()
+	}
+}
+
+// queueTask queues the task on the task queue of the page or, if the
+// page doesn't have one, on the task queue of the browser context.
+// It returns false if there is no task queue to run the task.
+func (p *Page) queueTask(task func() error) bool {
+	p.taskQueueMu.Lock()
+	tq := p.taskQueue
+	p.taskQueueMu.Unlock()
+
+	if tq == nil {
+		tq = p.browserCtx.currentTaskQueue()
+	}
+	if tq == nil {
+		return false
+	}
+	tq.Queue(task)
+
+	return true
+}
+
+// hasRoutes returns true if the page or its browser context has
+// route handlers registered.
 func (p *Page) hasRoutes() bool {
-	return len(p.routes) > 0
+	return p.routes.count() > 0 || p.browserCtx.hasRoutes()
+}
+
+// routeRequest hands the route over to the matching route handler of the
+// page or the browser context on the VU event loop. The request is continued
+// if no route handlers match. It returns false if the route can't be handled
+// by the route handlers.
+func (p *Page) routeRequest(r *Route) bool {
+	return p.queueTask(func() error {
+		handlers := append(p.routes.all(), p.browserCtx.routes.all()...)
+		for _, h := range handlers {
+			handled, err := h.handle(r)
+			if err != nil {
+				return err
+			}
+			if handled {
+				return nil
+			}
+		}
+		if err := r.continueRequest(NewRouteContinueOptions()); err != nil {
+			p.logger.Debugf("Page:routeRequest", "sid:%v url:%q continuing request: %v",
+				p.sessionID(), r.request.URL(), err)
+		}
+
+		return nil
+	})
+}
+
+// installBinding installs the binding in the new documents and the
+// current documents of the frames of the page.
+func (p *Page) installBinding(b *binding) error {
+	for _, fs := range p.frameSessions {
+		if err := fs.addBindingScript(b); err != nil {
+			return err
+		}
+	}
+	for _, f := range p.frameManager.Frames() {
+		// The frames without an execution context don't have a document
+		// yet, so the binding script installs the binding for them.
+		fr, ok := f.(*Frame)
+		if !ok || !fr.hasContext(mainWorld) {
+			continue
+		}
+		if _, err := fr.evalInMainWorld(bindingInstallScript, b.name, exposedBinding, b.handle); err != nil {
+			// The frame might be navigating, in which case the binding
+			// script installs the binding in the new document.
+			p.logger.Debugf("Page:installBinding", "sid:%v fid:%v name:%s installing binding: %v",
+				p.sessionID(), fr.ID(), b.name, err)
+		}
+	}
+
+	return nil
+}
+
+// onBindingCalled calls the exposed binding that a page called in the
+// execution context.
+func (p *Page) onBindingCalled(ec *ExecutionContext, payload string) {
+	var call bindingCall
+	if err := json.Unmarshal([]byte(payload), &call); err != nil {
+		p.logger.Errorf("Page:onBindingCalled", "sid:%v parsing binding call: %v", p.sessionID(), err)
+		return
+	}
+	b := p.bindings.get(call.Name)
+	if b == nil {
+		b = p.browserCtx.bindings.get(call.Name)
+	}
+	if b == nil {
+		p.logger.Debugf("Page:onBindingCalled", "sid:%v name:%s binding not found", p.sessionID(), call.Name)
+		return
+	}
+
+	// The binding is called in another goroutine to not block the
+	// events of the frame session with the CDP calls.
+	go p.callBinding(ec, b, &call)
+}
+
+// callBinding calls the callback of the binding on the VU event loop and
+// delivers its result to the page.
+func (p *Page) callBinding(ec *ExecutionContext, b *binding, call *bindingCall) {
+	args := call.Args
+	if b.handle {
+		const getHandle = `(name, seq) => globalThis[name].__k6browserHandle(seq)`
+		h, err := ec.eval(p.ctx, evalOptions{forceCallable: true}, getHandle, call.Name, call.Seq)
+		if err != nil {
+			p.deliverBindingResult(ec, call, "", fmt.Sprintf("getting binding argument: %v", err))
+			return
+		}
+		args = []any{h}
+	}
+
+	queued := p.queueTask(func() error {
+		source := &api.BindingSource{
+			BrowserContext: p.browserCtx,
+			Page:           p,
+			Frame:          ec.Frame(),
+		}
+		v, err := b.callback(source, args...)
+		p.settleBindingCall(ec, call, v, err)
+
+		return nil
+	})
+	if !queued {
+		p.deliverBindingResult(ec, call, "", "page is closed")
+	}
+}
+
+// settleBindingCall delivers the result of the binding callback to the
+// page, waiting for the result first if it's a promise. It must be called
+// on the VU event loop.
+func (p *Page) settleBindingCall(ec *ExecutionContext, call *bindingCall, v goja.Value, err error) {
+	if promise, ok := exportedPromise(v); ok && err == nil {
+		switch promise.State() {
+		case goja.PromiseStatePending:
+			rt := p.vu.Runtime()
+			then, _ := goja.AssertFunction(v.ToObject(rt).Get("then"))
+			onFulfilled := func(v goja.Value) {
+				p.settleBindingCall(ec, call, v, nil)
+			}
+			onRejected := func(reason goja.Value) {
+				p.settleBindingCall(ec, call, nil, errors.New(jsErrorMessage(reason)))
+			}
+			if _, err = then(v, rt.ToValue(onFulfilled), rt.ToValue(onRejected)); err == nil {
+				return
+			}
+		case goja.PromiseStateFulfilled:
+			v = promise.Result()
+		case goja.PromiseStateRejected:
+			err = errors.New(jsErrorMessage(promise.Result()))
+		}
+	}
+
+	result, errMsg := bindingResult(v, err)
+	go p.deliverBindingResult(ec, call, result, errMsg)
+}
+
+// deliverBindingResult settles the promise of the binding call in the page
+// with the result encoded as JSON or, if errMsg isn't empty, with an error.
+func (p *Page) deliverBindingResult(ec *ExecutionContext, call *bindingCall, result, errMsg string) {
+	const deliver = `(name, seq, result, error) => globalThis[name].__k6browserDeliver(seq, result, error)`
+	_, err := ec.eval(p.ctx, evalOptions{forceCallable: true}, deliver, call.Name, call.Seq, result, errMsg)
+	if err != nil {
+		// The page might have navigated away since the call.
+		p.logger.Debugf("Page:deliverBindingResult", "sid:%v name:%s seq:%d delivering result: %v",
+			p.sessionID(), call.Name, call.Seq, err)
+	}
+}
+
+func (p *Page) updateRequestInterception() error {
+	p.logger.Debugf("Page:updateRequestInterception", "sid:%v", p.sessionID())
+
+	for _, fs := range p.frameSessions {
+		if err := fs.updateRequestInterception(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Page) updateFileChooserInterception() error {
+	p.logger.Debugf("Page:updateFileChooserInterception", "sid:%v", p.sessionID())
+
+	for _, fs := range p.frameSessions {
+		if err := fs.updateFileChooserInterception(false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateFileChooserWaiters adds delta to the number of the file chooser
+// waiters, and updates the file chooser interception of the page.
+func (p *Page) updateFileChooserWaiters(delta int) error {
+	p.fileChooserWaitersMu.Lock()
+	p.fileChooserWaiters += delta
+	p.fileChooserWaitersMu.Unlock()
+
+	return p.updateFileChooserInterception()
 }
 
 func (p *Page) resetViewport() error {
@@ -372,14 +732,18 @@ func (p *Page) AddInitScript(script goja.Value, arg goja.Value) {
 	k6ext.Panic(p.ctx, "Page.addInitScript(script, arg) has not been implemented yet")
 }
 
-// AddScriptTag is not implemented.
-func (p *Page) AddScriptTag(opts goja.Value) {
-	k6ext.Panic(p.ctx, "Page.addScriptTag(opts) has not been implemented yet")
+// AddScriptTag adds a script tag to the main frame of the page.
+func (p *Page) AddScriptTag(opts goja.Value) (api.ElementHandle, error) {
+	p.logger.Debugf("Page:AddScriptTag", "sid:%v", p.sessionID())
+
+	return p.MainFrame().AddScriptTag(opts)
 }
 
-// AddStyleTag is not implemented.
-func (p *Page) AddStyleTag(opts goja.Value) {
-	k6ext.Panic(p.ctx, "Page.addStyleTag(opts) has not been implemented yet")
+// AddStyleTag adds a style tag to the main frame of the page.
+func (p *Page) AddStyleTag(opts goja.Value) (api.ElementHandle, error) {
+	p.logger.Debugf("Page:AddStyleTag", "sid:%v", p.sessionID())
+
+	return p.MainFrame().AddStyleTag(opts)
 }
 
 // BringToFront activates the browser tab for this page.
@@ -427,6 +791,7 @@ func (p *Page) Close(opts goja.Value) error {
 
 	add := runtime.RemoveBinding(webVitalBinding)
 	if err := add.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
+		p.closeTaskQueue()
 		return fmt.Errorf("internal error while removing binding from page: %w", err)
 	}
 
@@ -445,10 +810,14 @@ func (p *Page) Close(opts goja.Value) error {
 		if errors.Is(err, context.Canceled) {
 			return nil
 		}
+		p.closeTaskQueue()
 
 		return fmt.Errorf("closing a page: %w", err)
 	}
 
+	// The task queue is closed when the browser detaches from the page,
+	// after the close event handlers are queued. See didClose.
+
 	return nil
 }
 
@@ -477,9 +846,12 @@ func (p *Page) DispatchEvent(selector string, typ string, eventInit goja.Value,
 	p.MainFrame().DispatchEvent(selector, typ, eventInit, opts)
 }
 
-// DragAndDrop is not implemented.
+// DragAndDrop drags the element matching the source selector and
+// drops it onto the element matching the target selector.
 func (p *Page) DragAndDrop(source string, target string, opts goja.Value) {
-	k6ext.Panic(p.ctx, "Page.DragAndDrop(source, target, opts) has not been implemented yet")
+	p.logger.Debugf("Page:DragAndDrop", "sid:%v source:%q target:%q", p.sessionID(), source, target)
+
+	p.MainFrame().DragAndDrop(source, target, opts)
 }
 
 func (p *Page) EmulateMedia(opts goja.Value) {
@@ -546,14 +918,43 @@ func (p *Page) EvaluateHandle(pageFunc goja.Value, args ...goja.Value) (api.JSHa
 	return h, nil
 }
 
-// ExposeBinding is not implemented.
-func (p *Page) ExposeBinding(name string, callback goja.Callable, opts goja.Value) {
-	k6ext.Panic(p.ctx, "Page.exposeBinding(name, callback) has not been implemented yet")
+// ExposeBinding adds a function with the name to the global object of every
+// frame of the page, including the frames that navigate or attach later.
+// Calling the function calls the callback on the VU with the source of the
+// call and the arguments, and returns a promise of the callback's result.
+func (p *Page) ExposeBinding(name string, callback api.BindingCallback, opts goja.Value) {
+	p.logger.Debugf("Page:ExposeBinding", "sid:%v name:%s", p.sessionID(), name)
+
+	popts := NewExposeBindingOptions()
+	if err := popts.Parse(p.ctx, opts); err != nil {
+		k6ext.Panic(p.ctx, "parsing expose binding options: %w", err)
+	}
+	if err := p.exposeBinding(newBinding(name, callback, popts.Handle)); err != nil {
+		k6ext.Panic(p.ctx, "exposing binding %q: %w", name, err)
+	}
 }
 
-// ExposeFunction is not implemented.
+// ExposeFunction is like ExposeBinding, but the callback is only
+// called with the arguments.
 func (p *Page) ExposeFunction(name string, callback goja.Callable) {
-	k6ext.Panic(p.ctx, "Page.exposeFunction(name, callback) has not been implemented yet")
+	p.logger.Debugf("Page:ExposeFunction", "sid:%v name:%s", p.sessionID(), name)
+
+	b := newBinding(name, functionBinding(p.vu.Runtime(), callback), false)
+	if err := p.exposeBinding(b); err != nil {
+		k6ext.Panic(p.ctx, "exposing function %q: %w", name, err)
+	}
+}
+
+func (p *Page) exposeBinding(b *binding) error {
+	if p.browserCtx.bindings.get(b.name) != nil {
+		return fmt.Errorf("function %q has been already registered in the browser context", b.name)
+	}
+	if err := p.bindings.add(b); err != nil {
+		return err
+	}
+	p.getTaskQueue()
+
+	return p.installBinding(b)
 }
 
 func (p *Page) Fill(selector string, value string, opts goja.Value) {
@@ -568,10 +969,25 @@ func (p *Page) Focus(selector string, opts goja.Value) {
 	p.MainFrame().Focus(selector, opts)
 }
 
-// Frame is not implemented.
-func (p *Page) Frame(frameSelector goja.Value) api.Frame {
-	k6ext.Panic(p.ctx, "Page.frame(frameSelector) has not been implemented yet")
-	return nil
+// Frame returns the frame of the page that matches the frame selector,
+// or nil if no frame matches. The frame selector is either the name of
+// the frame, or an object with the name or the URL of the frame.
+func (p *Page) Frame(frameSelector goja.Value) (api.Frame, error) {
+	p.logger.Debugf("Page:Frame", "sid:%v", p.sessionID())
+
+	opts := NewPageFrameOptions()
+	if err := opts.Parse(p.ctx, frameSelector); err != nil {
+		return nil, fmt.Errorf("parsing frame selector: %w", err)
+	}
+	f, err := p.frameManager.frame(opts)
+	if err != nil {
+		return nil, fmt.Errorf("finding frame: %w", err)
+	}
+	if f == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return f, nil
 }
 
 // Frames returns a list of frames on the page.
@@ -601,16 +1017,53 @@ func (p *Page) GetTouchscreen() api.Touchscreen {
 	return p.Touchscreen
 }
 
-// GoBack is not implemented.
+// GoBack navigates to the previous page in the history and returns the
+// response of the main resource. It returns nil if there is no previous
+// page, or if the navigation is within the same document.
 func (p *Page) GoBack(opts goja.Value) api.Response {
-	k6ext.Panic(p.ctx, "Page.goBack(opts) has not been implemented yet")
-	return nil
+	p.logger.Debugf("Page:GoBack", "sid:%v", p.sessionID())
+
+	return p.goHistory(-1, "going back", opts)
 }
 
-// GoForward is not implemented.
+// GoForward navigates to the next page in the history and returns the
+// response of the main resource. It returns nil if there is no next
+// page, or if the navigation is within the same document.
 func (p *Page) GoForward(opts goja.Value) api.Response {
-	k6ext.Panic(p.ctx, "Page.goForward(opts) has not been implemented yet")
-	return nil
+	p.logger.Debugf("Page:GoForward", "sid:%v", p.sessionID())
+
+	return p.goHistory(+1, "going forward", opts)
+}
+
+// goHistory navigates to the history entry at delta from the current one.
+// It takes the same options as Reload.
+func (p *Page) goHistory(delta int, what string, opts goja.Value) api.Response {
+	parsedOpts := NewPageReloadOptions(LifecycleEventLoad, p.defaultTimeout())
+	if err := parsedOpts.Parse(p.ctx, opts); err != nil {
+		k6ext.Panic(p.ctx, "parsing %s options: %w", what, err)
+	}
+
+	index, entries, err := cdppage.GetNavigationHistory().Do(cdp.WithExecutor(p.ctx, p.session))
+	if err != nil {
+		k6ext.Panic(p.ctx, "%s: getting navigation history: %w", what, err)
+	}
+	i := int(index) + delta
+	if i < 0 || i >= len(entries) {
+		return nil
+	}
+
+	resp, err := p.waitForNavigation(parsedOpts, func() error {
+		action := cdppage.NavigateToHistoryEntry(entries[i].ID)
+		return action.Do(cdp.WithExecutor(p.ctx, p.session)) //nolint:wrapcheck
+	})
+	if err != nil {
+		k6ext.Panic(p.ctx, "%s: %w", what, err)
+	}
+	if resp == nil {
+		return nil
+	}
+
+	return resp
 }
 
 // Goto will navigate the page to the specified URL and return a HTTP response object.
@@ -681,6 +1134,54 @@ func (p *Page) IsVisible(selector string, opts goja.Value) bool {
 	return p.MainFrame().IsVisible(selector, opts)
 }
 
+// GetByAltText creates and returns a new locator for the elements with
+// the given alt text in the main frame.
+func (p *Page) GetByAltText(text goja.Value, opts goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByAltText", "sid:%s text:%q opts:%+v", p.sessionID(), text, opts)
+
+	return p.MainFrame().GetByAltText(text, opts)
+}
+
+// GetByLabel creates and returns a new locator for the elements that
+// are labelled with the given text in the main frame.
+func (p *Page) GetByLabel(text goja.Value, opts goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByLabel", "sid:%s text:%q opts:%+v", p.sessionID(), text, opts)
+
+	return p.MainFrame().GetByLabel(text, opts)
+}
+
+// GetByPlaceholder creates and returns a new locator for the input
+// elements with the given placeholder text in the main frame.
+func (p *Page) GetByPlaceholder(text goja.Value, opts goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByPlaceholder", "sid:%s text:%q opts:%+v", p.sessionID(), text, opts)
+
+	return p.MainFrame().GetByPlaceholder(text, opts)
+}
+
+// GetByRole creates and returns a new locator for the elements with
+// the given ARIA role in the main frame.
+func (p *Page) GetByRole(role string, opts goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByRole", "sid:%s role:%q opts:%+v", p.sessionID(), role, opts)
+
+	return p.MainFrame().GetByRole(role, opts)
+}
+
+// GetByTestID creates and returns a new locator for the elements with
+// the given test ID in the main frame.
+func (p *Page) GetByTestID(testID goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByTestID", "sid:%s testID:%q", p.sessionID(), testID)
+
+	return p.MainFrame().GetByTestID(testID)
+}
+
+// GetByTitle creates and returns a new locator for the elements with
+// the given title in the main frame.
+func (p *Page) GetByTitle(text goja.Value, opts goja.Value) api.Locator {
+	p.logger.Debugf("Page:GetByTitle", "sid:%s text:%q opts:%+v", p.sessionID(), text, opts)
+
+	return p.MainFrame().GetByTitle(text, opts)
+}
+
 // Locator creates and returns a new locator for this page (main frame).
 func (p *Page) Locator(selector string, opts goja.Value) api.Locator {
 	p.logger.Debugf("Page:Locator", "sid:%s sel: %q opts:%+v", p.sessionID(), selector, opts)
@@ -703,6 +1204,66 @@ func (p *Page) MainFrame() api.Frame {
 	return mf
 }
 
+// Off removes the handler registered for the event with the key by On.
+func (p *Page) Off(event string, key goja.Value) {
+	p.logger.Debugf("Page:Off", "sid:%v event:%q", p.sessionID(), event)
+
+	p.eventHandlers.remove(event, key)
+
+	if event == EventPageFilechooser {
+		if err := p.updateFileChooserInterception(); err != nil {
+			k6ext.Panic(p.ctx, "removing file chooser interception: %w", err)
+		}
+	}
+}
+
+// On registers a handler that is called on the VU event loop each time
+// the page emits the event. Handlers are called in the order they're
+// registered. The key identifies the handler for Off, such as the JS
+// function that the handler calls.
+//
+// A dialog blocks the page until a dialog handler accepts or dismisses it.
+// As the handlers are called on the VU event loop, a synchronous call that
+// opens a dialog, such as Evaluate or Click, hangs. If a dialog handler
+// returns an error without handling the dialog, the dialog is handled as
+// it is without dialog handlers, so that the page isn't blocked.
+func (p *Page) On(event string, key goja.Value, handler func(any) error) error {
+	p.logger.Debugf("Page:On", "sid:%v event:%q", p.sessionID(), event)
+
+	if _, ok := pageEvents[event]; !ok {
+		return fmt.Errorf("unknown page event: %q", event)
+	}
+	p.getTaskQueue()
+
+	if event == EventPageDialog {
+		handler = p.handleDialogOnError(handler)
+	}
+
+	p.eventHandlers.add(event, key, handler)
+
+	if event == EventPageFilechooser {
+		if err := p.updateFileChooserInterception(); err != nil {
+			return fmt.Errorf("intercepting file chooser: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// handleDialogOnError wraps the dialog handler to handle the dialog by
+// default if the handler returns an error before handling it.
+func (p *Page) handleDialogOnError(handler func(any) error) func(any) error {
+	return func(data any) error {
+		err := handler(data)
+		if d, ok := data.(*Dialog); ok && err != nil {
+			if herr := d.handleByDefault(); herr != nil {
+				p.logger.Debugf("Page:handleDialogOnError", "sid:%v handling dialog: %v", p.sessionID(), herr)
+			}
+		}
+		return err
+	}
+}
+
 // Opener returns the opener of the target.
 func (p *Page) Opener() api.Page {
 	return p.opener
@@ -713,10 +1274,56 @@ func (p *Page) Pause() {
 	k6ext.Panic(p.ctx, "Page.pause() has not been implemented yet")
 }
 
-// Pdf is not implemented.
-func (p *Page) Pdf(opts goja.Value) []byte {
-	k6ext.Panic(p.ctx, "Page.pdf(opts) has not been implemented yet")
-	return nil
+// Pdf prints the page to PDF and returns the PDF data.
+// The PDF is also saved to the path option if it's given.
+func (p *Page) Pdf(opts goja.Value) goja.ArrayBuffer {
+	p.logger.Debugf("Page:Pdf", "sid:%v", p.sessionID())
+
+	parsedOpts := NewPagePdfOptions()
+	if err := parsedOpts.Parse(p.ctx, opts); err != nil {
+		k6ext.Panic(p.ctx, "parsing pdf options: %w", err)
+	}
+	buf, err := p.pdf(parsedOpts)
+	if err != nil {
+		k6ext.Panic(p.ctx, "printing page to pdf: %w", err)
+	}
+	rt := p.vu.Runtime()
+	return rt.NewArrayBuffer(buf)
+}
+
+func (p *Page) pdf(opts *PagePdfOptions) ([]byte, error) {
+	action := cdppage.PrintToPDF().
+		WithDisplayHeaderFooter(opts.DisplayHeaderFooter).
+		WithFooterTemplate(opts.FooterTemplate).
+		WithHeaderTemplate(opts.HeaderTemplate).
+		WithLandscape(opts.Landscape).
+		WithMarginBottom(opts.MarginBottom).
+		WithMarginLeft(opts.MarginLeft).
+		WithMarginRight(opts.MarginRight).
+		WithMarginTop(opts.MarginTop).
+		WithPageRanges(opts.PageRanges).
+		WithPaperHeight(opts.Height).
+		WithPaperWidth(opts.Width).
+		WithPreferCSSPageSize(opts.PreferCSSPageSize).
+		WithPrintBackground(opts.PrintBackground).
+		WithScale(opts.Scale)
+	buf, _, err := action.Do(cdp.WithExecutor(p.ctx, p.session))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	// TODO: we should not write to disk here but put it on some queue for async disk writes
+	if opts.Path != "" {
+		dir := filepath.Dir(opts.Path)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating pdf directory %q: %w", dir, err)
+		}
+		if err := os.WriteFile(opts.Path, buf, 0o644); err != nil { //nolint:gosec
+			return nil, fmt.Errorf("saving pdf to %q: %w", opts.Path, err)
+		}
+	}
+
+	return buf, nil
 }
 
 func (p *Page) Press(selector string, key string, opts goja.Value) {
@@ -748,7 +1355,25 @@ func (p *Page) Reload(opts goja.Value) api.Response {
 		k6ext.Panic(p.ctx, "parsing reload options: %w", err)
 	}
 
-	timeoutCtx, timeoutCancelFn := context.WithTimeout(p.ctx, parsedOpts.Timeout)
+	resp, err := p.waitForNavigation(parsedOpts, func() error {
+		return cdppage.Reload().Do(cdp.WithExecutor(p.ctx, p.session)) //nolint:wrapcheck
+	})
+	if err != nil {
+		k6ext.Panic(p.ctx, "reloading page: %w", err)
+	}
+	if resp == nil {
+		return nil
+	}
+
+	return resp
+}
+
+// waitForNavigation calls navigate and waits for the navigation of the main
+// frame that it starts, and then for the lifecycle event of the options.
+// It returns the response of the main resource of the new document, or nil
+// if the navigation is within the same document.
+func (p *Page) waitForNavigation(opts *PageReloadOptions, navigate func() error) (*Response, error) {
+	timeoutCtx, timeoutCancelFn := context.WithTimeout(p.ctx, opts.Timeout)
 	defer timeoutCancelFn()
 
 	ch, evCancelFn := createWaitForEventHandler(
@@ -763,26 +1388,24 @@ func (p *Page) Reload(opts goja.Value) api.Response {
 		timeoutCtx, p.frameManager.MainFrame(), []string{EventFrameAddLifecycle},
 		func(data any) bool {
 			if le, ok := data.(FrameLifecycleEvent); ok {
-				return le.Event == parsedOpts.WaitUntil
+				return le.Event == opts.WaitUntil
 			}
 			return false
 		})
 	defer lifecycleEvtCancel()
 
-	action := cdppage.Reload()
-	if err := action.Do(cdp.WithExecutor(p.ctx, p.session)); err != nil {
-		k6ext.Panic(p.ctx, "reloading page: %w", err)
+	if err := navigate(); err != nil {
+		return nil, err
 	}
 
 	wrapTimeoutError := func(err error) error {
 		if errors.Is(err, context.DeadlineExceeded) {
-			err = &k6ext.UserFriendlyError{
+			return &k6ext.UserFriendlyError{
 				Err:     err,
-				Timeout: parsedOpts.Timeout,
+				Timeout: opts.Timeout,
 			}
-			return fmt.Errorf("reloading page: %w", err)
 		}
-		p.logger.Debugf("Page:Reload", "timeoutCtx done: %v", err)
+		p.logger.Debugf("Page:waitForNavigation", "timeoutCtx done: %v", err)
 
 		return err // TODO maybe wrap this as well?
 	}
@@ -790,12 +1413,20 @@ func (p *Page) Reload(opts goja.Value) api.Response {
 	var event *NavigationEvent
 	select {
 	case <-p.ctx.Done():
+		return nil, p.ctx.Err() //nolint:wrapcheck
 	case <-timeoutCtx.Done():
-		k6ext.Panic(p.ctx, "%w", wrapTimeoutError(timeoutCtx.Err()))
+		return nil, wrapTimeoutError(timeoutCtx.Err())
 	case data := <-ch:
 		event = data.(*NavigationEvent)
 	}
 
+	// The lifecycle events don't fire again for a navigation
+	// within the same document.
+	if event.newDocument == nil {
+		applySlowMo(p.ctx)
+		return nil, nil
+	}
+
 	var resp *Response
 	req := event.newDocument.request
 	if req != nil {
@@ -807,17 +1438,33 @@ func (p *Page) Reload(opts goja.Value) api.Response {
 	select {
 	case <-lifecycleEvtCh:
 	case <-timeoutCtx.Done():
-		k6ext.Panic(p.ctx, "%w", wrapTimeoutError(timeoutCtx.Err()))
+		return nil, wrapTimeoutError(timeoutCtx.Err())
 	}
 
 	applySlowMo(p.ctx)
 
-	return resp
+	return resp, nil
 }
 
-// Route is not implemented.
-func (p *Page) Route(url goja.Value, handler goja.Callable) {
-	k6ext.Panic(p.ctx, "Page.route(url, handler) has not been implemented yet")
+// Route registers a handler for the requests matching the url.
+// The url can be a glob pattern, a RegExp or a predicate function that
+// receives the request URL.
+//
+// The handler is called on the VU event loop with the intercepted route
+// and must abort, continue or fulfill it. The key identifies the handler
+// for Unroute, such as the JS function that the handler calls.
+func (p *Page) Route(url, key goja.Value, handler func(api.Route) error) {
+	p.logger.Debugf("Page:Route", "sid:%v url:%v", p.sessionID(), url)
+
+	m, err := newURLMatcher(p.vu.Runtime(), url)
+	if err != nil {
+		k6ext.Panic(p.ctx, "adding route: %w", err)
+	}
+	p.getTaskQueue()
+	p.routes.add(m, key, handler)
+	if err := p.updateRequestInterception(); err != nil {
+		k6ext.Panic(p.ctx, "adding route: %w", err)
+	}
 }
 
 // Screenshot will instruct Chrome to save a screenshot of the current page and save it to specified file.
@@ -869,10 +1516,12 @@ func (p *Page) SetExtraHTTPHeaders(headers map[string]string) {
 	p.updateExtraHTTPHeaders()
 }
 
-// SetInputFiles is not implemented.
+// SetInputFiles sets the files of the first input[type=file] element
+// found that matches the selector.
 func (p *Page) SetInputFiles(selector string, files goja.Value, opts goja.Value) {
-	k6ext.Panic(p.ctx, "Page.textContent(selector, opts) has not been implemented yet")
-	// TODO: needs slowMo
+	p.logger.Debugf("Page:SetInputFiles", "sid:%v selector:%s", p.sessionID(), selector)
+
+	p.MainFrame().SetInputFiles(selector, files, opts)
 }
 
 // SetViewportSize will update the viewport width and height.
@@ -914,9 +1563,16 @@ func (p *Page) Type(selector string, text string, opts goja.Value) {
 	p.MainFrame().Type(selector, text, opts)
 }
 
-// Unroute is not implemented.
-func (p *Page) Unroute(url goja.Value, handler goja.Callable) {
-	k6ext.Panic(p.ctx, "Page.unroute(url, handler) has not been implemented yet")
+// Unroute removes the route handlers registered for the url.
+// The url must be the same value that is passed to Route. If the key
+// is given, only the handler registered with the same key is removed.
+func (p *Page) Unroute(url, key goja.Value) {
+	p.logger.Debugf("Page:Unroute", "sid:%v url:%v", p.sessionID(), url)
+
+	p.routes.remove(url, key)
+	if err := p.updateRequestInterception(); err != nil {
+		k6ext.Panic(p.ctx, "removing route: %w", err)
+	}
 }
 
 // URL returns the location of the page.
@@ -927,10 +1583,15 @@ func (p *Page) URL() string {
 	return gojaValueToString(p.ctx, p.Evaluate(v))
 }
 
-// Video returns information of recorded video.
+// Video returns the video recording of the page, or nil if the
+// videosPath option of the browser context isn't set.
 func (p *Page) Video() api.Video {
-	k6ext.Panic(p.ctx, "Page.video() has not been implemented yet")
-	return nil
+	v := p.getVideo()
+	if v == nil {
+		return nil
+	}
+
+	return v
 }
 
 // ViewportSize will return information on the viewport width and height.
@@ -944,10 +1605,129 @@ func (p *Page) ViewportSize() map[string]float64 {
 	}
 }
 
-// WaitForEvent waits for the specified event to trigger.
-func (p *Page) WaitForEvent(event string, optsOrPredicate goja.Value) any {
-	k6ext.Panic(p.ctx, "Page.waitForEvent(event, optsOrPredicate) has not been implemented yet")
-	return nil
+// WaitForEvent returns a function that waits for the page to emit the
+// event, and returns the event data. If a predicate is given, it waits for
+// the event data that the predicate returns true for.
+//
+// It must be called on the VU event loop, as it reads the options and
+// registers a callback for the predicate, if any. The returned function
+// blocks until the event is received or the timeout expires, so it must be
+// called from a promise, and it must be called for the callback to be released.
+func (p *Page) WaitForEvent(event string, optsOrPredicate goja.Value) (func() (any, error), error) {
+	p.logger.Debugf("Page:WaitForEvent", "sid:%v event:%q", p.sessionID(), event)
+
+	if _, ok := pageEvents[event]; !ok {
+		return nil, fmt.Errorf("unknown page event: %q", event)
+	}
+	parsedOpts := NewPageWaitForEventOptions(p.defaultTimeout())
+	if err := parsedOpts.Parse(p.ctx, optsOrPredicate); err != nil {
+		return nil, fmt.Errorf("parsing waitForEvent options: %w", err)
+	}
+
+	// The file choosers are only emitted while they are intercepted.
+	fileChooser := event == EventPageFilechooser
+	if fileChooser {
+		if err := p.updateFileChooserWaiters(1); err != nil {
+			return nil, fmt.Errorf("intercepting file chooser: %w", err)
+		}
+	}
+	wait := p.waitForEvent(event, parsedOpts)
+
+	return func() (any, error) {
+		if fileChooser {
+			defer func() {
+				if err := p.updateFileChooserWaiters(-1); err != nil {
+					p.logger.Debugf("Page:WaitForEvent", "sid:%v removing file chooser interception: %v",
+						p.sessionID(), err)
+				}
+			}()
+		}
+		data, err := wait()
+		if err != nil {
+			return nil, fmt.Errorf("waiting for %q event: %w", event, err)
+		}
+		return data, nil
+	}, nil
+}
+
+// waitForEvent starts waiting for the event whose data the predicate of
+// the options, if any, returns true for, and returns a function that
+// returns the event data once it's received. The function returns an
+// error if the page is closed before.
+//
+// It must be called on the VU event loop, as it registers a callback for
+// the predicate, if any. The returned function blocks until the event is
+// received or the timeout expires, so it must be called from a promise,
+// and it must be called for the callback to be released.
+func (p *Page) waitForEvent(event string, opts *PageWaitForEventOptions) func() (any, error) {
+	// Predicates are JS functions, so they are called on the VU event loop.
+	var tq *k6ext.TaskQueue
+	if opts.Predicate != nil {
+		tq = k6ext.NewTaskQueue(p.vu.RegisterCallback)
+	}
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(p.ctx, opts.Timeout)
+	var (
+		evCh    = make(chan Event)
+		matchCh = make(chan any, 1)
+		errCh   = make(chan error, 1)
+		events  = []string{event}
+	)
+	if event != EventPageClose {
+		events = append(events, EventPageClose)
+	}
+	p.on(timeoutCtx, events, evCh)
+
+	match := func(data any) {
+		matched, err := opts.Predicate(goja.Undefined(), p.vu.Runtime().ToValue(data))
+		switch {
+		case err != nil:
+			select {
+			case errCh <- fmt.Errorf("calling predicate: %w", err):
+			default:
+			}
+		case matched.ToBoolean():
+			select {
+			case matchCh <- data:
+			default:
+			}
+		}
+	}
+
+	return func() (any, error) {
+		defer timeoutCancel()
+		if tq != nil {
+			defer tq.Close()
+		}
+		for {
+			select {
+			case ev := <-evCh:
+				if ev.typ != event {
+					return nil, errors.New("page is closed")
+				}
+				if tq == nil {
+					return ev.data, nil
+				}
+				tq.Queue(func() error {
+					match(ev.data)
+					return nil
+				})
+			case data := <-matchCh:
+				return data, nil
+			case err := <-errCh:
+				return nil, err
+			case <-timeoutCtx.Done():
+				err := timeoutCtx.Err()
+				if errors.Is(err, context.DeadlineExceeded) {
+					err = &k6ext.UserFriendlyError{
+						Err:     err,
+						Timeout: opts.Timeout,
+					}
+				}
+				return nil, err
+			}
+		}
+	}
 }
 
 // WaitForFunction waits for the given predicate to return a truthy value.
@@ -971,16 +1751,148 @@ func (p *Page) WaitForNavigation(opts goja.Value) (api.Response, error) {
 	return p.frameManager.MainFrame().WaitForNavigation(opts)
 }
 
-// WaitForRequest is not implemented.
-func (p *Page) WaitForRequest(urlOrPredicate, opts goja.Value) api.Request {
-	k6ext.Panic(p.ctx, "Page.waitForRequest(urlOrPredicate, opts) has not been implemented yet")
-	return nil
+// WaitForRequest returns a function that waits for a request whose URL
+// matches the glob pattern, RegExp or predicate, and returns the request.
+//
+// It must be called on the VU event loop, as it reads the URL matcher and
+// the options. The returned function blocks until the request is received
+// or the timeout expires, so it must be called from a promise.
+func (p *Page) WaitForRequest(urlOrPredicate, opts goja.Value) (func() (api.Request, error), error) {
+	p.logger.Debugf("Page:WaitForRequest", "sid:%v", p.sessionID())
+
+	wait, err := p.waitForNetworkEvent(EventPageRequest, urlOrPredicate, opts)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for request: %w", err)
+	}
+
+	return func() (api.Request, error) {
+		data, err := wait()
+		if err != nil {
+			return nil, fmt.Errorf("waiting for request: %w", err)
+		}
+		req, ok := data.(*Request)
+		if !ok {
+			return nil, fmt.Errorf("waiting for request: unexpected event data %T", data)
+		}
+		return req, nil
+	}, nil
 }
 
-// WaitForResponse is not implemented.
-func (p *Page) WaitForResponse(urlOrPredicate, opts goja.Value) api.Response {
-	k6ext.Panic(p.ctx, "Page.waitForResponse(urlOrPredicate, opts) has not been implemented yet")
-	return nil
+// WaitForResponse returns a function that waits for a response whose URL
+// matches the glob pattern, RegExp or predicate, and returns the response.
+//
+// It must be called on the VU event loop, as it reads the URL matcher and
+// the options. The returned function blocks until the response is received
+// or the timeout expires, so it must be called from a promise.
+func (p *Page) WaitForResponse(urlOrPredicate, opts goja.Value) (func() (api.Response, error), error) {
+	p.logger.Debugf("Page:WaitForResponse", "sid:%v", p.sessionID())
+
+	wait, err := p.waitForNetworkEvent(EventPageResponse, urlOrPredicate, opts)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for response: %w", err)
+	}
+
+	return func() (api.Response, error) {
+		data, err := wait()
+		if err != nil {
+			return nil, fmt.Errorf("waiting for response: %w", err)
+		}
+		resp, ok := data.(*Response)
+		if !ok {
+			return nil, fmt.Errorf("waiting for response: unexpected event data %T", data)
+		}
+		return resp, nil
+	}, nil
+}
+
+// waitForNetworkEvent starts waiting for a request or response event whose
+// URL matches urlOrPredicate, and returns a function that returns the event
+// data once it's received.
+//
+// It must be called on the VU event loop, as it reads the JS values and
+// registers a callback for the predicate, if any. The returned function
+// blocks until the event is received or the timeout expires, so it must be
+// called from a promise, and it must be called for the callback to be released.
+func (p *Page) waitForNetworkEvent(event string, urlOrPredicate, opts goja.Value) (func() (any, error), error) {
+	parsedOpts := NewPageWaitForNetworkEventOptions(p.defaultTimeout())
+	if err := parsedOpts.Parse(p.ctx, opts); err != nil {
+		return nil, fmt.Errorf("parsing options: %w", err)
+	}
+	m, err := newURLMatcher(p.vu.Runtime(), urlOrPredicate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Predicates are JS functions, so they are called on the VU event loop.
+	var tq *k6ext.TaskQueue
+	if m.isPredicate() {
+		tq = k6ext.NewTaskQueue(p.vu.RegisterCallback)
+	}
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(p.ctx, parsedOpts.Timeout)
+	var (
+		evCh    = make(chan Event)
+		matchCh = make(chan any, 1)
+		errCh   = make(chan error, 1)
+	)
+	p.on(timeoutCtx, []string{event}, evCh)
+
+	match := func(data any) {
+		var url string
+		switch d := data.(type) {
+		case *Request:
+			url = d.URL()
+		case *Response:
+			url = d.URL()
+		}
+		matched, err := m.matches(url)
+		switch {
+		case err != nil:
+			select {
+			case errCh <- err:
+			default:
+			}
+		case matched:
+			select {
+			case matchCh <- data:
+			default:
+			}
+		}
+	}
+	wait := func() (any, error) {
+		defer timeoutCancel()
+		if tq != nil {
+			defer tq.Close()
+		}
+		for {
+			select {
+			case ev := <-evCh:
+				if tq == nil {
+					match(ev.data)
+					continue
+				}
+				tq.Queue(func() error {
+					match(ev.data)
+					return nil
+				})
+			case data := <-matchCh:
+				return data, nil
+			case err := <-errCh:
+				return nil, err
+			case <-timeoutCtx.Done():
+				err := timeoutCtx.Err()
+				if errors.Is(err, context.DeadlineExceeded) {
+					err = &k6ext.UserFriendlyError{
+						Err:     err,
+						Timeout: parsedOpts.Timeout,
+					}
+				}
+				return nil, err
+			}
+		}
+	}
+
+	return wait, nil
 }
 
 // WaitForSelector waits for the given selector to match the waiting criteria.
@@ -1001,6 +1913,9 @@ func (p *Page) WaitForTimeout(timeout int64) {
 
 // Workers returns all WebWorkers of page.
 func (p *Page) Workers() []api.Worker {
+	p.workersMu.RLock()
+	defer p.workersMu.RUnlock()
+
 	workers := make([]api.Worker, 0, len(p.workers))
 	for _, w := range p.workers {
 		workers = append(workers, w)
@@ -2,7 +2,9 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,6 +25,40 @@ type PageReloadOptions struct {
 	Timeout   time.Duration  `json:"timeout"`
 }
 
+// PageWaitForNetworkEventOptions are the options for waiting for
+// a request or a response.
+type PageWaitForNetworkEventOptions struct {
+	Timeout time.Duration `json:"timeout"`
+}
+
+// PageWaitForEventOptions are the options for waiting for a page event.
+type PageWaitForEventOptions struct {
+	// Predicate is called with the event data, and the event is waited
+	// for until the predicate returns true.
+	Predicate goja.Callable `json:"-"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
+// PagePdfOptions are the options for printing a page to PDF.
+// The paper sizes and margins are in inches.
+type PagePdfOptions struct {
+	DisplayHeaderFooter bool    `json:"displayHeaderFooter"`
+	FooterTemplate      string  `json:"footerTemplate"`
+	HeaderTemplate      string  `json:"headerTemplate"`
+	Height              float64 `json:"height"`
+	Landscape           bool    `json:"landscape"`
+	MarginBottom        float64 `json:"marginBottom"`
+	MarginLeft          float64 `json:"marginLeft"`
+	MarginRight         float64 `json:"marginRight"`
+	MarginTop           float64 `json:"marginTop"`
+	PageRanges          string  `json:"pageRanges"`
+	Path                string  `json:"path"`
+	PreferCSSPageSize   bool    `json:"preferCSSPageSize"`
+	PrintBackground     bool    `json:"printBackground"`
+	Scale               float64 `json:"scale"`
+	Width               float64 `json:"width"`
+}
+
 type PageScreenshotOptions struct {
 	Clip           *page.Viewport `json:"clip"`
 	Path           string         `json:"path"`
@@ -86,6 +122,165 @@ func (o *PageReloadOptions) Parse(ctx context.Context, opts goja.Value) error {
 	return nil
 }
 
+// pdfPaperFormats are the supported paper formats and their width and
+// height in inches.
+var pdfPaperFormats = map[string][2]float64{ //nolint:gochecknoglobals
+	"letter":  {8.5, 11},
+	"legal":   {8.5, 14},
+	"tabloid": {11, 17},
+	"ledger":  {17, 11},
+	"a0":      {33.1, 46.8},
+	"a1":      {23.4, 33.1},
+	"a2":      {16.54, 23.4},
+	"a3":      {11.7, 16.54},
+	"a4":      {8.27, 11.7},
+	"a5":      {5.83, 8.27},
+	"a6":      {4.13, 5.83},
+}
+
+// pdfUnitToPixels are the supported units of the PDF sizes and margins,
+// and the number of CSS pixels per unit.
+var pdfUnitToPixels = map[string]float64{ //nolint:gochecknoglobals
+	"px": 1,
+	"in": 96,
+	"cm": 37.8,
+	"mm": 3.78,
+}
+
+// NewPagePdfOptions returns a new PagePdfOptions with the defaults
+// of the letter paper format and no margins.
+func NewPagePdfOptions() *PagePdfOptions {
+	return &PagePdfOptions{
+		Width:  pdfPaperFormats["letter"][0],
+		Height: pdfPaperFormats["letter"][1],
+		Scale:  1,
+	}
+}
+
+// Parse parses the PDF options.
+func (o *PagePdfOptions) Parse(ctx context.Context, opts goja.Value) error { //nolint:cyclop
+	rt := k6ext.Runtime(ctx)
+	if opts == nil || goja.IsUndefined(opts) || goja.IsNull(opts) {
+		return nil
+	}
+	var (
+		obj           = opts.ToObject(rt)
+		width, height goja.Value
+		err           error
+	)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "displayHeaderFooter":
+			o.DisplayHeaderFooter = obj.Get(k).ToBoolean()
+		case "footerTemplate":
+			o.FooterTemplate = obj.Get(k).String()
+		case "format":
+			format := obj.Get(k).String()
+			size, ok := pdfPaperFormats[strings.ToLower(format)]
+			if !ok {
+				return fmt.Errorf("unknown paper format: %q", format)
+			}
+			o.Width, o.Height = size[0], size[1]
+		case "headerTemplate":
+			o.HeaderTemplate = obj.Get(k).String()
+		case "height":
+			height = obj.Get(k)
+		case "landscape":
+			o.Landscape = obj.Get(k).ToBoolean()
+		case "margin":
+			if err := o.parseMargin(rt, obj.Get(k)); err != nil {
+				return err
+			}
+		case "pageRanges":
+			o.PageRanges = obj.Get(k).String()
+		case "path":
+			o.Path = obj.Get(k).String()
+		case "preferCSSPageSize":
+			o.PreferCSSPageSize = obj.Get(k).ToBoolean()
+		case "printBackground":
+			o.PrintBackground = obj.Get(k).ToBoolean()
+		case "scale":
+			o.Scale = obj.Get(k).ToFloat()
+		case "width":
+			width = obj.Get(k)
+		}
+	}
+	// width and height override the paper format.
+	if gojaValueExists(width) {
+		if o.Width, err = pdfSizeToInches(width); err != nil {
+			return fmt.Errorf("parsing width: %w", err)
+		}
+	}
+	if gojaValueExists(height) {
+		if o.Height, err = pdfSizeToInches(height); err != nil {
+			return fmt.Errorf("parsing height: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (o *PagePdfOptions) parseMargin(rt *goja.Runtime, margin goja.Value) error {
+	if !gojaValueExists(margin) {
+		return nil
+	}
+	obj := margin.ToObject(rt)
+	for _, k := range obj.Keys() {
+		var m *float64
+		switch k {
+		case "bottom":
+			m = &o.MarginBottom
+		case "left":
+			m = &o.MarginLeft
+		case "right":
+			m = &o.MarginRight
+		case "top":
+			m = &o.MarginTop
+		default:
+			continue
+		}
+		v, err := pdfSizeToInches(obj.Get(k))
+		if err != nil {
+			return fmt.Errorf("parsing %s margin: %w", k, err)
+		}
+		*m = v
+	}
+
+	return nil
+}
+
+// pdfSizeToInches converts a size to inches. The size can be a number
+// of pixels, or a string with one of the px, in, cm or mm units.
+func pdfSizeToInches(size goja.Value) (float64, error) {
+	var pixels float64
+	switch v := size.Export().(type) {
+	case int64:
+		pixels = float64(v)
+	case float64:
+		pixels = v
+	case string:
+		var (
+			text = strings.TrimSpace(v)
+			unit = "px"
+		)
+		if len(text) > 2 {
+			if _, ok := pdfUnitToPixels[strings.ToLower(text[len(text)-2:])]; ok {
+				unit = strings.ToLower(text[len(text)-2:])
+				text = text[:len(text)-2]
+			}
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q", v)
+		}
+		pixels = value * pdfUnitToPixels[unit]
+	default:
+		return 0, fmt.Errorf("invalid size type %T", v)
+	}
+
+	return pixels / pdfUnitToPixels["in"], nil
+}
+
 func NewPageScreenshotOptions() *PageScreenshotOptions {
 	return &PageScreenshotOptions{
 		Clip:           nil,
@@ -141,3 +336,152 @@ func (o *PageScreenshotOptions) Parse(ctx context.Context, opts goja.Value) erro
 
 	return nil
 }
+
+// NewPageWaitForNetworkEventOptions returns a new PageWaitForNetworkEventOptions.
+func NewPageWaitForNetworkEventOptions(defaultTimeout time.Duration) *PageWaitForNetworkEventOptions {
+	return &PageWaitForNetworkEventOptions{
+		Timeout: defaultTimeout,
+	}
+}
+
+// Parse parses the wait for request or response options.
+func (o *PageWaitForNetworkEventOptions) Parse(ctx context.Context, opts goja.Value) error {
+	rt := k6ext.Runtime(ctx)
+	if opts != nil && !goja.IsUndefined(opts) && !goja.IsNull(opts) {
+		opts := opts.ToObject(rt)
+		for _, k := range opts.Keys() {
+			switch k {
+			case "timeout":
+				o.Timeout = time.Duration(opts.Get(k).ToInteger()) * time.Millisecond
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewPageWaitForEventOptions returns a new PageWaitForEventOptions.
+func NewPageWaitForEventOptions(defaultTimeout time.Duration) *PageWaitForEventOptions {
+	return &PageWaitForEventOptions{
+		Timeout: defaultTimeout,
+	}
+}
+
+// Parse parses the wait for event options, or the predicate function
+// that is given instead of them.
+func (o *PageWaitForEventOptions) Parse(ctx context.Context, optsOrPredicate goja.Value) error {
+	if !gojaValueExists(optsOrPredicate) {
+		return nil
+	}
+	if fn, ok := goja.AssertFunction(optsOrPredicate); ok {
+		o.Predicate = fn
+		return nil
+	}
+
+	rt := k6ext.Runtime(ctx)
+	opts := optsOrPredicate.ToObject(rt)
+	for _, k := range opts.Keys() {
+		switch k {
+		case "predicate":
+			fn, ok := goja.AssertFunction(opts.Get(k))
+			if !ok {
+				return errors.New("predicate must be a function")
+			}
+			o.Predicate = fn
+		case "timeout":
+			o.Timeout = time.Duration(opts.Get(k).ToInteger()) * time.Millisecond
+		}
+	}
+
+	return nil
+}
+
+// ExposeBindingOptions are the options for exposing a binding
+// to the pages.
+type ExposeBindingOptions struct {
+	// Handle passes the single argument of the binding to the
+	// callback as a JS handle instead of by value.
+	Handle bool `json:"handle"`
+}
+
+// NewExposeBindingOptions returns a new ExposeBindingOptions.
+func NewExposeBindingOptions() *ExposeBindingOptions {
+	return &ExposeBindingOptions{}
+}
+
+// Parse parses the expose binding options.
+func (o *ExposeBindingOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(k6ext.Runtime(ctx))
+	for _, k := range obj.Keys() {
+		if k == "handle" {
+			o.Handle = obj.Get(k).ToBoolean()
+		}
+	}
+
+	return nil
+}
+
+// PageFrameOptions are the options for finding a frame of a page.
+// A frame matches if it matches all the given options.
+type PageFrameOptions struct {
+	// Name is the name attribute of the frame.
+	Name string
+	// URL matches the URL of the frame.
+	URL *urlMatcher
+}
+
+// NewPageFrameOptions returns a new PageFrameOptions.
+func NewPageFrameOptions() *PageFrameOptions {
+	return &PageFrameOptions{}
+}
+
+// Parse parses the frame options. The options are either the name
+// of the frame, or an object with the name or the URL of the frame.
+// The URL can be a glob pattern, a RegExp or a predicate function.
+func (o *PageFrameOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if !gojaValueExists(opts) {
+		return errors.New("provide a frame name or an object with a name or url property")
+	}
+	rt := k6ext.Runtime(ctx)
+	obj, ok := opts.(*goja.Object)
+	if !ok {
+		o.Name = opts.String()
+		return nil
+	}
+	for _, k := range obj.Keys() {
+		v := obj.Get(k)
+		if !gojaValueExists(v) {
+			continue
+		}
+		switch k {
+		case "name":
+			o.Name = v.String()
+		case "url":
+			m, err := newURLMatcher(rt, v)
+			if err != nil {
+				return err
+			}
+			o.URL = m
+		}
+	}
+	if o.Name == "" && o.URL == nil {
+		return errors.New("provide a frame name or an object with a name or url property")
+	}
+
+	return nil
+}
+
+// matches returns true if the frame matches the options.
+func (o *PageFrameOptions) matches(f *Frame) (bool, error) {
+	if o.Name != "" && f.Name() != o.Name {
+		return false, nil
+	}
+	if o.URL == nil {
+		return true, nil
+	}
+
+	return o.URL.matches(f.URL())
+}
@@ -0,0 +1,156 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPagePdfOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePdfOptions()
+		require.NoError(t, opts.Parse(vu.Context(), nil))
+
+		assert.Equal(t, &PagePdfOptions{Width: 8.5, Height: 11, Scale: 1}, opts)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePdfOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"format":    "A4",
+			"landscape": true,
+			"margin": map[string]any{
+				"top":    "1in",
+				"bottom": "2.54cm",
+				"left":   "96px",
+				"right":  48,
+			},
+			"pageRanges":      "1-2",
+			"path":            "invoice.pdf",
+			"printBackground": true,
+			"scale":           0.5,
+		}))
+		require.NoError(t, err)
+
+		assert.Equal(t, 8.27, opts.Width)
+		assert.Equal(t, 11.7, opts.Height)
+		assert.True(t, opts.Landscape)
+		assert.InDelta(t, 1, opts.MarginTop, 0.001)
+		assert.InDelta(t, 1, opts.MarginBottom, 0.001)
+		assert.InDelta(t, 1, opts.MarginLeft, 0.001)
+		assert.InDelta(t, 0.5, opts.MarginRight, 0.001)
+		assert.Equal(t, "1-2", opts.PageRanges)
+		assert.Equal(t, "invoice.pdf", opts.Path)
+		assert.True(t, opts.PrintBackground)
+		assert.Equal(t, 0.5, opts.Scale)
+	})
+
+	t.Run("width_height", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePdfOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"format": "A4",
+			"width":  "100mm",
+			"height": 480,
+		}))
+		require.NoError(t, err)
+
+		assert.InDelta(t, 3.9375, opts.Width, 0.001)
+		assert.InDelta(t, 5, opts.Height, 0.001)
+	})
+
+	t.Run("err/format", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePdfOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"format": "B5",
+		}))
+		assert.EqualError(t, err, `unknown paper format: "B5"`)
+	})
+
+	t.Run("err/size", func(t *testing.T) {
+		t.Parallel()
+
+		vu := k6test.NewVU(t)
+		opts := NewPagePdfOptions()
+		err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+			"margin": map[string]any{"top": "1ft"},
+		}))
+		assert.EqualError(t, err, `parsing top margin: invalid size "1ft"`)
+	})
+}
+
+func TestPageFrameOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+
+	opts := NewPageFrameOptions()
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue("checkout")))
+	assert.Equal(t, "checkout", opts.Name)
+	assert.Nil(t, opts.URL)
+
+	opts = NewPageFrameOptions()
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+		"name": "checkout",
+		"url":  "**/pay?id=*",
+	})))
+	assert.Equal(t, "checkout", opts.Name)
+	require.NotNil(t, opts.URL)
+	ok, err := opts.URL.matches("https://example.com/pay?id=1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	for _, v := range []any{nil, map[string]any{}, map[string]any{"name": ""}} {
+		err := NewPageFrameOptions().Parse(vu.Context(), vu.ToGojaValue(v))
+		assert.EqualError(t, err, "provide a frame name or an object with a name or url property")
+	}
+}
+
+func TestPageWaitForEventOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	rt := vu.Runtime()
+
+	opts := NewPageWaitForEventOptions(time.Second)
+	require.NoError(t, opts.Parse(vu.Context(), nil))
+	assert.Nil(t, opts.Predicate)
+	assert.Equal(t, time.Second, opts.Timeout)
+
+	predicate, err := rt.RunString(`fc => fc.multiple`)
+	require.NoError(t, err)
+	opts = NewPageWaitForEventOptions(time.Second)
+	require.NoError(t, opts.Parse(vu.Context(), predicate))
+	assert.NotNil(t, opts.Predicate)
+	assert.Equal(t, time.Second, opts.Timeout)
+
+	opts = NewPageWaitForEventOptions(time.Second)
+	require.NoError(t, opts.Parse(vu.Context(), rt.ToValue(map[string]any{
+		"predicate": predicate,
+		"timeout":   500,
+	})))
+	assert.NotNil(t, opts.Predicate)
+	assert.Equal(t, 500*time.Millisecond, opts.Timeout)
+
+	err = NewPageWaitForEventOptions(time.Second).Parse(vu.Context(), rt.ToValue(map[string]any{
+		"predicate": "multiple",
+	}))
+	assert.EqualError(t, err, "predicate must be a function")
+}
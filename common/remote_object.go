@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext"
 
 	cdpruntime "github.com/chromedp/cdproto/runtime"
@@ -162,6 +163,34 @@ func parseExceptionDetails(exc *cdpruntime.ExceptionDetails) string {
 	return errMsg
 }
 
+// newPageError returns the page error of an uncaught exception. The name
+// and the message of an Error object are parsed from the first line of its
+// description, which is its stack, e.g. "TypeError: x is not a function".
+func newPageError(exc *cdpruntime.ExceptionDetails) *api.PageError {
+	if exc.Exception == nil || exc.Exception.Subtype != cdpruntime.SubtypeError {
+		msg := parseExceptionDetails(exc)
+		if msg == "" {
+			msg = exc.Text
+		}
+		return &api.PageError{Message: msg}
+	}
+
+	stack := exc.Exception.Description
+	name := exc.Exception.ClassName
+	message, _, _ := strings.Cut(stack, "\n")
+	if n, m, ok := strings.Cut(message, ": "); ok {
+		name, message = n, m
+	} else if message == name {
+		message = ""
+	}
+
+	return &api.PageError{
+		Name:    name,
+		Message: message,
+		Stack:   stack,
+	}
+}
+
 func parseRemoteObject(obj *cdpruntime.RemoteObject) (any, error) {
 	if obj.UnserializableValue == "" {
 		return parseRemoteObjectValue(obj.Type, obj.Subtype, string(obj.Value), obj.Preview)
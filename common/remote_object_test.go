@@ -7,6 +7,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext/k6test"
 
 	"github.com/chromedp/cdproto/runtime"
@@ -245,6 +246,74 @@ func TestParseRemoteObject(t *testing.T) {
 	}
 }
 
+func TestNewPageError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		exc  *runtime.ExceptionDetails
+		want *api.PageError
+	}{
+		{
+			name: "error",
+			exc: &runtime.ExceptionDetails{
+				Text: "Uncaught",
+				Exception: &runtime.RemoteObject{
+					Type:        runtime.TypeObject,
+					Subtype:     runtime.SubtypeError,
+					ClassName:   "TypeError",
+					Description: "TypeError: x.y is not a function: oops\n    at <anonymous>:1:3",
+				},
+			},
+			want: &api.PageError{
+				Name:    "TypeError",
+				Message: "x.y is not a function: oops",
+				Stack:   "TypeError: x.y is not a function: oops\n    at <anonymous>:1:3",
+			},
+		},
+		{
+			name: "error_without_message",
+			exc: &runtime.ExceptionDetails{
+				Text: "Uncaught",
+				Exception: &runtime.RemoteObject{
+					Type:        runtime.TypeObject,
+					Subtype:     runtime.SubtypeError,
+					ClassName:   "Error",
+					Description: "Error\n    at <anonymous>:1:7",
+				},
+			},
+			want: &api.PageError{
+				Name:  "Error",
+				Stack: "Error\n    at <anonymous>:1:7",
+			},
+		},
+		{
+			name: "thrown_value",
+			exc: &runtime.ExceptionDetails{
+				Text: "Uncaught",
+				Exception: &runtime.RemoteObject{
+					Type:  runtime.TypeString,
+					Value: easyjson.RawMessage(`"boom"`),
+				},
+			},
+			want: &api.PageError{Message: "boom"},
+		},
+		{
+			name: "no_exception",
+			exc:  &runtime.ExceptionDetails{Text: "Uncaught SyntaxError: Unexpected token"},
+			want: &api.PageError{Message: "Uncaught SyntaxError: Unexpected token"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, newPageError(tt.exc))
+		})
+	}
+}
+
 func TestMultierror(t *testing.T) {
 	t.Parallel()
 
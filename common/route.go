@@ -0,0 +1,269 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/log"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/dop251/goja"
+)
+
+// Ensure Route implements the api.Route interface.
+var _ api.Route = &Route{}
+
+// errRouteHandled is returned when a route is handled more than once.
+var errRouteHandled = errors.New("route is already handled")
+
+// routeErrorReasons maps the error codes of Route.Abort to the network error reasons.
+var routeErrorReasons = map[string]network.ErrorReason{ //nolint:gochecknoglobals
+	"aborted":              network.ErrorReasonAborted,
+	"accessdenied":         network.ErrorReasonAccessDenied,
+	"addressunreachable":   network.ErrorReasonAddressUnreachable,
+	"blockedbyclient":      network.ErrorReasonBlockedByClient,
+	"blockedbyresponse":    network.ErrorReasonBlockedByResponse,
+	"connectionaborted":    network.ErrorReasonConnectionAborted,
+	"connectionclosed":     network.ErrorReasonConnectionClosed,
+	"connectionfailed":     network.ErrorReasonConnectionFailed,
+	"connectionrefused":    network.ErrorReasonConnectionRefused,
+	"connectionreset":      network.ErrorReasonConnectionReset,
+	"internetdisconnected": network.ErrorReasonInternetDisconnected,
+	"namenotresolved":      network.ErrorReasonNameNotResolved,
+	"timedout":             network.ErrorReasonTimedOut,
+	"failed":               network.ErrorReasonFailed,
+}
+
+// Route represents a request intercepted by a route handler.
+// The request is paused until it is either aborted, continued or fulfilled.
+type Route struct {
+	ctx            context.Context
+	session        session
+	request        *Request
+	interceptionID fetch.RequestID
+	logger         *log.Logger
+
+	handledMu sync.Mutex
+	handled   bool
+}
+
+// NewRoute creates a new route for the request paused with the given interception ID.
+func NewRoute(ctx context.Context, s session, req *Request, id fetch.RequestID, l *log.Logger) *Route {
+	return &Route{
+		ctx:            ctx,
+		session:        s,
+		request:        req,
+		interceptionID: id,
+		logger:         l,
+	}
+}
+
+// Abort aborts the request with the given error code.
+// The default error code is "failed".
+func (r *Route) Abort(errorCode string) {
+	r.logger.Debugf("Route:Abort", "iid:%s url:%q code:%q", r.interceptionID, r.request.URL(), errorCode)
+
+	if err := r.abort(errorCode); err != nil {
+		k6ext.Panic(r.ctx, "aborting route: %w", err)
+	}
+}
+
+func (r *Route) abort(errorCode string) error {
+	if errorCode == "" {
+		errorCode = "failed"
+	}
+	reason, ok := routeErrorReasons[strings.ToLower(errorCode)]
+	if !ok {
+		return fmt.Errorf("unknown error code %q", errorCode)
+	}
+	if err := r.startHandling(); err != nil {
+		return err
+	}
+	action := fetch.FailRequest(r.interceptionID, reason)
+	if err := action.Do(cdp.WithExecutor(r.ctx, r.session)); err != nil {
+		return fmt.Errorf("failing request: %w", err)
+	}
+
+	return nil
+}
+
+// Continue continues the request, optionally overriding its URL, method,
+// headers or post data.
+func (r *Route) Continue(opts goja.Value) {
+	r.logger.Debugf("Route:Continue", "iid:%s url:%q", r.interceptionID, r.request.URL())
+
+	copts := NewRouteContinueOptions()
+	if err := copts.Parse(r.ctx, opts); err != nil {
+		k6ext.Panic(r.ctx, "parsing continue options: %w", err)
+	}
+	if err := r.continueRequest(copts); err != nil {
+		k6ext.Panic(r.ctx, "continuing route: %w", err)
+	}
+}
+
+func (r *Route) continueRequest(opts *RouteContinueOptions) error {
+	if err := r.startHandling(); err != nil {
+		return err
+	}
+	action := fetch.ContinueRequest(r.interceptionID)
+	if opts.URL != "" {
+		action = action.WithURL(opts.URL)
+	}
+	if opts.Method != "" {
+		action = action.WithMethod(opts.Method)
+	}
+	if opts.PostData != "" {
+		action = action.WithPostData(base64.StdEncoding.EncodeToString([]byte(opts.PostData)))
+	}
+	if opts.Headers != nil {
+		action = action.WithHeaders(toHeaderEntries(opts.Headers))
+	}
+	if err := action.Do(cdp.WithExecutor(r.ctx, r.session)); err != nil {
+		return fmt.Errorf("continuing request: %w", err)
+	}
+
+	return nil
+}
+
+// Fulfill fulfills the request with the given response.
+func (r *Route) Fulfill(opts goja.Value) {
+	r.logger.Debugf("Route:Fulfill", "iid:%s url:%q", r.interceptionID, r.request.URL())
+
+	fopts := NewRouteFulfillOptions()
+	if err := fopts.Parse(r.ctx, opts); err != nil {
+		k6ext.Panic(r.ctx, "parsing fulfill options: %w", err)
+	}
+	if err := r.fulfill(fopts); err != nil {
+		k6ext.Panic(r.ctx, "fulfilling route: %w", err)
+	}
+}
+
+func (r *Route) fulfill(opts *RouteFulfillOptions) error {
+	if err := r.startHandling(); err != nil {
+		return err
+	}
+	action := fetch.FulfillRequest(r.interceptionID, opts.Status).
+		WithResponseHeaders(opts.headerEntries()).
+		WithBody(base64.StdEncoding.EncodeToString(opts.Body))
+	if err := action.Do(cdp.WithExecutor(r.ctx, r.session)); err != nil {
+		return fmt.Errorf("fulfilling request: %w", err)
+	}
+
+	return nil
+}
+
+// Request returns the intercepted request.
+func (r *Route) Request() api.Request {
+	return r.request
+}
+
+// startHandling marks the route as handled.
+// It returns an error if the route is already handled.
+func (r *Route) startHandling() error {
+	r.handledMu.Lock()
+	defer r.handledMu.Unlock()
+
+	if r.handled {
+		return errRouteHandled
+	}
+	r.handled = true
+
+	return nil
+}
+
+// routeHandler is a handler registered with the Route method of
+// a page or browser context.
+type routeHandler struct {
+	matcher *urlMatcher
+	// key identifies the handler to remove it with Unroute, such as
+	// the JS function that the handler calls.
+	key     goja.Value
+	handler func(api.Route) error
+}
+
+// handle calls the handler with the route if the route's URL matches.
+// It returns false if the URL doesn't match.
+//
+// It must be called on the VU event loop.
+func (h *routeHandler) handle(r *Route) (bool, error) {
+	matched, err := h.matcher.matches(r.request.URL())
+	if err != nil || !matched {
+		return false, err
+	}
+	if err := h.handler(r); err != nil {
+		return true, fmt.Errorf("calling route handler: %w", err)
+	}
+
+	return true, nil
+}
+
+// matches returns true if the handler is registered for the URL matcher
+// value and, if the key is given, with the same key.
+func (h *routeHandler) matches(url, key goja.Value) bool {
+	if !h.matcher.equals(url) {
+		return false
+	}
+	if !gojaValueExists(key) {
+		return true
+	}
+	return gojaValueExists(h.key) && h.key.StrictEquals(key)
+}
+
+// routeHandlers is a list of route handlers that is safe for
+// concurrent use. The last registered handler comes first.
+type routeHandlers struct {
+	mu       sync.RWMutex
+	handlers []*routeHandler
+}
+
+// add registers a new route handler for the URL matcher.
+func (rh *routeHandlers) add(m *urlMatcher, key goja.Value, handler func(api.Route) error) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	h := &routeHandler{matcher: m, key: key, handler: handler}
+	rh.handlers = append([]*routeHandler{h}, rh.handlers...)
+}
+
+// remove removes the route handlers registered for the URL matcher value.
+// If the key is given, only the handlers registered with the same key are
+// removed.
+func (rh *routeHandlers) remove(url, key goja.Value) {
+	rh.mu.Lock()
+	defer rh.mu.Unlock()
+
+	handlers := make([]*routeHandler, 0, len(rh.handlers))
+	for _, h := range rh.handlers {
+		if !h.matches(url, key) {
+			handlers = append(handlers, h)
+		}
+	}
+	rh.handlers = handlers
+}
+
+// all returns a copy of the registered route handlers.
+func (rh *routeHandlers) all() []*routeHandler {
+	rh.mu.RLock()
+	defer rh.mu.RUnlock()
+
+	handlers := make([]*routeHandler, len(rh.handlers))
+	copy(handlers, rh.handlers)
+
+	return handlers
+}
+
+// count returns the number of registered route handlers.
+func (rh *routeHandlers) count() int {
+	rh.mu.RLock()
+	defer rh.mu.RUnlock()
+
+	return len(rh.handlers)
+}
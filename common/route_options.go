@@ -0,0 +1,160 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/dop251/goja"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// RouteContinueOptions are the options for continuing an intercepted request.
+type RouteContinueOptions struct {
+	Headers  map[string]string `json:"headers"`
+	Method   string            `json:"method"`
+	PostData string            `json:"postData"`
+	URL      string            `json:"url"`
+}
+
+// RouteFulfillOptions are the options for fulfilling an intercepted request.
+type RouteFulfillOptions struct {
+	Body        []byte            `json:"body"`
+	ContentType string            `json:"contentType"`
+	Headers     map[string]string `json:"headers"`
+	Path        string            `json:"path"`
+	Status      int64             `json:"status"`
+}
+
+// NewRouteContinueOptions returns a new RouteContinueOptions.
+func NewRouteContinueOptions() *RouteContinueOptions {
+	return &RouteContinueOptions{}
+}
+
+// Parse parses the route continue options.
+func (o *RouteContinueOptions) Parse(ctx context.Context, opts goja.Value) error {
+	rt := k6ext.Runtime(ctx)
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "headers":
+			o.Headers = parseHeaders(rt, obj.Get(k))
+		case "method":
+			o.Method = obj.Get(k).String()
+		case "postData":
+			o.PostData = obj.Get(k).String()
+		case "url":
+			o.URL = obj.Get(k).String()
+		}
+	}
+
+	return nil
+}
+
+// NewRouteFulfillOptions returns a new RouteFulfillOptions.
+func NewRouteFulfillOptions() *RouteFulfillOptions {
+	return &RouteFulfillOptions{
+		Status: 200,
+	}
+}
+
+// Parse parses the route fulfill options. The content of the file at
+// the path is read as the body, unless the body is given. The path is read
+// as open() reads it in the init context.
+func (o *RouteFulfillOptions) Parse(ctx context.Context, opts goja.Value) error {
+	rt := k6ext.Runtime(ctx)
+	if !gojaValueExists(opts) {
+		return nil
+	}
+	obj := opts.ToObject(rt)
+	for _, k := range obj.Keys() {
+		switch k {
+		case "body":
+			body := obj.Get(k)
+			switch v := body.Export().(type) {
+			case []byte:
+				o.Body = v
+			case goja.ArrayBuffer:
+				o.Body = v.Bytes()
+			default:
+				o.Body = []byte(body.String())
+			}
+		case "contentType":
+			o.ContentType = obj.Get(k).String()
+		case "headers":
+			o.Headers = parseHeaders(rt, obj.Get(k))
+		case "path":
+			o.Path = obj.Get(k).String()
+		case "status":
+			o.Status = obj.Get(k).ToInteger()
+		}
+	}
+	if o.Path != "" && o.Body == nil {
+		body, err := k6ext.ReadFile(ctx, o.Path)
+		if err != nil {
+			return fmt.Errorf("reading body from %q: %w", o.Path, err)
+		}
+		o.Body = body
+	}
+
+	return nil
+}
+
+// headerEntries returns the response headers of the fulfilled request
+// including the content type and length headers.
+func (o *RouteFulfillOptions) headerEntries() []*fetch.HeaderEntry {
+	var (
+		entries          = make([]*fetch.HeaderEntry, 0, len(o.Headers)+2)
+		hasContentLength bool
+	)
+	for name, value := range o.Headers {
+		switch http.CanonicalHeaderKey(name) {
+		case "Content-Type":
+			if o.ContentType != "" {
+				continue
+			}
+		case "Content-Length":
+			hasContentLength = true
+		}
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: value})
+	}
+	if o.ContentType != "" {
+		entries = append(entries, &fetch.HeaderEntry{Name: "Content-Type", Value: o.ContentType})
+	}
+	if !hasContentLength {
+		entries = append(entries, &fetch.HeaderEntry{
+			Name:  "Content-Length",
+			Value: strconv.Itoa(len(o.Body)),
+		})
+	}
+
+	return entries
+}
+
+func parseHeaders(rt *goja.Runtime, v goja.Value) map[string]string {
+	headers := make(map[string]string)
+	if !gojaValueExists(v) {
+		return headers
+	}
+	obj := v.ToObject(rt)
+	for _, k := range obj.Keys() {
+		headers[k] = obj.Get(k).String()
+	}
+
+	return headers
+}
+
+func toHeaderEntries(headers map[string]string) []*fetch.HeaderEntry {
+	entries := make([]*fetch.HeaderEntry, 0, len(headers))
+	for name, value := range headers {
+		entries = append(entries, &fetch.HeaderEntry{Name: name, Value: value})
+	}
+
+	return entries
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/grafana/xk6-browser/k6ext"
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	k6common "go.k6.io/k6/js/common"
+	k6fsext "go.k6.io/k6/lib/fsext"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteFulfillOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	// the files opened in the init context are in the file system
+	// of the init environment, e.g. in the archive of the test.
+	fs := k6fsext.NewMemMapFs()
+	require.NoError(t, k6fsext.WriteFile(fs, "/scripts/data/users.json", []byte(`[]`), 0o600))
+	vu := k6test.NewVU(t)
+	ctx := k6ext.WithInitEnv(vu.Context(), &k6common.InitEnvironment{
+		FileSystems: map[string]k6fsext.Fs{"file": fs},
+		CWD:         &url.URL{Scheme: "file", Path: "/scripts/"},
+	})
+
+	opts := NewRouteFulfillOptions()
+	require.NoError(t, opts.Parse(ctx, vu.ToGojaValue(map[string]any{
+		"path":   "data/users.json",
+		"status": 201,
+	})))
+	assert.Equal(t, []byte(`[]`), opts.Body)
+	assert.EqualValues(t, 201, opts.Status)
+
+	opts = NewRouteFulfillOptions()
+	require.NoError(t, opts.Parse(ctx, vu.ToGojaValue(map[string]any{
+		"path": "missing.json",
+		"body": "body",
+	})))
+	assert.Equal(t, []byte("body"), opts.Body, "body must take precedence over path")
+
+	err := NewRouteFulfillOptions().Parse(ctx, vu.ToGojaValue(map[string]any{"path": "missing.json"}))
+	assert.ErrorContains(t, err, `reading body from "missing.json"`)
+}
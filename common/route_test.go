@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+func TestRouteHandlersRemove(t *testing.T) {
+	t.Parallel()
+
+	rt := goja.New()
+	url := rt.ToValue("**/data")
+	m, err := newURLMatcher(rt, url)
+	require.NoError(t, err)
+
+	handler := func(api.Route) error { return nil }
+	keyA, err := rt.RunString(`() => {}`)
+	require.NoError(t, err)
+	keyB, err := rt.RunString(`() => {}`)
+	require.NoError(t, err)
+
+	add := func() *routeHandlers {
+		var rh routeHandlers
+		rh.add(m, keyA, handler)
+		rh.add(m, keyB, handler)
+		return &rh
+	}
+
+	t.Run("key", func(t *testing.T) {
+		rh := add()
+		rh.remove(url, keyA)
+		require.Equal(t, 1, rh.count())
+		assert.Equal(t, keyB, rh.all()[0].key)
+	})
+	t.Run("no_key", func(t *testing.T) {
+		rh := add()
+		rh.remove(url, goja.Undefined())
+		assert.Equal(t, 0, rh.count())
+	})
+	t.Run("other_url", func(t *testing.T) {
+		rh := add()
+		rh.remove(rt.ToValue("**/other"), keyA)
+		assert.Equal(t, 2, rh.count())
+	})
+}
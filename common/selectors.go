@@ -17,7 +17,9 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -31,6 +33,10 @@ var reXPathSelector *regexp.Regexp = regexp.MustCompile(`^\(*//`)
 type SelectorPart struct {
 	Name string `json:"name"`
 	Body string `json:"body"`
+
+	// Nested is the selector given to the internal:has engine
+	// that matches elements containing the elements it selects.
+	Nested *Selector `json:"nested,omitempty"`
 }
 
 type Selector struct {
@@ -54,6 +60,16 @@ func NewSelector(selector string) (*Selector, error) {
 }
 
 func (s *Selector) appendPart(p *SelectorPart, capture bool) error {
+	if p.Name == "internal:has" {
+		var nested string
+		if err := json.Unmarshal([]byte(p.Body), &nested); err != nil {
+			return fmt.Errorf("parsing has selector %s: %w", p.Body, err)
+		}
+		var err error
+		if p.Nested, err = NewSelector(nested); err != nil {
+			return err
+		}
+	}
 	s.Parts = append(s.Parts, p)
 	if capture {
 		if s.Capture != nil {
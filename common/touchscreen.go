@@ -2,12 +2,14 @@ package common
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/k6ext"
 
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/input"
+	"github.com/dop251/goja"
 )
 
 // Ensure Touchscreen implements the EventEmitter and api.Touchscreen interfaces.
@@ -32,23 +34,169 @@ func NewTouchscreen(ctx context.Context, s session, k *Keyboard) *Touchscreen {
 	}
 }
 
-func (t *Touchscreen) tap(x float64, y float64) error {
-	action := input.DispatchTouchEvent(input.TouchStart, []*input.TouchPoint{{X: x, Y: y}}).
+// dispatch dispatches a touch event with the touch points that are
+// active after the event. The touch points that are missing compared to
+// the previous event are released.
+func (t *Touchscreen) dispatch(typ input.TouchType, points []*input.TouchPoint) error {
+	action := input.DispatchTouchEvent(typ, points).
 		WithModifiers(input.Modifier(t.keyboard.modifiers))
-	if err := action.Do(cdp.WithExecutor(t.ctx, t.session)); err != nil {
+	return action.Do(cdp.WithExecutor(t.ctx, t.session))
+}
+
+func (t *Touchscreen) tap(x float64, y float64) error {
+	if err := t.dispatch(input.TouchStart, []*input.TouchPoint{{X: x, Y: y}}); err != nil {
 		return err
 	}
-	action = input.DispatchTouchEvent(input.TouchEnd, []*input.TouchPoint{}).
-		WithModifiers(input.Modifier(t.keyboard.modifiers))
-	if err := action.Do(cdp.WithExecutor(t.ctx, t.session)); err != nil {
-		return err
+	return t.dispatch(input.TouchEnd, []*input.TouchPoint{})
+}
+
+// move moves the touch points from their positions to the positions
+// that the to function returns for them in the given number of steps.
+func (t *Touchscreen) move(from []*input.TouchPoint, steps int64, to func(p *input.TouchPoint) (x, y float64)) error {
+	for i := int64(1); i <= steps; i++ {
+		progress := float64(i) / float64(steps)
+		points := make([]*input.TouchPoint, 0, len(from))
+		for _, p := range from {
+			x, y := to(p)
+			points = append(points, &input.TouchPoint{
+				X:  p.X + (x-p.X)*progress,
+				Y:  p.Y + (y-p.Y)*progress,
+				ID: p.ID,
+			})
+		}
+		if err := t.dispatch(input.TouchMove, points); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func (t *Touchscreen) swipe(x float64, y float64, opts *TouchscreenSwipeOptions) error {
+	start := []*input.TouchPoint{{X: x, Y: y}}
+	if err := t.dispatch(input.TouchStart, start); err != nil {
+		return err
+	}
+	dx, dy := opts.Direction.offset(opts.Distance)
+	err := t.move(start, opts.Steps, func(p *input.TouchPoint) (float64, float64) {
+		return p.X + dx, p.Y + dy
+	})
+	if err != nil {
+		return err
+	}
+	return t.dispatch(input.TouchEnd, []*input.TouchPoint{})
+}
+
+func (t *Touchscreen) pinch(x float64, y float64, opts *TouchscreenPinchOptions) error {
+	// The touch points are placed horizontally around the center,
+	// and move away from or towards it.
+	half := opts.Distance / 2
+	start := []*input.TouchPoint{
+		{X: x - half, Y: y, ID: 0},
+		{X: x + half, Y: y, ID: 1},
+	}
+	if err := t.dispatch(input.TouchStart, start); err != nil {
+		return err
+	}
+	err := t.move(start, opts.Steps, func(p *input.TouchPoint) (float64, float64) {
+		return x + (p.X-x)*opts.Scale, p.Y
+	})
+	if err != nil {
+		return err
+	}
+	return t.dispatch(input.TouchEnd, []*input.TouchPoint{})
+}
+
+func (t *Touchscreen) longPress(x float64, y float64, opts *TouchscreenLongPressOptions) error {
+	if err := t.dispatch(input.TouchStart, []*input.TouchPoint{{X: x, Y: y}}); err != nil {
+		return err
+	}
+	timer := time.NewTimer(time.Duration(opts.Duration) * time.Millisecond)
+	select {
+	case <-t.ctx.Done():
+		timer.Stop()
+	case <-timer.C:
+	}
+	return t.dispatch(input.TouchEnd, []*input.TouchPoint{})
+}
+
+// LongPress touches the touchscreen at x and y, and holds the touch
+// point for a duration.
+func (t *Touchscreen) LongPress(x float64, y float64, opts goja.Value) {
+	popts := NewTouchscreenLongPressOptions()
+	if err := popts.Parse(t.ctx, opts); err != nil {
+		k6ext.Panic(t.ctx, "parsing long press options: %w", err)
+	}
+	if err := t.longPress(x, y, popts); err != nil {
+		k6ext.Panic(t.ctx, "long pressing on x:%f y:%f: %w", x, y, err)
+	}
+	applySlowMo(t.ctx)
+}
+
+// Pinch touches the touchscreen with two touch points around x and y,
+// and moves them apart to zoom in, or together to zoom out.
+func (t *Touchscreen) Pinch(x float64, y float64, opts goja.Value) {
+	popts := NewTouchscreenPinchOptions()
+	if err := popts.Parse(t.ctx, opts); err != nil {
+		k6ext.Panic(t.ctx, "parsing pinch options: %w", err)
+	}
+	if err := t.pinch(x, y, popts); err != nil {
+		k6ext.Panic(t.ctx, "pinching on x:%f y:%f: %w", x, y, err)
+	}
+	applySlowMo(t.ctx)
+}
+
+// Swipe touches the touchscreen at x and y, and moves the touch point
+// in a direction.
+func (t *Touchscreen) Swipe(x float64, y float64, opts goja.Value) {
+	popts := NewTouchscreenSwipeOptions()
+	if err := popts.Parse(t.ctx, opts); err != nil {
+		k6ext.Panic(t.ctx, "parsing swipe options: %w", err)
+	}
+	if err := t.swipe(x, y, popts); err != nil {
+		k6ext.Panic(t.ctx, "swiping from x:%f y:%f: %w", x, y, err)
+	}
+	applySlowMo(t.ctx)
+}
+
 // Tap dispatches a tap start and tap end event.
 func (t *Touchscreen) Tap(x float64, y float64) {
 	if err := t.tap(x, y); err != nil {
 		k6ext.Panic(t.ctx, "tapping: %w", err)
 	}
+	applySlowMo(t.ctx)
+}
+
+// TouchEnd releases all the touch points.
+func (t *Touchscreen) TouchEnd() {
+	if err := t.dispatch(input.TouchEnd, []*input.TouchPoint{}); err != nil {
+		k6ext.Panic(t.ctx, "ending touch: %w", err)
+	}
+	applySlowMo(t.ctx)
+}
+
+// TouchMove moves the touch points, which are either a single point or
+// an array of points such as {x, y, id}.
+func (t *Touchscreen) TouchMove(points goja.Value) {
+	tps, err := parseTouchPoints(t.ctx, points)
+	if err != nil {
+		k6ext.Panic(t.ctx, "parsing touch points: %w", err)
+	}
+	if err := t.dispatch(input.TouchMove, tps); err != nil {
+		k6ext.Panic(t.ctx, "moving touch: %w", err)
+	}
+	applySlowMo(t.ctx)
+}
+
+// TouchStart touches the touchscreen with the touch points, which are
+// either a single point or an array of points such as {x, y, id}. The
+// touch points that were active before and are missing are released.
+func (t *Touchscreen) TouchStart(points goja.Value) {
+	tps, err := parseTouchPoints(t.ctx, points)
+	if err != nil {
+		k6ext.Panic(t.ctx, "parsing touch points: %w", err)
+	}
+	if err := t.dispatch(input.TouchStart, tps); err != nil {
+		k6ext.Panic(t.ctx, "starting touch: %w", err)
+	}
+	applySlowMo(t.ctx)
 }
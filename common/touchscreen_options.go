@@ -0,0 +1,219 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/dop251/goja"
+
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// SwipeDirection is the direction that a swipe moves the touch point in.
+type SwipeDirection string
+
+// Valid swipe directions.
+const (
+	SwipeDirectionLeft  SwipeDirection = "left"
+	SwipeDirectionRight SwipeDirection = "right"
+	SwipeDirectionUp    SwipeDirection = "up"
+	SwipeDirectionDown  SwipeDirection = "down"
+)
+
+// offset returns the offset of a swipe of the distance in the direction.
+func (d SwipeDirection) offset(distance float64) (dx, dy float64) {
+	switch d {
+	case SwipeDirectionLeft:
+		return -distance, 0
+	case SwipeDirectionRight:
+		return distance, 0
+	case SwipeDirectionUp:
+		return 0, -distance
+	case SwipeDirectionDown:
+		return 0, distance
+	}
+	return 0, 0
+}
+
+// TouchscreenSwipeOptions are the options for swiping on the touchscreen.
+type TouchscreenSwipeOptions struct {
+	Direction SwipeDirection `json:"direction"`
+	// Distance is the distance in CSS pixels that the touch point moves.
+	Distance float64 `json:"distance"`
+	// Steps is the number of touch moves that the swipe is made of.
+	Steps int64 `json:"steps"`
+}
+
+// TouchscreenPinchOptions are the options for pinching on the touchscreen.
+type TouchscreenPinchOptions struct {
+	// Distance is the distance in CSS pixels between the two touch
+	// points when the pinch starts.
+	Distance float64 `json:"distance"`
+	// Scale is the factor that the distance between the touch points
+	// changes by. The pinch zooms in when it's above 1, and out when
+	// it's below 1.
+	Scale float64 `json:"scale"`
+	// Steps is the number of touch moves that the pinch is made of.
+	Steps int64 `json:"steps"`
+}
+
+// TouchscreenLongPressOptions are the options for long pressing
+// on the touchscreen.
+type TouchscreenLongPressOptions struct {
+	// Duration is the time in milliseconds that the touch point is held.
+	Duration int64 `json:"duration"`
+}
+
+// NewTouchscreenSwipeOptions returns a new TouchscreenSwipeOptions.
+func NewTouchscreenSwipeOptions() *TouchscreenSwipeOptions {
+	return &TouchscreenSwipeOptions{
+		Direction: SwipeDirectionLeft,
+		Distance:  100,
+		Steps:     10,
+	}
+}
+
+// Parse parses the swipe options.
+func (o *TouchscreenSwipeOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if gojaValueExists(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			switch k {
+			case "direction":
+				o.Direction = SwipeDirection(strings.ToLower(opts.Get(k).String()))
+			case "distance":
+				o.Distance = opts.Get(k).ToFloat()
+			case "steps":
+				o.Steps = opts.Get(k).ToInteger()
+			}
+		}
+	}
+	switch o.Direction {
+	case SwipeDirectionLeft, SwipeDirectionRight, SwipeDirectionUp, SwipeDirectionDown:
+	default:
+		return fmt.Errorf("invalid swipe direction: %q; must be one of: left, right, up, down", o.Direction)
+	}
+	if o.Distance <= 0 {
+		return errors.New("distance must be greater than 0")
+	}
+	if o.Steps < 1 {
+		return errors.New("steps must be at least 1")
+	}
+
+	return nil
+}
+
+// NewTouchscreenPinchOptions returns a new TouchscreenPinchOptions.
+func NewTouchscreenPinchOptions() *TouchscreenPinchOptions {
+	return &TouchscreenPinchOptions{
+		Distance: 100,
+		Scale:    2,
+		Steps:    10,
+	}
+}
+
+// Parse parses the pinch options.
+func (o *TouchscreenPinchOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if gojaValueExists(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			switch k {
+			case "distance":
+				o.Distance = opts.Get(k).ToFloat()
+			case "scale":
+				o.Scale = opts.Get(k).ToFloat()
+			case "steps":
+				o.Steps = opts.Get(k).ToInteger()
+			}
+		}
+	}
+	if o.Distance <= 0 {
+		return errors.New("distance must be greater than 0")
+	}
+	if o.Scale <= 0 {
+		return errors.New("scale must be greater than 0")
+	}
+	if o.Steps < 1 {
+		return errors.New("steps must be at least 1")
+	}
+
+	return nil
+}
+
+// NewTouchscreenLongPressOptions returns a new TouchscreenLongPressOptions.
+func NewTouchscreenLongPressOptions() *TouchscreenLongPressOptions {
+	return &TouchscreenLongPressOptions{
+		Duration: 1000,
+	}
+}
+
+// Parse parses the long press options.
+func (o *TouchscreenLongPressOptions) Parse(ctx context.Context, opts goja.Value) error {
+	if gojaValueExists(opts) {
+		opts := opts.ToObject(k6ext.Runtime(ctx))
+		for _, k := range opts.Keys() {
+			if k == "duration" {
+				o.Duration = opts.Get(k).ToInteger()
+			}
+		}
+	}
+	if o.Duration < 0 {
+		return errors.New("duration must not be negative")
+	}
+
+	return nil
+}
+
+// parseTouchPoints parses the touch points of a touch event, which are
+// either a single point or an array of points such as {x, y, id}. The
+// points without an ID are identified by their index.
+func parseTouchPoints(ctx context.Context, points goja.Value) ([]*input.TouchPoint, error) {
+	if !gojaValueExists(points) {
+		return nil, errors.New("missing touch points")
+	}
+	rt := k6ext.Runtime(ctx)
+	obj := points.ToObject(rt)
+	values := []goja.Value{obj}
+	if obj.ClassName() == "Array" {
+		n := obj.Get("length").ToInteger()
+		values = make([]goja.Value, 0, n)
+		for i := int64(0); i < n; i++ {
+			values = append(values, obj.Get(strconv.FormatInt(i, 10)))
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.New("at least one touch point is required")
+	}
+
+	tps := make([]*input.TouchPoint, 0, len(values))
+	for i, v := range values {
+		if !gojaValueExists(v) {
+			return nil, fmt.Errorf("missing touch point at index %d", i)
+		}
+		p := v.ToObject(rt)
+		x, y := p.Get("x"), p.Get("y")
+		if !gojaValueExists(x) || !gojaValueExists(y) {
+			return nil, fmt.Errorf("touch point at index %d must have x and y", i)
+		}
+		tp := &input.TouchPoint{X: x.ToFloat(), Y: y.ToFloat(), ID: float64(i)}
+		if id := p.Get("id"); gojaValueExists(id) {
+			tp.ID = id.ToFloat()
+		}
+		if v := p.Get("radiusX"); gojaValueExists(v) {
+			tp.RadiusX = v.ToFloat()
+		}
+		if v := p.Get("radiusY"); gojaValueExists(v) {
+			tp.RadiusY = v.ToFloat()
+		}
+		if v := p.Get("force"); gojaValueExists(v) {
+			tp.Force = v.ToFloat()
+		}
+		tps = append(tps, tp)
+	}
+
+	return tps, nil
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/chromedp/cdproto/input"
+
+	"github.com/grafana/xk6-browser/k6ext/k6test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTouchscreenSwipeOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	opts := NewTouchscreenSwipeOptions()
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{
+		"direction": "Up",
+		"distance":  250,
+		"steps":     5,
+	})))
+	assert.Equal(t, &TouchscreenSwipeOptions{Direction: SwipeDirectionUp, Distance: 250, Steps: 5}, opts)
+
+	dx, dy := opts.Direction.offset(opts.Distance)
+	assert.Equal(t, []float64{0, -250}, []float64{dx, dy})
+
+	opts = NewTouchscreenSwipeOptions()
+	err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"direction": "sideways"}))
+	assert.EqualError(t, err, `invalid swipe direction: "sideways"; must be one of: left, right, up, down`)
+
+	opts = NewTouchscreenSwipeOptions()
+	err = opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"steps": 0}))
+	assert.EqualError(t, err, "steps must be at least 1")
+}
+
+func TestTouchscreenPinchOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	opts := NewTouchscreenPinchOptions()
+	require.NoError(t, opts.Parse(vu.Context(), nil))
+	assert.Equal(t, &TouchscreenPinchOptions{Distance: 100, Scale: 2, Steps: 10}, opts)
+
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"scale": 0.5})))
+	assert.Equal(t, 0.5, opts.Scale)
+
+	err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"scale": -1}))
+	assert.EqualError(t, err, "scale must be greater than 0")
+}
+
+func TestTouchscreenLongPressOptionsParse(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+	opts := NewTouchscreenLongPressOptions()
+	require.NoError(t, opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"duration": 500})))
+	assert.Equal(t, int64(500), opts.Duration)
+
+	err := opts.Parse(vu.Context(), vu.ToGojaValue(map[string]any{"duration": -1}))
+	assert.EqualError(t, err, "duration must not be negative")
+}
+
+func TestParseTouchPoints(t *testing.T) {
+	t.Parallel()
+
+	vu := k6test.NewVU(t)
+
+	tps, err := parseTouchPoints(vu.Context(), vu.ToGojaValue(map[string]any{"x": 1, "y": 2}))
+	require.NoError(t, err)
+	assert.Equal(t, []*input.TouchPoint{{X: 1, Y: 2}}, tps)
+
+	tps, err = parseTouchPoints(vu.Context(), vu.ToGojaValue([]any{
+		map[string]any{"x": 1, "y": 2},
+		map[string]any{"x": 3, "y": 4, "id": 7, "force": 0.5},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []*input.TouchPoint{
+		{X: 1, Y: 2, ID: 0},
+		{X: 3, Y: 4, ID: 7, Force: 0.5},
+	}, tps)
+
+	_, err = parseTouchPoints(vu.Context(), vu.ToGojaValue([]any{}))
+	assert.EqualError(t, err, "at least one touch point is required")
+
+	_, err = parseTouchPoints(vu.Context(), vu.ToGojaValue([]any{map[string]any{"x": 1}}))
+	assert.EqualError(t, err, "touch point at index 0 must have x and y")
+}
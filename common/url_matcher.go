@@ -0,0 +1,183 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// urlMatcher matches URLs against a glob pattern, a regular expression, or
+// a predicate function given by the user.
+//
+// Glob patterns and regular expressions are compiled into Go regular
+// expressions, so they can be matched from any goroutine. Predicates
+// are JS functions and must only be called on the VU event loop.
+type urlMatcher struct {
+	value     goja.Value
+	re        *regexp.Regexp
+	predicate goja.Callable
+	rt        *goja.Runtime
+}
+
+// newURLMatcher returns a new urlMatcher for the given value.
+// The value can be a glob pattern string, a RegExp, or a predicate
+// function that receives the URL string and returns a boolean.
+func newURLMatcher(rt *goja.Runtime, match goja.Value) (*urlMatcher, error) {
+	if !gojaValueExists(match) {
+		return nil, errors.New("missing URL matcher")
+	}
+	if fn, ok := goja.AssertFunction(match); ok {
+		return &urlMatcher{value: match, predicate: fn, rt: rt}, nil
+	}
+
+	var (
+		re  *regexp.Regexp
+		err error
+	)
+	if obj, ok := match.(*goja.Object); ok && obj.ClassName() == "RegExp" {
+		re, err = regexpFromJS(obj)
+	} else {
+		re, err = regexp.Compile(globToRegexp(match.String()))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL matcher %q: %w", match, err)
+	}
+
+	return &urlMatcher{value: match, re: re}, nil
+}
+
+// isPredicate returns true if the matcher uses a JS predicate function, and
+// it needs to be called on the VU event loop.
+func (m *urlMatcher) isPredicate() bool {
+	return m.predicate != nil
+}
+
+// matches returns true if the URL matches.
+func (m *urlMatcher) matches(url string) (bool, error) {
+	if m.predicate == nil {
+		return m.re.MatchString(url), nil
+	}
+	v, err := m.predicate(goja.Undefined(), m.rt.ToValue(url))
+	if err != nil {
+		return false, fmt.Errorf("calling URL predicate: %w", err)
+	}
+
+	return v.ToBoolean(), nil
+}
+
+// equals returns true if the matcher was created from the given value.
+// Strings are compared by value, while RegExps and functions are compared
+// by identity.
+func (m *urlMatcher) equals(match goja.Value) bool {
+	return m.value.StrictEquals(match)
+}
+
+// regexpFromJS converts a JS RegExp object into a Go regular expression.
+// Only the flags that have a Go equivalent are carried over.
+func regexpFromJS(obj *goja.Object) (*regexp.Regexp, error) {
+	var (
+		source = obj.Get("source").String()
+		flags  = obj.Get("flags").String()
+		goflag strings.Builder
+	)
+	for _, f := range flags {
+		switch f {
+		case 'i', 'm', 's':
+			goflag.WriteRune(f)
+		}
+	}
+	if goflag.Len() > 0 {
+		source = "(?" + goflag.String() + ")" + source
+	}
+
+	return regexp.Compile(source) //nolint:wrapcheck
+}
+
+// globToRegexp converts a glob pattern into a regular expression.
+//
+//   - `*` matches any characters except `/`.
+//   - `**` matches any characters including `/`.
+//   - `?` matches a single character.
+//   - `{a,b}` matches one of the given alternatives.
+//   - `[...]` matches a character range.
+//
+// Based on the globToRegex function of Playwright.
+func globToRegexp(glob string) string {
+	const escapedChars = "$^+.*()|\\?{}[]"
+
+	var (
+		tokens  = []string{"^"}
+		inGroup bool
+	)
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		if c == '\\' && i+1 < len(glob) {
+			i++
+			char := glob[i]
+			if strings.IndexByte(escapedChars, char) >= 0 {
+				tokens = append(tokens, "\\"+string(char))
+			} else {
+				tokens = append(tokens, string(char))
+			}
+			continue
+		}
+		if c == '*' {
+			var beforeDeep byte
+			if i > 0 {
+				beforeDeep = glob[i-1]
+			}
+			starCount := 1
+			for i+1 < len(glob) && glob[i+1] == '*' {
+				starCount++
+				i++
+			}
+			var afterDeep byte
+			if i+1 < len(glob) {
+				afterDeep = glob[i+1]
+			}
+			isDeep := starCount > 1 &&
+				(beforeDeep == '/' || beforeDeep == 0) &&
+				(afterDeep == '/' || afterDeep == 0)
+			if isDeep {
+				tokens = append(tokens, "((?:[^/]*(?:/|$))*)")
+				i++
+			} else {
+				tokens = append(tokens, "([^/]*)")
+			}
+			continue
+		}
+
+		switch c {
+		case '?':
+			tokens = append(tokens, ".")
+		case '[':
+			tokens = append(tokens, "[")
+		case ']':
+			tokens = append(tokens, "]")
+		case '{':
+			inGroup = true
+			tokens = append(tokens, "(")
+		case '}':
+			inGroup = false
+			tokens = append(tokens, ")")
+		case ',':
+			if inGroup {
+				tokens = append(tokens, "|")
+				break
+			}
+			tokens = append(tokens, "\\"+string(c))
+		default:
+			if strings.IndexByte(escapedChars, c) >= 0 {
+				tokens = append(tokens, "\\"+string(c))
+			} else {
+				tokens = append(tokens, string(c))
+			}
+		}
+	}
+	tokens = append(tokens, "$")
+
+	return strings.Join(tokens, "")
+}
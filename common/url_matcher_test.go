@@ -0,0 +1,71 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLMatcher(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		match string // JS expression
+		url   string
+		want  bool
+	}{
+		{"glob_exact", `'https://example.com/'`, "https://example.com/", true},
+		{"glob_exact_mismatch", `'https://example.com/'`, "https://example.com/a", false},
+		{"glob_star", `'https://example.com/*.js'`, "https://example.com/app.js", true},
+		{"glob_star_no_slash", `'https://example.com/*.js'`, "https://example.com/js/app.js", false},
+		{"glob_double_star", `'**/*.js'`, "https://example.com/js/app.js", true},
+		{"glob_double_star_any", `'**'`, "https://example.com/a/b?c=d", true},
+		{"glob_question_mark", `'https://example.com/?.css'`, "https://example.com/a.css", true},
+		{"glob_group", `'**/*.{png,jpg}'`, "https://example.com/img/a.jpg", true},
+		{"glob_group_mismatch", `'**/*.{png,jpg}'`, "https://example.com/img/a.gif", false},
+		{"glob_escaped", `'**/a\\?b'`, "https://example.com/a?b", true},
+		{"regexp", `/api\/v\d+/`, "https://example.com/api/v2/users", true},
+		{"regexp_mismatch", `/api\/v\d+/`, "https://example.com/api/users", false},
+		{"regexp_ignore_case", `/EXAMPLE/i`, "https://example.com/", true},
+		{"predicate", `url => url.endsWith('.json')`, "https://example.com/data.json", true},
+		{"predicate_mismatch", `url => url.endsWith('.json')`, "https://example.com/data.xml", false},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := goja.New()
+			v, err := rt.RunString(tc.match)
+			require.NoError(t, err)
+
+			m, err := newURLMatcher(rt, v)
+			require.NoError(t, err)
+			got, err := m.matches(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.True(t, m.equals(v))
+		})
+	}
+
+	t.Run("err_missing", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newURLMatcher(goja.New(), goja.Undefined())
+		require.Error(t, err)
+	})
+
+	t.Run("err_regexp", func(t *testing.T) {
+		t.Parallel()
+
+		rt := goja.New()
+		v, err := rt.RunString(`/(?<=a)b/`)
+		require.NoError(t, err)
+
+		_, err = newURLMatcher(rt, v)
+		require.ErrorContains(t, err, "parsing URL matcher")
+	})
+}
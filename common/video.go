@@ -0,0 +1,107 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+// Ensure Video implements the api.Video interface.
+var _ api.Video = &Video{}
+
+// Video is the video recording of a page.
+type Video struct {
+	ctx      context.Context
+	path     string
+	recorder *videoRecorder
+
+	// done is closed when the recording is finished.
+	done     chan struct{}
+	doneOnce sync.Once
+	err      error
+}
+
+// NewVideo creates the video file at the path and returns a new Video that
+// records into it.
+func NewVideo(ctx context.Context, path string) (*Video, error) {
+	r, err := newVideoRecorder(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Video{
+		ctx:      ctx,
+		path:     path,
+		recorder: r,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// Path returns the path of the video file.
+func (v *Video) Path() string {
+	return v.path
+}
+
+// SaveAs waits for the recording to finish and copies the video to the path.
+//
+// The recording finishes when the page, its browser context or the browser
+// is closed, so it must be called from a promise, and not from the VU event
+// loop.
+func (v *Video) SaveAs(path string) error {
+	if err := v.saveAs(path); err != nil {
+		return fmt.Errorf("saving video: %w", err)
+	}
+
+	return nil
+}
+
+func (v *Video) saveAs(path string) error {
+	select {
+	case <-v.done:
+	case <-v.ctx.Done():
+		return fmt.Errorf("waiting for the video to finish: %w", v.ctx.Err())
+	}
+	if v.err != nil {
+		return v.err
+	}
+
+	src, err := os.Open(v.path)
+	if err != nil {
+		return fmt.Errorf("opening video file: %w", err)
+	}
+	defer src.Close() //nolint:errcheck
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating video directory %q: %w", dir, err)
+	}
+	dst, err := os.Create(path) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("creating video file %q: %w", path, err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return fmt.Errorf("copying video to %q: %w", path, err)
+	}
+
+	return dst.Close() //nolint:wrapcheck
+}
+
+// addFrame adds a screencast frame captured at ts to the video.
+func (v *Video) addFrame(frame []byte, ts time.Time) error {
+	return v.recorder.writeFrame(frame, ts)
+}
+
+// finish stops the recording and finalizes the video file.
+func (v *Video) finish() {
+	v.doneOnce.Do(func() {
+		v.err = v.recorder.stop(time.Now())
+		close(v.done)
+	})
+}
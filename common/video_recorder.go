@@ -0,0 +1,298 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// videoFrameRate is the number of frames per second of the recorded videos.
+const videoFrameRate = 10
+
+// aviHeaderSize is the size of the AVI headers that precede the frames.
+//
+//	RIFF header (12) + hdrl list (12 + 64 + 12 + 64 + 48) + movi list header (12)
+const aviHeaderSize = 224
+
+// aviMainHeader is the AVI main header (avih).
+type aviMainHeader struct {
+	MicroSecPerFrame    uint32
+	MaxBytesPerSec      uint32
+	PaddingGranularity  uint32
+	Flags               uint32
+	TotalFrames         uint32
+	InitialFrames       uint32
+	Streams             uint32
+	SuggestedBufferSize uint32
+	Width               uint32
+	Height              uint32
+	Reserved            [4]uint32
+}
+
+// aviStreamHeader is the AVI stream header (strh).
+type aviStreamHeader struct {
+	Type                [4]byte
+	Handler             [4]byte
+	Flags               uint32
+	Priority            uint16
+	Language            uint16
+	InitialFrames       uint32
+	Scale               uint32
+	Rate                uint32
+	Start               uint32
+	Length              uint32
+	SuggestedBufferSize uint32
+	Quality             uint32
+	SampleSize          uint32
+	Frame               [4]uint16
+}
+
+// aviBitmapInfoHeader is the AVI stream format (strf) of a video stream.
+type aviBitmapInfoHeader struct {
+	Size          uint32
+	Width         uint32
+	Height        uint32
+	Planes        uint16
+	BitCount      uint16
+	Compression   [4]byte
+	SizeImage     uint32
+	XPelsPerMeter uint32
+	YPelsPerMeter uint32
+	ClrUsed       uint32
+	ClrImportant  uint32
+}
+
+// aviIndexEntry is an entry of the AVI index (idx1).
+type aviIndexEntry struct {
+	ChunkID [4]byte
+	Flags   uint32
+	Offset  uint32
+	Size    uint32
+}
+
+// videoRecorder writes the JPEG frames of a screencast into a Motion JPEG
+// video in an AVI container.
+//
+// Screencast frames are only received when the page changes. The recorder
+// repeats the last frame until the next one is received so that the video
+// plays at a constant frame rate.
+type videoRecorder struct {
+	mu sync.Mutex
+
+	file          *os.File
+	width, height int
+	maxFrameSize  int
+	moviSize      int
+	index         []aviIndexEntry
+
+	start     time.Time
+	lastFrame []byte
+	frames    int
+	stopped   bool
+}
+
+// newVideoRecorder creates the video file at the path, and returns a new
+// video recorder that writes to it.
+func newVideoRecorder(path string) (*videoRecorder, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating video directory %q: %w", dir, err)
+	}
+	f, err := os.Create(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("creating video file %q: %w", path, err)
+	}
+	// reserve space for the headers that are written when the recording stops.
+	if _, err := f.Write(make([]byte, aviHeaderSize)); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("writing video file %q: %w", path, err)
+	}
+
+	return &videoRecorder{file: f}, nil
+}
+
+// writeFrame adds the JPEG frame captured at ts to the video.
+func (r *videoRecorder) writeFrame(frame []byte, ts time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return nil
+	}
+	if r.lastFrame == nil {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(frame))
+		if err != nil {
+			return fmt.Errorf("decoding video frame: %w", err)
+		}
+		r.width, r.height = cfg.Width, cfg.Height
+		r.start = ts
+	} else if err := r.flush(ts); err != nil {
+		return err
+	}
+	r.lastFrame = frame
+
+	return nil
+}
+
+// stop writes the last frame until ts, finalizes the video, and closes
+// the video file.
+func (r *videoRecorder) stop(ts time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return nil
+	}
+	r.stopped = true
+
+	err := r.flush(ts)
+	if err == nil {
+		err = r.finalize()
+	}
+	if cerr := r.file.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("closing video file: %w", cerr)
+	}
+
+	return err
+}
+
+// flush writes the last frame as many times as needed to fill the time
+// until ts. The last frame is written at least once.
+func (r *videoRecorder) flush(ts time.Time) error {
+	if r.lastFrame == nil {
+		return nil
+	}
+	until := int(ts.Sub(r.start).Seconds() * videoFrameRate)
+	if until <= r.frames {
+		until = r.frames + 1
+	}
+	for r.frames < until {
+		if err := r.writeChunk(r.lastFrame); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *videoRecorder) writeChunk(frame []byte) error {
+	size := len(frame)
+	var buf bytes.Buffer
+	buf.WriteString("00dc")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(size))
+	buf.Write(frame)
+	if size%2 == 1 {
+		buf.WriteByte(0) // chunks are padded to an even size
+	}
+	if _, err := r.file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("writing video frame: %w", err)
+	}
+
+	r.index = append(r.index, aviIndexEntry{
+		ChunkID: [4]byte{'0', '0', 'd', 'c'},
+		Flags:   0x10, // AVIIF_KEYFRAME
+		Offset:  uint32(4 + r.moviSize),
+		Size:    uint32(size),
+	})
+	r.moviSize += buf.Len()
+	r.frames++
+	if size > r.maxFrameSize {
+		r.maxFrameSize = size
+	}
+
+	return nil
+}
+
+// finalize writes the index after the frames and the headers at the
+// beginning of the video file.
+func (r *videoRecorder) finalize() error {
+	var idx bytes.Buffer
+	idx.WriteString("idx1")
+	_ = binary.Write(&idx, binary.LittleEndian, uint32(len(r.index)*16))
+	_ = binary.Write(&idx, binary.LittleEndian, r.index)
+	if _, err := r.file.Write(idx.Bytes()); err != nil {
+		return fmt.Errorf("writing video index: %w", err)
+	}
+
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("writing video headers: %w", err)
+	}
+	fileSize := aviHeaderSize + r.moviSize + idx.Len()
+	if _, err := r.file.Write(r.headers(fileSize)); err != nil {
+		return fmt.Errorf("writing video headers: %w", err)
+	}
+
+	return nil
+}
+
+// headers returns the RIFF, hdrl and movi headers of the video.
+func (r *videoRecorder) headers(fileSize int) []byte {
+	var (
+		buf   bytes.Buffer
+		le    = binary.LittleEndian
+		w, h  = uint32(r.width), uint32(r.height)
+		write = func(data any) { _ = binary.Write(&buf, le, data) }
+	)
+
+	buf.WriteString("RIFF")
+	write(uint32(fileSize - 8))
+	buf.WriteString("AVI ")
+
+	buf.WriteString("LIST")
+	write(uint32(192))
+	buf.WriteString("hdrl")
+
+	buf.WriteString("avih")
+	write(uint32(56))
+	write(aviMainHeader{
+		MicroSecPerFrame:    uint32(time.Second / time.Microsecond / videoFrameRate),
+		MaxBytesPerSec:      uint32(r.maxFrameSize * videoFrameRate),
+		Flags:               0x10, // AVIF_HASINDEX
+		TotalFrames:         uint32(r.frames),
+		Streams:             1,
+		SuggestedBufferSize: uint32(r.maxFrameSize),
+		Width:               w,
+		Height:              h,
+	})
+
+	buf.WriteString("LIST")
+	write(uint32(116))
+	buf.WriteString("strl")
+
+	buf.WriteString("strh")
+	write(uint32(56))
+	write(aviStreamHeader{
+		Type:                [4]byte{'v', 'i', 'd', 's'},
+		Handler:             [4]byte{'M', 'J', 'P', 'G'},
+		Scale:               1,
+		Rate:                videoFrameRate,
+		Length:              uint32(r.frames),
+		SuggestedBufferSize: uint32(r.maxFrameSize),
+		Quality:             0xFFFFFFFF, // default quality
+		Frame:               [4]uint16{0, 0, uint16(w), uint16(h)},
+	})
+
+	buf.WriteString("strf")
+	write(uint32(40))
+	write(aviBitmapInfoHeader{
+		Size:        40,
+		Width:       w,
+		Height:      h,
+		Planes:      1,
+		BitCount:    24,
+		Compression: [4]byte{'M', 'J', 'P', 'G'},
+		SizeImage:   w * h * 3,
+	})
+
+	buf.WriteString("LIST")
+	write(uint32(4 + r.moviSize))
+	buf.WriteString("movi")
+
+	return buf.Bytes()
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoRecorder(t *testing.T) {
+	t.Parallel()
+
+	var frame bytes.Buffer
+	require.NoError(t, jpeg.Encode(&frame, image.NewRGBA(image.Rect(0, 0, 64, 48)), nil))
+
+	path := filepath.Join(t.TempDir(), "videos", "page.avi")
+	r, err := newVideoRecorder(path)
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, r.writeFrame(frame.Bytes(), start))
+	// the first frame is repeated until the second one.
+	require.NoError(t, r.writeFrame(frame.Bytes(), start.Add(500*time.Millisecond)))
+	// the second frame is repeated until the recording stops.
+	require.NoError(t, r.stop(start.Add(time.Second)))
+	// stopping again is a no-op.
+	require.NoError(t, r.stop(start.Add(2*time.Second)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	le := binary.LittleEndian
+	assert.Equal(t, "RIFF", string(data[0:4]))
+	assert.EqualValues(t, len(data)-8, le.Uint32(data[4:8]))
+	assert.Equal(t, "AVI ", string(data[8:12]))
+
+	// avih
+	assert.Equal(t, "avih", string(data[24:28]))
+	avih := data[32:]
+	assert.EqualValues(t, 100000, le.Uint32(avih[0:4]), "microseconds per frame")
+	assert.EqualValues(t, 10, le.Uint32(avih[16:20]), "total frames")
+	assert.EqualValues(t, 64, le.Uint32(avih[32:36]), "width")
+	assert.EqualValues(t, 48, le.Uint32(avih[36:40]), "height")
+
+	// movi
+	assert.Equal(t, "movi", string(data[aviHeaderSize-4:aviHeaderSize]))
+	assert.Equal(t, "00dc", string(data[aviHeaderSize:aviHeaderSize+4]))
+	size := le.Uint32(data[aviHeaderSize+4 : aviHeaderSize+8])
+	assert.Equal(t, frame.Bytes(), data[aviHeaderSize+8:aviHeaderSize+8+int(size)])
+
+	// idx1
+	moviSize := int(le.Uint32(data[aviHeaderSize-8 : aviHeaderSize-4]))
+	idx := data[aviHeaderSize-4+moviSize:]
+	assert.Equal(t, "idx1", string(idx[0:4]))
+	assert.EqualValues(t, 10*16, le.Uint32(idx[4:8]))
+}
+
+func TestVideoRecorderNoFrames(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "page.avi")
+	r, err := newVideoRecorder(path)
+	require.NoError(t, err)
+	require.NoError(t, r.stop(time.Now()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, data, aviHeaderSize+8)
+}
@@ -0,0 +1,49 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoSaveAs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v, err := NewVideo(ctx, filepath.Join(t.TempDir(), "page.avi"))
+	require.NoError(t, err)
+
+	saved := make(chan error, 1)
+	path := filepath.Join(t.TempDir(), "videos", "saved.avi")
+	go func() { saved <- v.SaveAs(path) }()
+	select {
+	case err := <-saved:
+		t.Fatalf("saved the video before the recording finished: %v", err)
+	default:
+	}
+
+	v.finish()
+	require.NoError(t, <-saved)
+	want, err := os.ReadFile(v.Path())
+	require.NoError(t, err)
+	got, err := os.ReadFile(path) //nolint:gosec
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		v, err := NewVideo(ctx, filepath.Join(t.TempDir(), "page.avi"))
+		require.NoError(t, err)
+		cancel()
+
+		err = v.SaveAs(filepath.Join(t.TempDir(), "saved.avi"))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,135 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/log"
+)
+
+// Ensure WebSocket implements the api.WebSocket interface.
+var _ api.WebSocket = &WebSocket{}
+
+// webSocketEvents are the events that can be subscribed to with WebSocket.On.
+var webSocketEvents = map[string]struct{}{ //nolint:gochecknoglobals
+	EventWebSocketClose:         {},
+	EventWebSocketError:         {},
+	EventWebSocketFrameReceived: {},
+	EventWebSocketFrameSent:     {},
+}
+
+// webSocketOpcodeBinary is the opcode of the binary frames,
+// whose payload the browser sends as base64.
+const webSocketOpcodeBinary = 2
+
+// WebSocket represents a WebSocket that a page opens.
+type WebSocket struct {
+	BaseEventEmitter
+
+	ctx       context.Context
+	page      *Page
+	requestID network.RequestID
+	url       string
+	// start is the time that the WebSocket is created at,
+	// to measure the duration of its session.
+	start time.Time
+
+	closedMu sync.RWMutex
+	closed   bool
+
+	eventHandlers eventHandlers
+
+	logger *log.Logger
+}
+
+// NewWebSocket creates a new WebSocket of the page.
+func NewWebSocket(ctx context.Context, p *Page, id network.RequestID, url string, l *log.Logger) *WebSocket {
+	return &WebSocket{
+		BaseEventEmitter: NewBaseEventEmitter(ctx),
+		ctx:              ctx,
+		page:             p,
+		requestID:        id,
+		url:              url,
+		start:            time.Now(),
+		logger:           l,
+	}
+}
+
+// emit emits the event to the listeners of the WebSocket, and queues the
+// handlers registered with On to run on the VU event loop. The WebSocket
+// is handed to JS by the websocket event of the page, whose handlers run on
+// the same task queue. The handlers are looked up when the task runs, so the
+// handlers registered in the websocket event handlers are called with the
+// events that the WebSocket emits before they run, such as a quick close.
+func (ws *WebSocket) emit(event string, data any) {
+	ws.BaseEventEmitter.emit(event, data)
+	ws.eventHandlers.dispatchDeferred(ws.page.queueTask, event, data)
+}
+
+// onFrame emits the frame that the WebSocket sends or receives,
+// and returns it.
+func (ws *WebSocket) onFrame(event string, frame *network.WebSocketFrame) (*api.WebSocketFrame, error) {
+	f := api.WebSocketFrame{
+		Payload: []byte(frame.PayloadData),
+		Binary:  frame.Opcode == webSocketOpcodeBinary,
+	}
+	if f.Binary {
+		payload, err := base64.StdEncoding.DecodeString(frame.PayloadData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding WebSocket frame: %w", err)
+		}
+		f.Payload = payload
+	}
+	ws.emit(event, &f)
+
+	return &f, nil
+}
+
+func (ws *WebSocket) onError(errorMessage string) {
+	ws.logger.Debugf("WebSocket:onError", "url:%q err:%s", ws.url, errorMessage)
+
+	ws.emit(EventWebSocketError, errorMessage)
+}
+
+func (ws *WebSocket) onClose() {
+	ws.logger.Debugf("WebSocket:onClose", "url:%q", ws.url)
+
+	ws.closedMu.Lock()
+	ws.closed = true
+	ws.closedMu.Unlock()
+
+	ws.emit(EventWebSocketClose, ws)
+}
+
+// IsClosed returns true if the WebSocket is closed.
+func (ws *WebSocket) IsClosed() bool {
+	ws.closedMu.RLock()
+	defer ws.closedMu.RUnlock()
+
+	return ws.closed
+}
+
+// On registers a handler that is called on the VU event loop each time
+// the WebSocket emits the event.
+func (ws *WebSocket) On(event string, handler func(any) error) error {
+	ws.logger.Debugf("WebSocket:On", "url:%q event:%q", ws.url, event)
+
+	if _, ok := webSocketEvents[event]; !ok {
+		return fmt.Errorf("unknown WebSocket event: %q", event)
+	}
+	ws.page.getTaskQueue()
+	ws.eventHandlers.add(event, nil, handler)
+
+	return nil
+}
+
+// URL returns the URL of the WebSocket.
+func (ws *WebSocket) URL() string {
+	return ws.url
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/log"
+)
+
+func TestWebSocket(t *testing.T) {
+	t.Parallel()
+
+	// the page doesn't have a task queue to run the event handlers on.
+	p := &Page{browserCtx: &BrowserContext{}}
+	ws := NewWebSocket(context.Background(), p, "1", "ws://host/ws", log.NewNullLogger())
+	assert.Equal(t, "ws://host/ws", ws.URL())
+	assert.False(t, ws.IsClosed())
+
+	f, err := ws.onFrame(EventWebSocketFrameSent, &network.WebSocketFrame{Opcode: 1, PayloadData: "hello"})
+	require.NoError(t, err)
+	assert.Equal(t, &api.WebSocketFrame{Payload: []byte("hello")}, f)
+
+	f, err = ws.onFrame(EventWebSocketFrameReceived, &network.WebSocketFrame{Opcode: 2, PayloadData: "AAEC"})
+	require.NoError(t, err)
+	assert.Equal(t, &api.WebSocketFrame{Payload: []byte{0, 1, 2}, Binary: true}, f)
+
+	_, err = ws.onFrame(EventWebSocketFrameReceived, &network.WebSocketFrame{Opcode: 2, PayloadData: "!"})
+	assert.ErrorContains(t, err, "decoding WebSocket frame")
+
+	ws.onClose()
+	assert.True(t, ws.IsClosed())
+	assert.EqualError(t, ws.On("message", nil), `unknown WebSocket event: "message"`)
+}
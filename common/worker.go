@@ -2,11 +2,15 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/grafana/xk6-browser/api"
-	"github.com/grafana/xk6-browser/k6error"
+	"github.com/grafana/xk6-browser/k6ext"
+	k6log "github.com/grafana/xk6-browser/log"
 
+	"github.com/chromedp/cdproto"
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/log"
 	"github.com/chromedp/cdproto/network"
@@ -19,24 +23,46 @@ import (
 var _ EventEmitter = &Worker{}
 var _ api.Worker = &Worker{}
 
+// workerEvents are the events that can be subscribed to with Worker.On.
+var workerEvents = map[string]struct{}{ //nolint:gochecknoglobals
+	EventWorkerClose: {},
+}
+
+// Worker represents a dedicated web worker of a page.
 type Worker struct {
 	BaseEventEmitter
 
 	ctx     context.Context
 	session session
+	page    *Page
 
 	targetID target.ID
 	url      string
+
+	// execCtx is the execution context of the worker. It's created
+	// when the worker starts running, after which execCtxReady is closed.
+	execCtxMu    sync.RWMutex
+	execCtx      *ExecutionContext
+	execCtxReady chan struct{}
+
+	eventHandlers eventHandlers
+
+	logger *k6log.Logger
 }
 
-// NewWorker creates a new page viewport.
-func NewWorker(ctx context.Context, s session, id target.ID, url string) (*Worker, error) {
+// NewWorker creates a new web worker of the page.
+func NewWorker(
+	ctx context.Context, s session, p *Page, id target.ID, url string, l *k6log.Logger,
+) (*Worker, error) {
 	w := Worker{
 		BaseEventEmitter: NewBaseEventEmitter(ctx),
 		ctx:              ctx,
 		session:          s,
+		page:             p,
 		targetID:         id,
 		url:              url,
+		execCtxReady:     make(chan struct{}),
+		logger:           l,
 	}
 	if err := w.initEvents(); err != nil {
 		return nil, err
@@ -46,13 +72,40 @@ func NewWorker(ctx context.Context, s session, id target.ID, url string) (*Worke
 }
 
 func (w *Worker) didClose() {
+	w.logger.Debugf("Worker:didClose", "sid:%v tid:%v", w.session.ID(), w.targetID)
+
 	w.emit(EventWorkerClose, w)
 }
 
+func (w *Worker) emit(event string, data any) {
+	w.BaseEventEmitter.emit(event, data)
+	w.eventHandlers.dispatch(w.page.queueTask, event, data)
+}
+
 func (w *Worker) initEvents() error {
+	// The execution context of the worker is created once the runtime
+	// is enabled, so the event must be subscribed to before that.
+	ch := make(chan Event)
+	w.session.on(w.ctx, []string{cdproto.EventRuntimeExecutionContextCreated}, ch)
+	go func() {
+		for {
+			select {
+			case <-w.session.Done():
+				return
+			case <-w.ctx.Done():
+				return
+			case event := <-ch:
+				if ev, ok := event.data.(*runtime.EventExecutionContextCreated); ok {
+					w.onExecutionContextCreated(ev)
+				}
+			}
+		}
+	}()
+
 	actions := []Action{
 		log.Enable(),
 		network.Enable(),
+		runtime.Enable(),
 		runtime.RunIfWaitingForDebugger(),
 	}
 	for _, action := range actions {
@@ -63,16 +116,82 @@ func (w *Worker) initEvents() error {
 	return nil
 }
 
+func (w *Worker) onExecutionContextCreated(event *runtime.EventExecutionContextCreated) {
+	w.logger.Debugf("Worker:onExecutionContextCreated",
+		"sid:%v tid:%v ectxid:%d", w.session.ID(), w.targetID, event.Context.ID)
+
+	w.execCtxMu.Lock()
+	defer w.execCtxMu.Unlock()
+
+	// A worker has a single execution context.
+	if w.execCtx != nil {
+		return
+	}
+	w.execCtx = NewExecutionContext(w.ctx, w.session, nil, event.Context.ID, w.logger)
+	close(w.execCtxReady)
+}
+
+// executionContext returns the execution context of the worker
+// once the worker is running.
+func (w *Worker) executionContext() (*ExecutionContext, error) {
+	select {
+	case <-w.execCtxReady:
+	case <-w.session.Done():
+		return nil, errors.New("worker is closed")
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err() //nolint:wrapcheck
+	}
+
+	w.execCtxMu.RLock()
+	defer w.execCtxMu.RUnlock()
+
+	return w.execCtx, nil
+}
+
 // Evaluate evaluates a page function in the context of the web worker.
 func (w *Worker) Evaluate(pageFunc goja.Value, args ...goja.Value) any {
-	// TODO: implement
-	return nil
+	w.logger.Debugf("Worker:Evaluate", "sid:%v tid:%v", w.session.ID(), w.targetID)
+
+	ec, err := w.executionContext()
+	if err != nil {
+		k6ext.Panic(w.ctx, "evaluating JS in worker: %w", err)
+	}
+	result, err := ec.Eval(w.ctx, pageFunc, args...)
+	if err != nil {
+		k6ext.Panic(w.ctx, "evaluating JS in worker: %w", err)
+	}
+
+	return result
 }
 
 // EvaluateHandle evaluates a page function in the context of the web worker and returns a JS handle.
 func (w *Worker) EvaluateHandle(pageFunc goja.Value, args ...goja.Value) (api.JSHandle, error) {
-	// TODO: implement
-	return nil, fmt.Errorf("Worker.EvaluateHandle has not been implemented yet: %w", k6error.ErrFatal)
+	w.logger.Debugf("Worker:EvaluateHandle", "sid:%v tid:%v", w.session.ID(), w.targetID)
+
+	ec, err := w.executionContext()
+	if err != nil {
+		return nil, fmt.Errorf("evaluating handle in worker: %w", err)
+	}
+	h, err := ec.EvalHandle(w.ctx, pageFunc, args...)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating handle in worker: %w", err)
+	}
+
+	return h, nil
+}
+
+// On registers a handler that is called on the VU event loop each time
+// the worker emits the event.
+func (w *Worker) On(event string, handler func(any) error) error {
+	w.logger.Debugf("Worker:On", "sid:%v tid:%v event:%q", w.session.ID(), w.targetID, event)
+
+	if _, ok := workerEvents[event]; !ok {
+		return fmt.Errorf("unknown worker event: %q", event)
+	}
+	w.page.getTaskQueue()
+	w.eventHandlers.add(event, nil, handler)
+
+	return nil
 }
 
 // URL returns the URL of the web worker.
@@ -0,0 +1,51 @@
+import { check } from 'k6';
+import { browser } from 'k6/x/browser';
+import { Trend } from 'k6/metrics';
+
+export const options = {
+  scenarios: {
+    ui: {
+      executor: 'shared-iterations',
+      options: {
+        browser: {
+            type: 'chromium',
+        },
+      },
+    },
+  },
+  thresholds: {
+    checks: ["rate==1.0"]
+  }
+}
+
+const renderTime = new Trend('app_render_time', true);
+
+export default async function() {
+  const context = browser.newContext();
+  const page = context.newPage();
+
+  try {
+    // The app can call window.reportTiming(name, ms) to report its own
+    // timings, even after navigations.
+    page.exposeFunction('reportTiming', (name, ms) => {
+      renderTime.add(ms, { name });
+      return true;
+    });
+
+    await page.goto('https://test.k6.io/', { waitUntil: 'load' });
+
+    // Don't wait for the result in page.evaluate: the function is called
+    // on the event loop, which page.evaluate blocks.
+    page.evaluate(() => {
+      window.reportTiming('home', performance.now())
+        .then((ok) => window.timingReported = ok);
+    });
+    const reported = await page.waitForFunction(() => window.timingReported);
+
+    check(reported, {
+      'timing is reported': (r) => r.jsonValue() === true,
+    });
+  } finally {
+    page.close();
+  }
+}
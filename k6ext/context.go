@@ -3,6 +3,7 @@ package k6ext
 import (
 	"context"
 
+	k6common "go.k6.io/k6/js/common"
 	k6modules "go.k6.io/k6/js/modules"
 	k6lib "go.k6.io/k6/lib"
 
@@ -15,6 +16,7 @@ const (
 	ctxKeyVU ctxKey = iota
 	ctxKeyPid
 	ctxKeyCustomK6Metrics
+	ctxKeyInitEnv
 )
 
 // WithVU returns a new context based on ctx with the k6 VU instance attached.
@@ -48,6 +50,21 @@ func GetCustomMetrics(ctx context.Context) *CustomMetrics {
 	return nil
 }
 
+// WithInitEnv attaches the init environment of the VU to the context, as the
+// VU returns it only in the init context.
+func WithInitEnv(ctx context.Context, env *k6common.InitEnvironment) context.Context {
+	return context.WithValue(ctx, ctxKeyInitEnv, env)
+}
+
+// GetInitEnv returns the init environment attached to the context.
+func GetInitEnv(ctx context.Context) *k6common.InitEnvironment {
+	v := ctx.Value(ctxKeyInitEnv)
+	if env, ok := v.(*k6common.InitEnvironment); ok {
+		return env
+	}
+	return nil
+}
+
 // Runtime is a convenience function for getting a k6 VU runtime.
 func Runtime(ctx context.Context) *goja.Runtime {
 	return GetVU(ctx).Runtime()
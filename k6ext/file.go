@@ -0,0 +1,34 @@
+package k6ext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	k6fsext "go.k6.io/k6/lib/fsext"
+)
+
+// ReadFile reads the file at path from the file system of the init
+// environment attached to ctx. It's the file system that the files opened
+// in the init context, e.g. with open(), are read from, so it also works
+// when the test runs from an archive. Relative paths are relative to the
+// script, as they are for open().
+func ReadFile(ctx context.Context, path string) ([]byte, error) {
+	env := GetInitEnv(ctx)
+	if env == nil {
+		return nil, errors.New("init environment is not available")
+	}
+	fs, ok := env.FileSystems["file"]
+	if !ok {
+		return nil, errors.New("file system is not available")
+	}
+	if path == "" {
+		return nil, errors.New("empty file path")
+	}
+	data, err := k6fsext.ReadFile(fs, env.GetAbsFilePath(path))
+	if err != nil {
+		return nil, fmt.Errorf("reading file %q: %w", path, err)
+	}
+
+	return data, nil
+}
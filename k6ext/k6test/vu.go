@@ -1,6 +1,8 @@
 package k6test
 
 import (
+	"net/url"
+	"os"
 	"testing"
 
 	"github.com/dop251/goja"
@@ -14,6 +16,7 @@ import (
 	k6modulestest "go.k6.io/k6/js/modulestest"
 	k6lib "go.k6.io/k6/lib"
 	k6executor "go.k6.io/k6/lib/executor"
+	k6fsext "go.k6.io/k6/lib/fsext"
 	k6testutils "go.k6.io/k6/lib/testutils"
 	k6metrics "go.k6.io/k6/metrics"
 )
@@ -84,6 +87,12 @@ func NewVU(tb testing.TB, opts ...any) *VU {
 
 	testRT := k6modulestest.NewRuntime(tb)
 	testRT.VU.InitEnvField.LookupEnv = lookupFunc
+	// the files are read from the working directory, as k6 does for a script
+	// in it.
+	cwd, err := os.Getwd()
+	require.NoError(tb, err)
+	testRT.VU.InitEnvField.FileSystems = map[string]k6fsext.Fs{"file": k6fsext.NewOsFs()}
+	testRT.VU.InitEnvField.CWD = &url.URL{Scheme: "file", Path: cwd}
 
 	tags := testRT.VU.InitEnvField.Registry.RootTagSet()
 
@@ -117,6 +126,7 @@ func NewVU(tb testing.TB, opts ...any) *VU {
 	}
 
 	ctx := k6ext.WithVU(testRT.VU.CtxField, testRT.VU)
+	ctx = k6ext.WithInitEnv(ctx, testRT.VU.InitEnvField)
 	ctx = k6lib.WithScenarioState(ctx, &k6lib.ScenarioState{Name: "default"})
 	testRT.VU.CtxField = ctx
 
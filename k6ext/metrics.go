@@ -23,6 +23,16 @@ const (
 	browserDataReceivedName    = "browser_data_received"
 	browserHTTPReqDurationName = "browser_http_req_duration"
 	browserHTTPReqFailedName   = "browser_http_req_failed"
+	browserConsoleErrorsName   = "browser_console_errors"
+	browserPageErrorsName      = "browser_page_errors"
+	browserWSSessionsName      = "browser_ws_sessions"
+	browserWSSessionDurName    = "browser_ws_session_duration"
+	browserWSMsgsSentName      = "browser_ws_msgs_sent"
+	browserWSMsgsReceivedName  = "browser_ws_msgs_received"
+	browserWSDataSentName      = "browser_ws_data_sent"
+	browserWSDataReceivedName  = "browser_ws_data_received"
+	browserDownloadSizeName    = "browser_download_size"
+	browserDownloadDurName     = "browser_download_duration"
 )
 
 // CustomMetrics are the custom k6 metrics used by xk6-browser.
@@ -33,6 +43,19 @@ type CustomMetrics struct {
 	BrowserDataReceived    *k6metrics.Metric
 	BrowserHTTPReqDuration *k6metrics.Metric
 	BrowserHTTPReqFailed   *k6metrics.Metric
+
+	BrowserConsoleErrors *k6metrics.Metric
+	BrowserPageErrors    *k6metrics.Metric
+
+	BrowserWSSessions        *k6metrics.Metric
+	BrowserWSSessionDuration *k6metrics.Metric
+	BrowserWSMsgsSent        *k6metrics.Metric
+	BrowserWSMsgsReceived    *k6metrics.Metric
+	BrowserWSDataSent        *k6metrics.Metric
+	BrowserWSDataReceived    *k6metrics.Metric
+
+	BrowserDownloadSize     *k6metrics.Metric
+	BrowserDownloadDuration *k6metrics.Metric
 }
 
 // RegisterCustomMetrics creates and registers our custom metrics with the k6
@@ -66,5 +89,18 @@ func RegisterCustomMetrics(registry *k6metrics.Registry) *CustomMetrics {
 		BrowserDataReceived:    registry.MustNewMetric(browserDataReceivedName, k6metrics.Counter, k6metrics.Data),
 		BrowserHTTPReqDuration: registry.MustNewMetric(browserHTTPReqDurationName, k6metrics.Trend, k6metrics.Time),
 		BrowserHTTPReqFailed:   registry.MustNewMetric(browserHTTPReqFailedName, k6metrics.Rate),
+
+		BrowserConsoleErrors: registry.MustNewMetric(browserConsoleErrorsName, k6metrics.Counter),
+		BrowserPageErrors:    registry.MustNewMetric(browserPageErrorsName, k6metrics.Counter),
+
+		BrowserWSSessions:        registry.MustNewMetric(browserWSSessionsName, k6metrics.Counter),
+		BrowserWSSessionDuration: registry.MustNewMetric(browserWSSessionDurName, k6metrics.Trend, k6metrics.Time),
+		BrowserWSMsgsSent:        registry.MustNewMetric(browserWSMsgsSentName, k6metrics.Counter),
+		BrowserWSMsgsReceived:    registry.MustNewMetric(browserWSMsgsReceivedName, k6metrics.Counter),
+		BrowserWSDataSent:        registry.MustNewMetric(browserWSDataSentName, k6metrics.Counter, k6metrics.Data),
+		BrowserWSDataReceived:    registry.MustNewMetric(browserWSDataReceivedName, k6metrics.Counter, k6metrics.Data),
+
+		BrowserDownloadSize:     registry.MustNewMetric(browserDownloadSizeName, k6metrics.Trend, k6metrics.Data),
+		BrowserDownloadDuration: registry.MustNewMetric(browserDownloadDurName, k6metrics.Trend, k6metrics.Time),
 	}
 }
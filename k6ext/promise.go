@@ -23,15 +23,23 @@ type PromisifiedFunc func() (result any, reason error)
 //     first result value fn returns.
 //   - Otherwise, rejects the promise with the error fn returns.
 func Promise(ctx context.Context, fn PromisifiedFunc) *goja.Promise {
-	return promise(ctx, fn, continueEventLoop)
+	return promise(ctx, fn, nil, continueEventLoop)
+}
+
+// MappedPromise is like Promise, but it resolves the promise with the
+// value that mapFn returns for the result value of fn. mapFn is called
+// on the VU event loop, so it can use the runtime, e.g. to map a Go
+// value to a JS object.
+func MappedPromise(ctx context.Context, fn PromisifiedFunc, mapFn func(any) any) *goja.Promise {
+	return promise(ctx, fn, mapFn, continueEventLoop)
 }
 
 // AbortingPromise is like Promise, but it aborts the event loop if an error occurs.
 func AbortingPromise(ctx context.Context, fn PromisifiedFunc) *goja.Promise {
-	return promise(ctx, fn, abortEventLoop)
+	return promise(ctx, fn, nil, abortEventLoop)
 }
 
-func promise(ctx context.Context, fn PromisifiedFunc, d eventLoopDirective) *goja.Promise {
+func promise(ctx context.Context, fn PromisifiedFunc, mapFn func(any) any, d eventLoopDirective) *goja.Promise {
 	var (
 		vu                 = GetVU(ctx)
 		cb                 = vu.RegisterCallback()
@@ -40,9 +48,12 @@ func promise(ctx context.Context, fn PromisifiedFunc, d eventLoopDirective) *goj
 	go func() {
 		v, err := fn()
 		cb(func() error {
-			if err != nil {
+			switch {
+			case err != nil:
 				reject(err)
-			} else {
+			case mapFn != nil:
+				resolve(mapFn(v))
+			default:
 				resolve(v)
 			}
 			if d == continueEventLoop {
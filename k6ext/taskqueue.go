@@ -0,0 +1,92 @@
+package k6ext
+
+import (
+	"sync"
+)
+
+// TaskQueue runs tasks queued from any goroutine on the VU event loop.
+//
+// The VU event loop requires the registration of a callback to happen on
+// the main runtime thread, while the callback itself can be enqueued from
+// any goroutine. TaskQueue keeps exactly one callback registered at a time
+// and re-registers a new one each time it runs the queued tasks on the
+// main runtime thread.
+//
+// A registered callback keeps the event loop (and the iteration) alive.
+// Close must be called when no more tasks are expected to be queued,
+// otherwise the iteration won't finish until the VU stops.
+type TaskQueue struct {
+	mu               sync.Mutex
+	registerCallback func() func(func() error)
+	enqueueCallback  func(func() error)
+	tasks            []func() error
+	closed           bool
+}
+
+// NewTaskQueue returns a new TaskQueue that registers callbacks with the
+// given function, i.e. the RegisterCallback method of a k6 VU.
+//
+// It must be called from the main runtime thread.
+func NewTaskQueue(registerCallback func() func(func() error)) *TaskQueue {
+	return &TaskQueue{
+		registerCallback: registerCallback,
+		enqueueCallback:  registerCallback(),
+	}
+}
+
+// Queue queues the task to be run on the VU event loop.
+// It can be called from any goroutine and does nothing if the queue
+// is closed.
+func (tq *TaskQueue) Queue(task func() error) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+
+	if tq.closed {
+		return
+	}
+	tq.tasks = append(tq.tasks, task)
+	if tq.enqueueCallback == nil {
+		// the tasks will be run by the already enqueued callback.
+		return
+	}
+	tq.enqueueCallback(tq.run)
+	tq.enqueueCallback = nil
+}
+
+// run runs the queued tasks on the main runtime thread and registers
+// a new callback for the subsequent tasks unless the queue is closed.
+func (tq *TaskQueue) run() error {
+	tq.mu.Lock()
+	tasks := tq.tasks
+	tq.tasks = nil
+	if !tq.closed {
+		tq.enqueueCallback = tq.registerCallback()
+	}
+	tq.mu.Unlock()
+
+	for _, task := range tasks {
+		if err := task(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the queue and releases the registered callback so that the
+// event loop can finish. The tasks that are already queued are still run.
+// It can be called from any goroutine, and more than once.
+func (tq *TaskQueue) Close() {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+
+	if tq.closed {
+		return
+	}
+	tq.closed = true
+	if tq.enqueueCallback == nil {
+		return
+	}
+	tq.enqueueCallback(func() error { return nil })
+	tq.enqueueCallback = nil
+}
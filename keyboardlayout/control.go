@@ -0,0 +1,104 @@
+package keyboardlayout
+
+// controlKeys returns the definitions of the keys that are the same on
+// all the layouts: the function, control, navigation and numpad keys.
+func controlKeys() map[KeyInput]KeyDefinition {
+	return map[KeyInput]KeyDefinition{
+		// Functions row
+		"Escape": {Code: "Escape", KeyCode: 27, Key: "Escape"},
+		"F1":     {Code: "F1", KeyCode: 112, Key: "F1"},
+		"F2":     {Code: "F2", KeyCode: 113, Key: "F2"},
+		"F3":     {Code: "F3", KeyCode: 114, Key: "F3"},
+		"F4":     {Code: "F4", KeyCode: 115, Key: "F4"},
+		"F5":     {Code: "F5", KeyCode: 116, Key: "F5"},
+		"F6":     {Code: "F6", KeyCode: 117, Key: "F6"},
+		"F7":     {Code: "F7", KeyCode: 118, Key: "F7"},
+		"F8":     {Code: "F8", KeyCode: 119, Key: "F8"},
+		"F9":     {Code: "F9", KeyCode: 120, Key: "F9"},
+		"F10":    {Code: "F10", KeyCode: 121, Key: "F10"},
+		"F11":    {Code: "F11", KeyCode: 122, Key: "F11"},
+		"F12":    {Code: "F12", KeyCode: 123, Key: "F12"},
+
+		"Backspace": {Code: "Backspace", KeyCode: 8, Key: "Backspace"},
+		"Tab":       {Code: "Tab", KeyCode: 9, Key: "Tab"},
+		"CapsLock":  {Code: "CapsLock", KeyCode: 20, Key: "CapsLock"},
+		"Enter":     {Code: "Enter", KeyCode: 13, Key: "Enter", Text: "\r"},
+		"\r":        {Code: "Enter", KeyCode: 13, Key: "Enter", Text: "\r"},
+		"\n":        {Code: "Enter", KeyCode: 13, Key: "Enter", Text: "\r"},
+
+		// Modifiers and the last row
+		"ShiftLeft":    {Code: "ShiftLeft", KeyCode: 160, KeyCodeWithoutLocation: 16, Key: "Shift", Location: 1},
+		"ShiftRight":   {Code: "ShiftRight", KeyCode: 161, KeyCodeWithoutLocation: 16, Key: "Shift", Location: 2},
+		"ControlLeft":  {Code: "ControlLeft", KeyCode: 162, KeyCodeWithoutLocation: 17, Key: "Control", Location: 1},
+		"MetaLeft":     {Code: "MetaLeft", KeyCode: 91, Key: "Meta", Location: 1},
+		"AltLeft":      {Code: "AltLeft", KeyCode: 164, KeyCodeWithoutLocation: 18, Key: "Alt", Location: 1},
+		"Space":        {Code: "Space", KeyCode: 32, Key: " "},
+		"AltRight":     {Code: "AltRight", KeyCode: 165, KeyCodeWithoutLocation: 18, Key: "Alt", Location: 2},
+		"AltGraph":     {Code: "AltGraph", KeyCode: 225, Key: "AltGraph"},
+		"MetaRight":    {Code: "MetaRight", KeyCode: 92, Key: "Meta", Location: 2},
+		"ConTextMenu":  {Code: "ConTextMenu", KeyCode: 93, Key: "ConTextMenu"},
+		"ControlRight": {Code: "ControlRight", KeyCode: 163, KeyCodeWithoutLocation: 17, Key: "Control", Location: 2},
+
+		// Center block
+		"PrintScreen": {Code: "PrintScreen", KeyCode: 44, Key: "PrintScreen"},
+		"ScrollLock":  {Code: "ScrollLock", KeyCode: 145, Key: "ScrollLock"},
+		"Pause":       {Code: "Pause", KeyCode: 19, Key: "Pause"},
+
+		"PageUp":   {Code: "PageUp", KeyCode: 33, Key: "PageUp"},
+		"PageDown": {Code: "PageDown", KeyCode: 34, Key: "PageDown"},
+		"Insert":   {Code: "Insert", KeyCode: 45, Key: "Insert"},
+		"Delete":   {Code: "Delete", KeyCode: 46, Key: "Delete"},
+		"Home":     {Code: "Home", KeyCode: 36, Key: "Home"},
+		"End":      {Code: "End", KeyCode: 35, Key: "End"},
+
+		"ArrowLeft":  {Code: "ArrowLeft", KeyCode: 37, Key: "ArrowLeft"},
+		"ArrowUp":    {Code: "ArrowUp", KeyCode: 38, Key: "ArrowUp"},
+		"ArrowRight": {Code: "ArrowRight", KeyCode: 39, Key: "ArrowRight"},
+		"ArrowDown":  {Code: "ArrowDown", KeyCode: 40, Key: "ArrowDown"},
+
+		// Numpad
+		"NumLock":        {Code: "NumLock", KeyCode: 144, Key: "NumLock"},
+		"NumpadDivide":   {Code: "NumpadDivide", KeyCode: 111, Key: "/", Location: 3},
+		"NumpadMultiply": {Code: "NumpadMultiply", KeyCode: 106, Key: "*", Location: 3},
+		"NumpadSubtract": {Code: "NumpadSubtract", KeyCode: 109, Key: "-", Location: 3},
+		"Numpad7":        {Code: "Numpad7", KeyCode: 36, ShiftKeyCode: 103, Key: "Home", ShiftKey: "7", Location: 3},
+		"Numpad8":        {Code: "Numpad8", KeyCode: 38, ShiftKeyCode: 104, Key: "ArrowUp", ShiftKey: "8", Location: 3},
+		"Numpad9":        {Code: "Numpad9", KeyCode: 33, ShiftKeyCode: 105, Key: "PageUp", ShiftKey: "9", Location: 3},
+		"Numpad4":        {Code: "Numpad4", KeyCode: 37, ShiftKeyCode: 100, Key: "ArrowLeft", ShiftKey: "4", Location: 3},
+		"Numpad5":        {Code: "Numpad5", KeyCode: 12, ShiftKeyCode: 101, Key: "Clear", ShiftKey: "5", Location: 3},
+		"Numpad6":        {Code: "Numpad6", KeyCode: 39, ShiftKeyCode: 102, Key: "ArrowRight", ShiftKey: "6", Location: 3},
+		"NumpadAdd":      {Code: "NumpadAdd", KeyCode: 107, Key: "+", Location: 3},
+		"Numpad1":        {Code: "Numpad1", KeyCode: 35, ShiftKeyCode: 97, Key: "End", ShiftKey: "1", Location: 3},
+		"Numpad2":        {Code: "Numpad2", KeyCode: 40, ShiftKeyCode: 98, Key: "ArrowDown", ShiftKey: "2", Location: 3},
+		"Numpad3":        {Code: "Numpad3", KeyCode: 34, ShiftKeyCode: 99, Key: "PageDown", ShiftKey: "3", Location: 3},
+		"Numpad0":        {Code: "Numpad0", KeyCode: 45, ShiftKeyCode: 96, Key: "Insert", ShiftKey: "0", Location: 3},
+		"NumpadDecimal":  {Code: "NumpadDecimal", KeyCode: 46, ShiftKeyCode: 110, Key: "\u0000", ShiftKey: ".", Location: 3},
+		"NumpadEnter":    {Code: "NumpadEnter", KeyCode: 13, Key: "Enter", Text: "\r", Location: 3},
+	}
+}
+
+// withKeys returns the control keys with the character keys of a layout.
+func withKeys(keys map[KeyInput]KeyDefinition) map[KeyInput]KeyDefinition {
+	all := controlKeys()
+	for k, d := range keys {
+		all[k] = d
+	}
+	return all
+}
+
+// validKeysOf returns the keys that can be pressed on a layout with the
+// key definitions: their codes, and the keys they produce with and
+// without shift.
+func validKeysOf(keys map[KeyInput]KeyDefinition) map[KeyInput]bool {
+	valid := make(map[KeyInput]bool, len(keys)*2)
+	for k, d := range keys {
+		valid[k] = true
+		if d.Key != "" {
+			valid[KeyInput(d.Key)] = true
+		}
+		if d.ShiftKey != "" {
+			valid[KeyInput(d.ShiftKey)] = true
+		}
+	}
+	return valid
+}
@@ -0,0 +1,66 @@
+package keyboardlayout
+
+// initFR registers the French AZERTY layout, on which the digits are
+// typed with shift. The characters that are only typed with AltGraph,
+// e.g. @ and €, aren't on the layout.
+func initFR() {
+	keys := withKeys(map[KeyInput]KeyDefinition{
+		// Numbers row
+		"Backquote": {Code: "Backquote", KeyCode: 222, Key: "²"},
+		"Digit1":    {Code: "Digit1", KeyCode: 49, ShiftKey: "1", Key: "&"},
+		"Digit2":    {Code: "Digit2", KeyCode: 50, ShiftKey: "2", Key: "é"},
+		"Digit3":    {Code: "Digit3", KeyCode: 51, ShiftKey: "3", Key: "\""},
+		"Digit4":    {Code: "Digit4", KeyCode: 52, ShiftKey: "4", Key: "'"},
+		"Digit5":    {Code: "Digit5", KeyCode: 53, ShiftKey: "5", Key: "("},
+		"Digit6":    {Code: "Digit6", KeyCode: 54, ShiftKey: "6", Key: "-"},
+		"Digit7":    {Code: "Digit7", KeyCode: 55, ShiftKey: "7", Key: "è"},
+		"Digit8":    {Code: "Digit8", KeyCode: 56, ShiftKey: "8", Key: "_"},
+		"Digit9":    {Code: "Digit9", KeyCode: 57, ShiftKey: "9", Key: "ç"},
+		"Digit0":    {Code: "Digit0", KeyCode: 48, ShiftKey: "0", Key: "à"},
+		"Minus":     {Code: "Minus", KeyCode: 219, ShiftKey: "°", Key: ")"},
+		"Equal":     {Code: "Equal", KeyCode: 187, ShiftKey: "+", Key: "="},
+
+		// First row
+		"KeyQ":         {Code: "KeyQ", KeyCode: 65, ShiftKey: "A", Key: "a"},
+		"KeyW":         {Code: "KeyW", KeyCode: 90, ShiftKey: "Z", Key: "z"},
+		"KeyE":         {Code: "KeyE", KeyCode: 69, ShiftKey: "E", Key: "e"},
+		"KeyR":         {Code: "KeyR", KeyCode: 82, ShiftKey: "R", Key: "r"},
+		"KeyT":         {Code: "KeyT", KeyCode: 84, ShiftKey: "T", Key: "t"},
+		"KeyY":         {Code: "KeyY", KeyCode: 89, ShiftKey: "Y", Key: "y"},
+		"KeyU":         {Code: "KeyU", KeyCode: 85, ShiftKey: "U", Key: "u"},
+		"KeyI":         {Code: "KeyI", KeyCode: 73, ShiftKey: "I", Key: "i"},
+		"KeyO":         {Code: "KeyO", KeyCode: 79, ShiftKey: "O", Key: "o"},
+		"KeyP":         {Code: "KeyP", KeyCode: 80, ShiftKey: "P", Key: "p"},
+		"BracketLeft":  {Code: "BracketLeft", KeyCode: 221, ShiftKey: "¨", Key: "^"},
+		"BracketRight": {Code: "BracketRight", KeyCode: 186, ShiftKey: "£", Key: "$"},
+
+		// Second row
+		"KeyA":      {Code: "KeyA", KeyCode: 81, ShiftKey: "Q", Key: "q"},
+		"KeyS":      {Code: "KeyS", KeyCode: 83, ShiftKey: "S", Key: "s"},
+		"KeyD":      {Code: "KeyD", KeyCode: 68, ShiftKey: "D", Key: "d"},
+		"KeyF":      {Code: "KeyF", KeyCode: 70, ShiftKey: "F", Key: "f"},
+		"KeyG":      {Code: "KeyG", KeyCode: 71, ShiftKey: "G", Key: "g"},
+		"KeyH":      {Code: "KeyH", KeyCode: 72, ShiftKey: "H", Key: "h"},
+		"KeyJ":      {Code: "KeyJ", KeyCode: 74, ShiftKey: "J", Key: "j"},
+		"KeyK":      {Code: "KeyK", KeyCode: 75, ShiftKey: "K", Key: "k"},
+		"KeyL":      {Code: "KeyL", KeyCode: 76, ShiftKey: "L", Key: "l"},
+		"Semicolon": {Code: "Semicolon", KeyCode: 77, ShiftKey: "M", Key: "m"},
+		"Quote":     {Code: "Quote", KeyCode: 192, ShiftKey: "%", Key: "ù"},
+		"Backslash": {Code: "Backslash", KeyCode: 220, ShiftKey: "µ", Key: "*"},
+
+		// Third row
+		"IntlBackslash": {Code: "IntlBackslash", KeyCode: 226, ShiftKey: ">", Key: "<"},
+		"KeyZ":          {Code: "KeyZ", KeyCode: 87, ShiftKey: "W", Key: "w"},
+		"KeyX":          {Code: "KeyX", KeyCode: 88, ShiftKey: "X", Key: "x"},
+		"KeyC":          {Code: "KeyC", KeyCode: 67, ShiftKey: "C", Key: "c"},
+		"KeyV":          {Code: "KeyV", KeyCode: 86, ShiftKey: "V", Key: "v"},
+		"KeyB":          {Code: "KeyB", KeyCode: 66, ShiftKey: "B", Key: "b"},
+		"KeyN":          {Code: "KeyN", KeyCode: 78, ShiftKey: "N", Key: "n"},
+		"KeyM":          {Code: "KeyM", KeyCode: 188, ShiftKey: "?", Key: ","},
+		"Comma":         {Code: "Comma", KeyCode: 190, ShiftKey: ".", Key: ";"},
+		"Period":        {Code: "Period", KeyCode: 191, ShiftKey: "/", Key: ":"},
+		"Slash":         {Code: "Slash", KeyCode: 223, ShiftKey: "§", Key: "!"},
+	})
+
+	register("fr", validKeysOf(keys), keys)
+}
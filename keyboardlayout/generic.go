@@ -0,0 +1,10 @@
+package keyboardlayout
+
+// initGeneric registers a layout without character keys for typing
+// text in any script, e.g. non-Latin text. All the characters are
+// inserted as text, while the control keys can still be pressed.
+func initGeneric() {
+	keys := controlKeys()
+
+	register("generic", validKeysOf(keys), keys)
+}
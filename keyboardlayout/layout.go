@@ -25,24 +25,38 @@ type KeyboardLayout struct {
 
 // KeyDefinition returns true with the key definition of a given key input.
 // It returns false and an empty key definition if it cannot find the key.
+// The keys of the main block are preferred to the numpad keys.
 func (kl KeyboardLayout) KeyDefinition(key KeyInput) (KeyDefinition, bool) {
-	for _, d := range kl.Keys {
-		if d.Key == string(key) {
-			return d, true
-		}
-	}
-	return KeyDefinition{}, false
+	return kl.find(func(d KeyDefinition) bool { return d.Key == string(key) })
 }
 
 // ShiftKeyDefinition returns shift key definition of a given key input.
 // It returns an empty key definition if it cannot find the key.
+// The keys of the main block are preferred to the numpad keys.
 func (kl KeyboardLayout) ShiftKeyDefinition(key KeyInput) KeyDefinition {
+	d, _ := kl.find(func(d KeyDefinition) bool { return d.ShiftKey == string(key) })
+	return d
+}
+
+// find returns the key definition that matches, as several keys can produce
+// the same key input. It prefers the keys without a location, then the lowest
+// location, e.g. the left shift to the right one, and then the lowest code, so
+// that the lookup does not depend on the iteration order of the keys.
+func (kl KeyboardLayout) find(match func(KeyDefinition) bool) (KeyDefinition, bool) {
+	var (
+		found KeyDefinition
+		ok    bool
+	)
 	for _, d := range kl.Keys {
-		if d.ShiftKey == string(key) {
-			return d
+		if !match(d) {
+			continue
+		}
+		if !ok || d.Location < found.Location ||
+			(d.Location == found.Location && d.Code < found.Code) {
+			found, ok = d, true
 		}
 	}
-	return KeyDefinition{}
+	return found, ok
 }
 
 //nolint:gochecknoglobals
@@ -58,8 +72,20 @@ func GetKeyboardLayout(name string) KeyboardLayout {
 	return kbdLayouts[name]
 }
 
+// Exists returns true if a keyboard layout is registered with name.
+func Exists(name string) bool {
+	mx.RLock()
+	defer mx.RUnlock()
+	_, ok := kbdLayouts[name]
+	return ok
+}
+
 func init() {
 	initUS()
+	initDE()
+	initFR()
+	initUK()
+	initGeneric()
 }
 
 // Register the given keyboard layout.
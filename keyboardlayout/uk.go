@@ -0,0 +1,64 @@
+package keyboardlayout
+
+// initUK registers the United Kingdom QWERTY layout.
+func initUK() {
+	keys := withKeys(map[KeyInput]KeyDefinition{
+		// Numbers row
+		"Backquote": {Code: "Backquote", KeyCode: 223, ShiftKey: "¬", Key: "`"},
+		"Digit1":    {Code: "Digit1", KeyCode: 49, ShiftKey: "!", Key: "1"},
+		"Digit2":    {Code: "Digit2", KeyCode: 50, ShiftKey: "\"", Key: "2"},
+		"Digit3":    {Code: "Digit3", KeyCode: 51, ShiftKey: "£", Key: "3"},
+		"Digit4":    {Code: "Digit4", KeyCode: 52, ShiftKey: "$", Key: "4"},
+		"Digit5":    {Code: "Digit5", KeyCode: 53, ShiftKey: "%", Key: "5"},
+		"Digit6":    {Code: "Digit6", KeyCode: 54, ShiftKey: "^", Key: "6"},
+		"Digit7":    {Code: "Digit7", KeyCode: 55, ShiftKey: "&", Key: "7"},
+		"Digit8":    {Code: "Digit8", KeyCode: 56, ShiftKey: "*", Key: "8"},
+		"Digit9":    {Code: "Digit9", KeyCode: 57, ShiftKey: "(", Key: "9"},
+		"Digit0":    {Code: "Digit0", KeyCode: 48, ShiftKey: ")", Key: "0"},
+		"Minus":     {Code: "Minus", KeyCode: 189, ShiftKey: "_", Key: "-"},
+		"Equal":     {Code: "Equal", KeyCode: 187, ShiftKey: "+", Key: "="},
+
+		// First row
+		"KeyQ":         {Code: "KeyQ", KeyCode: 81, ShiftKey: "Q", Key: "q"},
+		"KeyW":         {Code: "KeyW", KeyCode: 87, ShiftKey: "W", Key: "w"},
+		"KeyE":         {Code: "KeyE", KeyCode: 69, ShiftKey: "E", Key: "e"},
+		"KeyR":         {Code: "KeyR", KeyCode: 82, ShiftKey: "R", Key: "r"},
+		"KeyT":         {Code: "KeyT", KeyCode: 84, ShiftKey: "T", Key: "t"},
+		"KeyY":         {Code: "KeyY", KeyCode: 89, ShiftKey: "Y", Key: "y"},
+		"KeyU":         {Code: "KeyU", KeyCode: 85, ShiftKey: "U", Key: "u"},
+		"KeyI":         {Code: "KeyI", KeyCode: 73, ShiftKey: "I", Key: "i"},
+		"KeyO":         {Code: "KeyO", KeyCode: 79, ShiftKey: "O", Key: "o"},
+		"KeyP":         {Code: "KeyP", KeyCode: 80, ShiftKey: "P", Key: "p"},
+		"BracketLeft":  {Code: "BracketLeft", KeyCode: 219, ShiftKey: "{", Key: "["},
+		"BracketRight": {Code: "BracketRight", KeyCode: 221, ShiftKey: "}", Key: "]"},
+
+		// Second row
+		"KeyA":      {Code: "KeyA", KeyCode: 65, ShiftKey: "A", Key: "a"},
+		"KeyS":      {Code: "KeyS", KeyCode: 83, ShiftKey: "S", Key: "s"},
+		"KeyD":      {Code: "KeyD", KeyCode: 68, ShiftKey: "D", Key: "d"},
+		"KeyF":      {Code: "KeyF", KeyCode: 70, ShiftKey: "F", Key: "f"},
+		"KeyG":      {Code: "KeyG", KeyCode: 71, ShiftKey: "G", Key: "g"},
+		"KeyH":      {Code: "KeyH", KeyCode: 72, ShiftKey: "H", Key: "h"},
+		"KeyJ":      {Code: "KeyJ", KeyCode: 74, ShiftKey: "J", Key: "j"},
+		"KeyK":      {Code: "KeyK", KeyCode: 75, ShiftKey: "K", Key: "k"},
+		"KeyL":      {Code: "KeyL", KeyCode: 76, ShiftKey: "L", Key: "l"},
+		"Semicolon": {Code: "Semicolon", KeyCode: 186, ShiftKey: ":", Key: ";"},
+		"Quote":     {Code: "Quote", KeyCode: 192, ShiftKey: "@", Key: "'"},
+		"Backslash": {Code: "Backslash", KeyCode: 222, ShiftKey: "~", Key: "#"},
+
+		// Third row
+		"IntlBackslash": {Code: "IntlBackslash", KeyCode: 220, ShiftKey: "|", Key: "\\"},
+		"KeyZ":          {Code: "KeyZ", KeyCode: 90, ShiftKey: "Z", Key: "z"},
+		"KeyX":          {Code: "KeyX", KeyCode: 88, ShiftKey: "X", Key: "x"},
+		"KeyC":          {Code: "KeyC", KeyCode: 67, ShiftKey: "C", Key: "c"},
+		"KeyV":          {Code: "KeyV", KeyCode: 86, ShiftKey: "V", Key: "v"},
+		"KeyB":          {Code: "KeyB", KeyCode: 66, ShiftKey: "B", Key: "b"},
+		"KeyN":          {Code: "KeyN", KeyCode: 78, ShiftKey: "N", Key: "n"},
+		"KeyM":          {Code: "KeyM", KeyCode: 77, ShiftKey: "M", Key: "m"},
+		"Comma":         {Code: "Comma", KeyCode: 188, ShiftKey: "<", Key: ","},
+		"Period":        {Code: "Period", KeyCode: 190, ShiftKey: ">", Key: "."},
+		"Slash":         {Code: "Slash", KeyCode: 191, ShiftKey: "?", Key: "/"},
+	})
+
+	register("uk", validKeysOf(keys), keys)
+}
@@ -27,6 +27,7 @@ func TestBrowserContextOptionsDefaultValues(t *testing.T) {
 	assert.False(t, opts.IgnoreHTTPSErrors)
 	assert.False(t, opts.IsMobile)
 	assert.True(t, opts.JavaScriptEnabled)
+	assert.Equal(t, common.DefaultKeyboardLayout, opts.KeyboardLayout)
 	assert.Equal(t, common.DefaultLocale, opts.Locale)
 	assert.False(t, opts.Offline)
 	assert.Empty(t, opts.Permissions)
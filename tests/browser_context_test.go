@@ -2,11 +2,16 @@ package tests
 
 import (
 	"fmt"
+	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/dop251/goja"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/k6ext"
 )
 
 func TestBrowserContextAddCookies(t *testing.T) {
@@ -190,3 +195,143 @@ func TestBrowserContextAddCookies(t *testing.T) {
 		})
 	}
 }
+
+func TestBrowserContextStorageState(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/login", func(w http.ResponseWriter, _ *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true})
+		_, _ = fmt.Fprint(w, `<script>localStorage.setItem("token", "xyz")</script>`)
+	})
+	tb.withHandler("/app", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `<p>app</p>`)
+	})
+
+	bc, err := tb.NewContext(nil)
+	require.NoError(t, err)
+	p, err := bc.NewPage()
+	require.NoError(t, err)
+	_, err = p.Goto(tb.url("/login"), nil)
+	require.NoError(t, err)
+	// the local storage of the origin is exported
+	// even though no page is open at the origin.
+	require.NoError(t, p.Close(nil))
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := bc.StorageState(tb.toGojaValue(map[string]any{"path": path}))
+	require.NoError(t, err)
+	require.Len(t, state.Cookies, 1)
+	assert.Equal(t, "session", state.Cookies[0].Name)
+	assert.Equal(t, "abc", state.Cookies[0].Value)
+	assert.True(t, state.Cookies[0].HTTPOnly)
+	assert.EqualValues(t, -1, state.Cookies[0].Expires)
+	require.Len(t, state.Origins, 1)
+	assert.Equal(t, tb.http.ServerHTTP.URL, state.Origins[0].Origin)
+	assert.Equal(t, []*api.NameValue{{Name: "token", Value: "xyz"}}, state.Origins[0].LocalStorage)
+	require.FileExists(t, path)
+	bc.Close()
+
+	bc, err = tb.NewContext(tb.toGojaValue(map[string]any{"storageState": path}))
+	require.NoError(t, err)
+	p, err = bc.NewPage()
+	require.NoError(t, err)
+	_, err = p.Goto(tb.url("/app"), nil)
+	require.NoError(t, err)
+
+	token := p.Evaluate(tb.toGojaValue(`() => localStorage.getItem("token")`))
+	assert.Equal(t, "xyz", tb.asGojaValue(token).Export())
+	cookies, err := bc.StorageState(nil)
+	require.NoError(t, err)
+	require.Len(t, cookies.Cookies, 1)
+	assert.Equal(t, "abc", cookies.Cookies[0].Value)
+}
+
+func TestBrowserContextCookies(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	bc, err := tb.NewContext(nil)
+	require.NoError(t, err)
+
+	cookies, err := bc.Cookies(nil)
+	require.NoError(t, err)
+	assert.Empty(t, cookies)
+
+	toAdd, err := tb.runJavaScript(`
+		[
+			{ name: "a", value: "1", url: "https://example.com/" },
+			{ name: "b", value: "2", domain: ".example.com", path: "/api", httpOnly: true },
+			{ name: "c", value: "3", url: "https://example.org/", secure: true, sameSite: "Strict" }
+		];
+	`)
+	require.NoError(t, err)
+	bc.AddCookies(toAdd)
+
+	names := func(cookies []*api.Cookie) []string {
+		var n []string
+		for _, c := range cookies {
+			n = append(n, c.Name)
+		}
+		return n
+	}
+
+	cookies, err = bc.Cookies(nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, names(cookies))
+
+	cookies, err = bc.Cookies(tb.toGojaValue("https://example.com/"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, names(cookies))
+
+	cookies, err = bc.Cookies(tb.toGojaValue([]string{"https://www.example.com/api/users", "http://example.org/"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, names(cookies))
+	assert.True(t, cookies[0].HTTPOnly)
+	assert.Equal(t, ".example.com", cookies[0].Domain)
+	assert.Equal(t, "/api", cookies[0].Path)
+	assert.EqualValues(t, -1, cookies[0].Expires)
+
+	cookies, err = bc.Cookies(tb.toGojaValue([]string{"https://example.org/"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, names(cookies))
+	assert.True(t, cookies[0].Secure)
+	assert.Equal(t, "Strict", cookies[0].SameSite)
+}
+
+func TestBrowserContextExposeFunction(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	bc, err := tb.NewContext(nil)
+	require.NoError(t, err)
+
+	var product any
+	err = tb.vu.Loop.Start(func() error {
+		// the function is exposed to the pages created later on.
+		bc.ExposeFunction("multiply", func(_ goja.Value, args ...goja.Value) (goja.Value, error) {
+			return tb.toGojaValue(args[0].ToFloat() * args[1].ToFloat()), nil
+		})
+		p, err := bc.NewPage()
+		require.NoError(t, err)
+		assert.Panics(t, func() {
+			p.ExposeFunction("multiply", func(goja.Value, ...goja.Value) (goja.Value, error) {
+				return nil, nil
+			})
+		}, "exposing a function of the browser context in a page should panic")
+
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			product = p.Evaluate(tb.toGojaValue(`() => window.multiply(3, 4)`))
+			if err := p.Close(nil); err != nil {
+				return nil, err
+			}
+			// releases the task queue of the browser context
+			// so that the event loop can finish.
+			bc.Close()
+			return nil, nil
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, tb.asGojaValue(product).Export())
+}
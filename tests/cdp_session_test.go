@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+func TestBrowserContextNewCDPSession(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`<iframe srcdoc="<p>in process</p>"></iframe>`, nil)
+	bc := p.Context()
+
+	s, err := bc.NewCDPSession(p)
+	require.NoError(t, err)
+	// the session of the page is not a new page.
+	assert.Len(t, bc.Pages(), 1)
+
+	var result *goja.Promise
+	err = tb.vu.Loop.Start(func() error {
+		result = s.Send("Runtime.evaluate", tb.toGojaValue(map[string]any{
+			"expression":    "1 + 2",
+			"returnByValue": true,
+		}))
+		s.Send("Runtime.enable", nil)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, goja.PromiseStateFulfilled, result.State())
+	evaluated, ok := result.Result().Export().(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"type": "number", "value": float64(3), "description": "3"}, evaluated["result"])
+
+	var consoleArgs any
+	err = tb.vu.Loop.Start(func() error {
+		err := s.On("Runtime.consoleAPICalled", func(params any) error {
+			consoleArgs = params.(map[string]any)["args"] //nolint:forcetypeassert
+			return s.Detach()
+		})
+		if err != nil {
+			return err
+		}
+		s.Send("Runtime.evaluate", tb.toGojaValue(map[string]any{
+			"expression": `console.log("hello")`,
+		}))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[string]any{"type": "string", "value": "hello"}}, consoleArgs)
+
+	err = tb.vu.Loop.Start(func() error {
+		result = s.Send("Runtime.evaluate", tb.toGojaValue(map[string]any{"expression": "1"}))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, goja.PromiseStateRejected, result.State())
+	assert.Contains(t, result.Result().String(), "CDP session is detached")
+
+	// the main frame shares the target of its page.
+	_, err = bc.NewCDPSession(p.MainFrame())
+	require.NoError(t, err)
+	var child api.Frame
+	for _, f := range p.Frames() {
+		if f != p.MainFrame() {
+			child = f
+		}
+	}
+	require.NotNil(t, child)
+	_, err = bc.NewCDPSession(child)
+	assert.ErrorContains(t, err, "doesn't have its own target")
+	_, err = bc.NewCDPSession(nil)
+	assert.ErrorContains(t, err, "target must be a page or a frame")
+}
+
+func TestBrowserNewBrowserCDPSession(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	s, err := tb.NewBrowserCDPSession()
+	require.NoError(t, err)
+
+	var result *goja.Promise
+	err = tb.vu.Loop.Start(func() error {
+		result = s.Send("Browser.getVersion", nil)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, goja.PromiseStateFulfilled, result.State())
+	version, ok := result.Result().Export().(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, tb.Version(), version["product"])
+	require.NoError(t, s.Detach())
+}
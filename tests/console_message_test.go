@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+func TestPageOnConsoleAndPageError(t *testing.T) {
+	t.Parallel()
+
+	samples := make(chan k6metrics.SampleContainer, 1000)
+	tb := newTestBrowser(t, withSamples(samples))
+	p := tb.NewPage(nil)
+
+	var (
+		msg       api.ConsoleMessage
+		msgArg    any
+		pageError *api.PageError
+		done      = make(chan struct{})
+	)
+	err := tb.vu.Loop.Start(func() error {
+		require.NoError(t, p.On(common.EventPageConsole, nil, func(data any) error {
+			var ok bool
+			msg, ok = data.(api.ConsoleMessage)
+			require.Truef(t, ok, "want api.ConsoleMessage; got %T", data)
+			require.Len(t, msg.Args(), 2)
+			msgArg = msg.Args()[1].JSONValue().ToObject(tb.vu.Runtime()).Get("code").Export()
+			return nil
+		}))
+		require.NoError(t, p.On(common.EventPageError, nil, func(data any) error {
+			var ok bool
+			pageError, ok = data.(*api.PageError)
+			require.Truef(t, ok, "want *api.PageError; got %T", data)
+			close(done)
+			return nil
+		}))
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			p.Evaluate(tb.toGojaValue(`() => {
+				console.error("failed:", { code: 42 });
+				setTimeout(() => { null.f(); }, 0);
+			}`))
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				return nil, errors.New("timed out waiting for the page error")
+			}
+			return nil, p.Close(nil) //nolint:wrapcheck
+		})
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, msg)
+	assert.Equal(t, "error", msg.Type())
+	assert.Equal(t, "failed: Object", msg.Text())
+	assert.EqualValues(t, 42, msgArg)
+	assert.Equal(t, p, msg.Page())
+
+	require.NotNil(t, pageError)
+	assert.Equal(t, "TypeError", pageError.Name)
+	assert.Contains(t, pageError.Message, "Cannot read properties of null")
+	assert.Contains(t, pageError.Stack, "TypeError: Cannot read properties of null")
+
+	counts := make(map[string]float64)
+	for len(samples) > 0 {
+		for _, s := range (<-samples).GetSamples() {
+			counts[s.Metric.Name] += s.Value
+		}
+	}
+	assert.Equal(t, float64(1), counts["browser_console_errors"])
+	assert.Equal(t, float64(1), counts["browser_page_errors"])
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+func TestPageDownload(t *testing.T) {
+	t.Parallel()
+
+	download := func(t *testing.T, acceptDownloads bool) api.Download {
+		t.Helper()
+
+		tb := newTestBrowser(t, withHTTPServer())
+		tb.withHandler("/report", func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="report.csv"`)
+			_, err := fmt.Fprint(w, "id,name\n1,k6\n")
+			require.NoError(t, err)
+		})
+		bc, err := tb.NewContext(tb.toGojaValue(struct {
+			AcceptDownloads bool `js:"acceptDownloads"`
+		}{
+			AcceptDownloads: acceptDownloads,
+		}))
+		require.NoError(t, err)
+		p, err := bc.NewPage()
+		require.NoError(t, err)
+		_, err = p.Goto(tb.url("/get"), nil)
+		require.NoError(t, err)
+		p.SetContent(fmt.Sprintf(`<a href="%s">report</a>`, tb.url("/report")), nil)
+
+		var (
+			d          api.Download
+			downloaded = make(chan api.Download, 1)
+		)
+		err = tb.vu.Loop.Start(func() error {
+			require.NoError(t, p.On(common.EventPageDownload, nil, func(data any) error {
+				d, ok := data.(api.Download)
+				require.Truef(t, ok, "want api.Download; got %T", data)
+				downloaded <- d
+				return nil
+			}))
+			k6ext.Promise(tb.vu.Context(), func() (any, error) {
+				if err := p.Click("a", nil); err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				select {
+				case d = <-downloaded:
+				case <-time.After(5 * time.Second):
+					return nil, errors.New("timed out waiting for the download")
+				}
+				// wait for the download to finish before closing the page.
+				if _, err := d.Failure(); err != nil {
+					return nil, err //nolint:wrapcheck
+				}
+				return nil, p.Close(nil) //nolint:wrapcheck
+			})
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotNil(t, d)
+		assert.Equal(t, tb.url("/report"), d.URL())
+		assert.Equal(t, "report.csv", d.SuggestedFilename())
+		assert.Equal(t, p, d.Page())
+
+		return d
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		t.Parallel()
+
+		d := download(t, true)
+
+		failure, err := d.Failure()
+		require.NoError(t, err)
+		assert.Empty(t, failure)
+		path, err := d.Path()
+		require.NoError(t, err)
+		assert.FileExists(t, path)
+
+		saved := filepath.Join(t.TempDir(), "report.csv")
+		require.NoError(t, d.SaveAs(saved))
+		data, err := os.ReadFile(saved) //nolint:gosec
+		require.NoError(t, err)
+		assert.Equal(t, "id,name\n1,k6\n", string(data))
+
+		require.NoError(t, d.Delete())
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("not_accepted", func(t *testing.T) {
+		t.Parallel()
+
+		d := download(t, false)
+
+		failure, err := d.Failure()
+		require.NoError(t, err)
+		assert.Equal(t, "canceled", failure)
+		_, err = d.Path()
+		assert.ErrorContains(t, err, "download failed")
+	})
+}
@@ -406,3 +406,32 @@ func TestElementHandlePress(t *testing.T) {
 
 	require.Equal(t, "AbC", el.InputValue(nil))
 }
+
+func TestElementHandleSetInputFiles(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`<input type="file" multiple>`, nil)
+
+	el, err := p.Query("input")
+	require.NoError(t, err)
+
+	el.SetInputFiles(tb.toGojaValue([]any{
+		map[string]any{"name": "a.txt", "mimeType": "text/plain", "buffer": "hello"},
+		map[string]any{"name": "b.json", "buffer": "{}"},
+	}), nil)
+
+	files := p.Evaluate(tb.toGojaValue(`() => {
+		const files = document.querySelector('input').files;
+		return Array.from(files).map(f => f.name + ':' + f.type + ':' + f.size);
+	}`))
+	assert.Equal(t,
+		[]any{"a.txt:text/plain:5", "b.json:application/json:2"},
+		tb.asGojaValue(files).Export(),
+	)
+
+	el.SetInputFiles(tb.toGojaValue([]any{}), nil)
+	count := p.Evaluate(tb.toGojaValue(`() => document.querySelector('input').files.length`))
+	assert.EqualValues(t, 0, tb.asGojaValue(count).Export())
+}
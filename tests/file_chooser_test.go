@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+// fileChooserContent is a page that opens the file chooser of a hidden
+// input from a styled button, as the upload widgets usually do.
+const fileChooserContent = `
+	<input type="file" id="single" style="display: none">
+	<input type="file" id="multiple" style="display: none" multiple>
+	<button id="upload" onclick="document.getElementById('single').click()">Upload</button>
+	<button id="uploadAll" onclick="document.getElementById('multiple').click()">Upload all</button>
+`
+
+func TestPageOnFileChooser(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(fileChooserContent, nil)
+
+	var fc api.FileChooser
+	err := tb.vu.Loop.Start(func() error {
+		require.NoError(t, p.On(common.EventPageFilechooser, nil, func(data any) error {
+			var ok bool
+			fc, ok = data.(api.FileChooser)
+			require.Truef(t, ok, "want api.FileChooser; got %T", data)
+			fc.SetFiles(tb.toGojaValue(map[string]any{
+				"name": "a.txt", "mimeType": "text/plain", "buffer": "hello",
+			}), nil)
+			return p.Close(nil) //nolint:wrapcheck
+		}))
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			return nil, p.Click("#upload", nil) //nolint:wrapcheck
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, fc)
+	assert.False(t, fc.IsMultiple())
+	assert.Equal(t, p, fc.Page())
+}
+
+func TestPageWaitForEventFileChooser(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(fileChooserContent, nil)
+
+	var fc api.FileChooser
+	err := tb.vu.Loop.Start(func() error {
+		wait, err := p.WaitForEvent(common.EventPageFilechooser, nil)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			data, err := wait()
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+			fc, _ = data.(api.FileChooser)
+			return nil, nil
+		})
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			// let the file chooser be intercepted before opening it.
+			p.WaitForTimeout(100)
+			return nil, p.Click("#uploadAll", nil) //nolint:wrapcheck
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, fc)
+	assert.True(t, fc.IsMultiple())
+	assert.Equal(t, "multiple", fc.Element().GetAttribute("id").String())
+
+	fc.SetFiles(tb.toGojaValue([]any{
+		map[string]any{"name": "a.txt", "mimeType": "text/plain", "buffer": "hello"},
+		map[string]any{"name": "b.json", "buffer": "{}"},
+	}), nil)
+	files := p.Evaluate(tb.toGojaValue(`() => {
+		const files = document.getElementById('multiple').files;
+		return Array.from(files).map(f => f.name);
+	}`))
+	assert.Equal(t, []any{"a.txt", "b.json"}, tb.asGojaValue(files).Export())
+}
@@ -2,6 +2,8 @@ package tests
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 
@@ -144,3 +146,80 @@ func TestFrameTitle(t *testing.T) {
 	p.SetContent(`<html><head><title>Some title</title></head></html>`, nil)
 	assert.Equal(t, "Some title", p.MainFrame().Title())
 }
+
+func TestFrameAddScriptTag(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/script.js", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript")
+		_, _ = w.Write([]byte(`window.fromURL = 42;`))
+	})
+	tb.withHandler("/page", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><head></head><body></body></html>`))
+	})
+	path := filepath.Join(t.TempDir(), "script.js")
+	require.NoError(t, os.WriteFile(path, []byte(`window.fromPath = "path";`), 0o600))
+
+	p := tb.NewPage(nil)
+	_, err := p.Goto(tb.url("/page"), nil)
+	require.NoError(t, err)
+
+	eh, err := p.AddScriptTag(tb.toGojaValue(map[string]any{"url": tb.url("/script.js")}))
+	require.NoError(t, err)
+	assert.Equal(t, tb.url("/script.js"), eh.GetAttribute("src").String())
+	assert.Equal(t, int64(42), tb.asGojaValue(p.Evaluate(tb.toGojaValue(`() => window.fromURL`))).Export())
+
+	_, err = p.MainFrame().AddScriptTag(tb.toGojaValue(map[string]any{"path": path}))
+	require.NoError(t, err)
+	assert.Equal(t, "path", tb.asGojaValue(p.Evaluate(tb.toGojaValue(`() => window.fromPath`))).Export())
+
+	eh, err = p.AddScriptTag(tb.toGojaValue(map[string]any{
+		"content": `window.fromModule = import.meta !== undefined;`,
+		"type":    "module",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "module", eh.GetAttribute("type").String())
+	assert.Equal(t, true, tb.asGojaValue(p.Evaluate(tb.toGojaValue(`() => window.fromModule`))).Export())
+
+	_, err = p.AddScriptTag(tb.toGojaValue(map[string]any{"url": tb.url("/missing.js")}))
+	assert.ErrorContains(t, err, "loading script from")
+	_, err = p.AddScriptTag(nil)
+	assert.ErrorContains(t, err, "provide an object with a url, path or content property")
+}
+
+func TestFrameAddStyleTag(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/style.css", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = w.Write([]byte(`body { margin: 7px; }`))
+	})
+	tb.withHandler("/page", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><head></head><body></body></html>`))
+	})
+
+	p := tb.NewPage(nil)
+	_, err := p.Goto(tb.url("/page"), nil)
+	require.NoError(t, err)
+
+	style := func(prop string) any {
+		js := `p => getComputedStyle(document.body)[p]`
+		return tb.asGojaValue(p.Evaluate(tb.toGojaValue(js), tb.toGojaValue(prop))).Export()
+	}
+
+	eh, err := p.AddStyleTag(tb.toGojaValue(map[string]any{"url": tb.url("/style.css")}))
+	require.NoError(t, err)
+	assert.Equal(t, tb.url("/style.css"), eh.GetAttribute("href").String())
+	assert.Equal(t, "7px", style("marginTop"))
+
+	_, err = p.MainFrame().AddStyleTag(tb.toGojaValue(map[string]any{
+		"content": `body { background-color: rgb(0, 128, 0); }`,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "rgb(0, 128, 0)", style("backgroundColor"))
+
+	_, err = p.AddStyleTag(tb.toGojaValue(map[string]any{"url": tb.url("/missing.css")}))
+	assert.ErrorContains(t, err, "loading stylesheet from")
+}
@@ -195,3 +195,56 @@ func TestKeyboardPress(t *testing.T) {
 		assert.Equal(t, "Hello!", el.InputValue(nil))
 	})
 }
+
+func TestKeyboardLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		layout       string
+		text         string
+		wantKeyCodes string
+	}{
+		// @ and € are typed with AltGraph, so they're inserted as text.
+		{layout: "de", text: "Grüße @€", wantKeyCodes: "71,82,186,219,69,32"},
+		{layout: "fr", text: "été1", wantKeyCodes: "50,84,50,49"},
+		{layout: "uk", text: "£5", wantKeyCodes: "51,53"},
+		{layout: "generic", text: "Привет", wantKeyCodes: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.layout, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t)
+			bctx, err := tb.NewContext(tb.toGojaValue(map[string]any{"keyboardLayout": tt.layout}))
+			require.NoError(t, err)
+			p, err := bctx.NewPage()
+			require.NoError(t, err)
+			p.SetContent(`
+				<input>
+				<script>
+					var keyCodes = [];
+					document.querySelector("input").addEventListener("keydown", e => keyCodes.push(e.keyCode));
+				</script>
+			`, nil)
+			el, err := p.Query("input")
+			require.NoError(t, err)
+			p.Focus("input", nil)
+
+			p.GetKeyboard().Type(tt.text, nil)
+
+			assert.Equal(t, tt.text, el.InputValue(nil))
+			keyCodes := p.Evaluate(tb.toGojaValue(`() => keyCodes.join(",")`))
+			assert.Equal(t, tt.wantKeyCodes, tb.asGojaValue(keyCodes).Export())
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		tb := newTestBrowser(t)
+		assertExceptionContains(t, tb.runtime(), func() {
+			_, _ = tb.NewContext(tb.toGojaValue(map[string]any{"keyboardLayout": "xx"}))
+		}, `unknown keyboard layout: "xx"`)
+	})
+}
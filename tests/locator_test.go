@@ -462,3 +462,202 @@ func TestLocatorShadowDOM(t *testing.T) {
 	err = p.Click("#inner-link", opts)
 	require.NoError(t, err)
 }
+
+func TestLocatorChaining(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`
+		<ul>
+			<li><span>Apple</span><button>Buy</button></li>
+			<li><span>Banana</span></li>
+			<li><span>Cherry</span><button>Buy</button></li>
+		</ul>
+	`, nil)
+
+	items := p.Locator("li", nil)
+	require.Equal(t, 3, items.Count())
+	assert.Equal(t, "Apple", items.First().Locator("span", nil).InnerText(nil))
+	assert.Equal(t, "Banana", items.Nth(1).Locator("span", nil).InnerText(nil))
+	assert.Equal(t, "Cherry", items.Last().Locator("span", nil).InnerText(nil))
+	assert.Equal(t, 2, items.Locator("button", nil).Count())
+
+	var texts []string
+	for _, l := range items.Locator("span", nil).All() {
+		texts = append(texts, l.InnerText(nil))
+	}
+	assert.Equal(t, []string{"Apple", "Banana", "Cherry"}, texts)
+
+	banana := items.Filter(tb.toGojaValue(map[string]any{"hasText": "banana"}))
+	assert.Equal(t, 1, banana.Count())
+	assert.Equal(t, "Banana", banana.InnerText(nil))
+
+	buyable := items.Filter(tb.toGojaValue(map[string]any{"has": p.Locator("button", nil)}))
+	assert.Equal(t, 2, buyable.Count())
+	assert.Equal(t, "Cherry", buyable.Last().Locator("span", nil).InnerText(nil))
+
+	cherry := p.Locator("li", tb.toGojaValue(map[string]any{
+		"has":     p.Locator("button", nil),
+		"hasText": "cherry",
+	}))
+	assert.Equal(t, 1, cherry.Count())
+	assert.Equal(t, "Cherry", cherry.Locator("span", nil).InnerText(nil))
+
+	assert.Equal(t, 0, items.Filter(tb.toGojaValue(map[string]any{"hasText": "durian"})).Count())
+}
+
+func TestLocatorGetBy(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`
+		<h1>Sign in</h1>
+		<form>
+			<label for="user">User name</label>
+			<input id="user" placeholder="name@example.com">
+			<label>Password <input id="pass" type="password"></label>
+			<input type="checkbox" aria-label="Remember me" checked>
+			<img src="" alt="Company logo">
+			<span title="Help text">?</span>
+			<button data-testid="submit">Sign in</button>
+			<button style="display: none">Hidden</button>
+		</form>
+	`, nil)
+
+	id := func(l api.Locator) any {
+		return l.GetAttribute("id", nil).Export()
+	}
+	str := tb.toGojaValue
+	exact := tb.toGojaValue(map[string]any{"exact": true})
+
+	assert.Equal(t, "Sign in", p.GetByRole("heading", tb.toGojaValue(map[string]any{"level": 1})).InnerText(nil))
+	assert.Equal(t, "Sign in", p.GetByRole("button", tb.toGojaValue(map[string]any{"name": "sign in"})).InnerText(nil))
+	assert.Equal(t, 1, p.GetByRole("button", nil).Count(), "hidden buttons are excluded")
+	assert.Equal(t, 2, p.GetByRole("button", tb.toGojaValue(map[string]any{"includeHidden": true})).Count())
+	assert.Equal(t, 1, p.GetByRole("checkbox", tb.toGojaValue(map[string]any{
+		"name": "Remember me", "checked": true,
+	})).Count())
+
+	assert.Equal(t, "user", id(p.GetByLabel(str("User name"), nil)))
+	assert.Equal(t, "pass", id(p.GetByLabel(str("password"), nil)))
+	assert.Equal(t, 0, p.GetByLabel(str("password"), exact).Count())
+	assert.Equal(t, "user", id(p.GetByPlaceholder(str("@example.com"), nil)))
+	assert.Equal(t, 1, p.GetByAltText(str("logo"), nil).Count())
+	assert.Equal(t, "?", p.GetByTitle(str("Help text"), exact).InnerText(nil))
+	assert.Equal(t, "Sign in", p.Locator("form", nil).GetByTestID(str("submit")).InnerText(nil))
+	assert.Equal(t, 0, p.GetByTestID(str("submi")).Count(), "test IDs match exactly")
+}
+
+func TestLocatorGetByTestIDAttribute(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	bctx, err := tb.NewContext(tb.toGojaValue(map[string]any{"testIdAttribute": "data-qa"}))
+	require.NoError(t, err)
+	p, err := bctx.NewPage()
+	require.NoError(t, err)
+	p.SetContent(`<button data-testid="a">A</button><button data-qa="b">B</button>`, nil)
+
+	assert.Equal(t, 0, p.GetByTestID(tb.toGojaValue("a")).Count())
+	assert.Equal(t, "B", p.GetByTestID(tb.toGojaValue("b")).InnerText(nil))
+}
+
+func TestLocatorDragTo(t *testing.T) {
+	t.Parallel()
+
+	const html5 = `
+		<div id="source" draggable="true" style="width: 50px; height: 50px; background: red">drag</div>
+		<div id="target" style="width: 100px; height: 100px; margin-top: 50px; background: blue"></div>
+		<script>
+			const target = document.getElementById("target");
+			source.addEventListener("dragstart", e => e.dataTransfer.setData("text/plain", e.target.id));
+			target.addEventListener("dragover", e => e.preventDefault());
+			target.addEventListener("drop", e => {
+				e.preventDefault();
+				target.textContent = "dropped:" + e.dataTransfer.getData("text/plain");
+			});
+		</script>
+	`
+	// mouse moves the source with the mouse events only, like the drag
+	// and drop libraries that don't use the HTML5 drag and drop.
+	const mouse = `
+		<div id="source" style="width: 50px; height: 50px; background: red">drag</div>
+		<div id="target" style="width: 100px; height: 100px; margin-top: 50px; background: blue"></div>
+		<script>
+			let dragging = false;
+			source.addEventListener("mousedown", () => dragging = true);
+			document.addEventListener("mouseup", e => {
+				if (dragging && target.contains(document.elementFromPoint(e.clientX, e.clientY))) {
+					target.textContent = "dropped:source";
+				}
+				dragging = false;
+			});
+		</script>
+	`
+	testCases := []struct {
+		name string
+		html string
+		drag func(api.Page)
+	}{
+		{
+			name: "html5", html: html5,
+			drag: func(p api.Page) { p.Locator("#source", nil).DragTo(p.Locator("#target", nil), nil) },
+		},
+		{
+			name: "mouse", html: mouse,
+			drag: func(p api.Page) { p.Locator("#source", nil).DragTo(p.Locator("#target", nil), nil) },
+		},
+		{
+			name: "page_html5", html: html5,
+			drag: func(p api.Page) { p.DragAndDrop("#source", "#target", nil) },
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t)
+			p := tb.NewPage(nil)
+			p.SetContent(tc.html, nil)
+
+			tc.drag(p)
+			assert.Equal(t, "dropped:source", p.InnerText("#target", nil))
+		})
+	}
+}
+
+func TestLocatorScrollIntoViewIfNeeded(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`
+		<div style="height: 10000px"></div>
+		<div id="feed" style="height: 200px; overflow: auto">
+			<div style="height: 5000px"></div>
+			<button>Load more</button>
+		</div>
+		<script>
+			window.wheels = 0;
+			document.addEventListener("wheel", () => window.wheels++);
+		</script>
+	`, nil)
+
+	inView := func() bool {
+		v := p.Evaluate(tb.toGojaValue(`() => {
+			const r = document.querySelector("button").getBoundingClientRect();
+			return r.top >= 0 && r.bottom <= window.innerHeight;
+		}`))
+		return tb.asGojaBool(v)
+	}
+	require.False(t, inView())
+
+	p.Locator("button", nil).ScrollIntoViewIfNeeded(nil)
+	assert.True(t, inView())
+	wheels := p.Evaluate(tb.toGojaValue(`() => window.wheels`))
+	assert.GreaterOrEqual(t, tb.asGojaValue(wheels).ToInteger(), int64(2),
+		"must scroll the page and the feed with wheel events")
+}
@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMouseWheel(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`
+		<div style="height: 10000px"></div>
+		<script>
+			var wheels = [];
+			window.addEventListener("wheel", e => wheels.push([e.clientX, e.clientY, e.deltaX, e.deltaY]));
+		</script>
+	`, nil)
+
+	m := p.GetMouse()
+	m.Move(50, 60, nil)
+	m.Wheel(0, 100)
+	m.Wheel(0, 200)
+
+	wheels := p.Evaluate(tb.toGojaValue(`() => JSON.stringify(wheels)`))
+	assert.Equal(t, "[[50,60,0,100],[50,60,0,200]]", tb.asGojaValue(wheels).Export())
+
+	// unlike window.scrollTo, the wheel events scroll the page like a
+	// user does, which triggers the listeners of the lazy loaders.
+	_, err := p.WaitForFunction(tb.toGojaValue(`() => window.scrollY === 300`), nil)
+	require.NoError(t, err)
+}
@@ -2,17 +2,25 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image/png"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dop251/goja"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/xk6-browser/api"
 	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
 )
 
 type emulateMediaOpts struct {
@@ -373,6 +381,25 @@ func TestPageIsChecked(t *testing.T) {
 	assert.False(t, p.IsChecked("input", nil), "expected checkbox to be unchecked")
 }
 
+func TestPagePdf(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+	p.SetContent(`<h1>Invoice</h1>`, nil)
+
+	path := filepath.Join(t.TempDir(), "invoice.pdf")
+	buf := p.Pdf(tb.toGojaValue(map[string]any{
+		"format": "A4",
+		"path":   path,
+	}))
+	require.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), saved)
+}
+
 func TestPageScreenshotFullpage(t *testing.T) {
 	tb := newTestBrowser(t)
 	p := tb.NewPage(nil)
@@ -703,6 +730,357 @@ func TestPageClose(t *testing.T) {
 	})
 }
 
+func TestPageRoute(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/data", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, "original")
+		require.NoError(t, err)
+	})
+	p := tb.NewPage(nil)
+
+	var (
+		routedURL string
+		content   string
+	)
+	err := tb.vu.Loop.Start(func() error {
+		p.Route(tb.toGojaValue("**/data"), nil, func(r api.Route) error {
+			routedURL = r.Request().URL()
+			r.Fulfill(tb.toGojaValue(map[string]any{
+				"contentType": "text/plain",
+				"body":        "routed",
+			}))
+			return nil
+		})
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			if _, err := p.Goto(tb.url("/data"), nil); err != nil {
+				return nil, err
+			}
+			content = p.InnerText("body", nil)
+			return nil, p.Close(nil)
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tb.url("/data"), routedURL)
+	assert.Equal(t, "routed", content)
+}
+
+func TestPageExposeBinding(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/page", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `<iframe src="/frame"></iframe>`)
+		require.NoError(t, err)
+	})
+	tb.withHandler("/frame", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `<p>frame</p>`)
+		require.NoError(t, err)
+	})
+	p := tb.NewPage(nil)
+
+	var (
+		sum, frameSum, failure any
+		sourceURLs             []string
+	)
+	err := tb.vu.Loop.Start(func() error {
+		p.ExposeBinding("add", func(source *api.BindingSource, args ...any) (goja.Value, error) {
+			sourceURLs = append(sourceURLs, source.Frame.URL())
+			var sum float64
+			for _, a := range args {
+				sum += a.(float64) //nolint:forcetypeassert
+			}
+			return tb.toGojaValue(sum), nil
+		}, nil)
+		p.ExposeFunction("fail", func(goja.Value, ...goja.Value) (goja.Value, error) {
+			return nil, errors.New("boom")
+		})
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			// the bindings are installed in the documents
+			// of the navigation and of its frames.
+			if _, err := p.Goto(tb.url("/page"), nil); err != nil {
+				return nil, err
+			}
+			sum = p.Evaluate(tb.toGojaValue(`() => window.add(2, 3)`))
+			frames := p.Frames()
+			if len(frames) != 2 {
+				return nil, fmt.Errorf("want 2 frames; got %d", len(frames))
+			}
+			frameSum = frames[1].Evaluate(tb.toGojaValue(`() => window.add(1, 2, 3)`))
+			failure = p.Evaluate(tb.toGojaValue(`() => window.fail().catch(e => e.message)`))
+			return nil, p.Close(nil)
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, tb.asGojaValue(sum).Export())
+	assert.EqualValues(t, 6, tb.asGojaValue(frameSum).Export())
+	assert.Equal(t, []string{tb.url("/page"), tb.url("/frame")}, sourceURLs)
+	assert.Equal(t, "boom", tb.asGojaValue(failure).Export())
+
+	assert.Panics(t, func() {
+		p.ExposeFunction("add", func(goja.Value, ...goja.Value) (goja.Value, error) {
+			return nil, nil
+		})
+	}, "exposing a function with the same name twice should panic")
+}
+
+func TestPageGoBackForward(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	for _, step := range []string{"/step1", "/step2"} {
+		step := step
+		tb.withHandler(step, func(w http.ResponseWriter, _ *http.Request) {
+			_, err := fmt.Fprintf(w, `<h1>%s</h1>`, step)
+			require.NoError(t, err)
+		})
+	}
+	p := tb.NewPage(nil)
+
+	for _, step := range []string{"/step1", "/step2"} {
+		_, err := p.Goto(tb.url(step), nil)
+		require.NoError(t, err)
+	}
+
+	resp := p.GoBack(nil)
+	require.NotNil(t, resp)
+	assert.Equal(t, tb.url("/step1"), resp.URL())
+	assert.Equal(t, "/step1", p.InnerText("h1", nil))
+
+	resp = p.GoForward(tb.toGojaValue(map[string]any{"waitUntil": "domcontentloaded"}))
+	require.NotNil(t, resp)
+	assert.Equal(t, tb.url("/step2"), resp.URL())
+	assert.Equal(t, "/step2", p.InnerText("h1", nil))
+
+	// there is no next page.
+	assert.Nil(t, p.GoForward(nil))
+
+	// the navigations within the same document don't have a response.
+	p.Evaluate(tb.toGojaValue(`() => history.pushState({}, "", "#details")`))
+	assert.Nil(t, p.GoBack(nil))
+	assert.Equal(t, tb.url("/step2"), p.URL())
+}
+
+func TestPageWaitForRequestResponse(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/api", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `{"ok": true}`)
+		require.NoError(t, err)
+	})
+	p := tb.NewPage(nil)
+
+	var (
+		req  api.Request
+		resp api.Response
+	)
+	waitRequest, err := p.WaitForRequest(tb.toGojaValue("**/api"), nil)
+	require.NoError(t, err)
+	waitResponse, err := p.WaitForResponse(tb.toGojaValue("**/api"), nil)
+	require.NoError(t, err)
+	waitForRequest := func() error {
+		var err error
+		req, err = waitRequest()
+		return err
+	}
+	waitForResponse := func() error {
+		var err error
+		resp, err = waitResponse()
+		return err
+	}
+	gotoAPI := func() error {
+		_, err := p.Goto(tb.url("/api"), nil)
+		return err
+	}
+	ctx, cancel := context.WithTimeout(tb.ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, tb.run(ctx, waitForRequest, waitForResponse, gotoAPI))
+
+	require.NotNil(t, req)
+	assert.Equal(t, tb.url("/api"), req.URL())
+	require.NotNil(t, resp)
+	assert.Equal(t, tb.url("/api"), resp.URL())
+	assert.Equal(t, http.StatusOK, int(resp.Status()))
+
+	waitMissing, err := p.WaitForRequest(tb.toGojaValue("**/missing"), tb.toGojaValue(map[string]any{
+		"timeout": 100,
+	}))
+	require.NoError(t, err)
+	_, err = waitMissing()
+	require.ErrorContains(t, err, "timed out after 100ms")
+}
+
+func TestPageOn(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withFileServer())
+	p := tb.NewPage(nil)
+
+	err := p.On("unknown", nil, func(any) error { return nil })
+	require.ErrorContains(t, err, `unknown page event: "unknown"`)
+
+	var (
+		requests  []string
+		responses []string
+		closed    bool
+	)
+	err = tb.vu.Loop.Start(func() error {
+		require.NoError(t, p.On(common.EventPageRequest, nil, func(data any) error {
+			req, ok := data.(api.Request)
+			require.Truef(t, ok, "want api.Request; got %T", data)
+			requests = append(requests, req.URL())
+			return nil
+		}))
+		require.NoError(t, p.On(common.EventPageResponse, nil, func(data any) error {
+			resp, ok := data.(api.Response)
+			require.Truef(t, ok, "want api.Response; got %T", data)
+			responses = append(responses, resp.URL())
+			return nil
+		}))
+		require.NoError(t, p.On(common.EventPageClose, nil, func(any) error {
+			closed = true
+			return nil
+		}))
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			if _, err := p.Goto(tb.staticURL("empty.html"), nil); err != nil {
+				return nil, err
+			}
+			return nil, p.Close(nil)
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Contains(t, requests, tb.staticURL("empty.html"))
+	assert.Contains(t, responses, tb.staticURL("empty.html"))
+	assert.True(t, closed)
+}
+
+func TestPageOnDialog(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+
+	var (
+		dialogType    string
+		dialogMessage string
+		result        any
+	)
+	err := tb.vu.Loop.Start(func() error {
+		if err := p.On(common.EventPageDialog, nil, func(data any) error {
+			d, ok := data.(api.Dialog)
+			if !ok {
+				return fmt.Errorf("want api.Dialog; got %T", data)
+			}
+			dialogType = d.Type()
+			dialogMessage = d.Message()
+			d.Accept("")
+			result = p.Evaluate(tb.toGojaValue(`() => window.result`))
+			return p.Close(nil)
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+		// the dialog is opened by the page code, as a synchronous call
+		// that opens it blocks the event loop that the handler runs on.
+		p.Evaluate(tb.toGojaValue(`() => setTimeout(() => { window.result = confirm("Are you sure?") }, 0)`))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "confirm", dialogType)
+	assert.Equal(t, "Are you sure?", dialogMessage)
+	assert.Equal(t, true, tb.asGojaValue(result).Export())
+}
+
+func TestPageOnDialogHandlerError(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t)
+	p := tb.NewPage(nil)
+
+	err := tb.vu.Loop.Start(func() error {
+		if err := p.On(common.EventPageDialog, nil, func(any) error {
+			return errors.New("handler failed")
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+		p.Evaluate(tb.toGojaValue(`() => setTimeout(() => { window.result = confirm("Are you sure?") }, 0)`))
+		return nil
+	})
+	require.ErrorContains(t, err, "handler failed")
+
+	// the dialog is dismissed, so the page isn't blocked.
+	result := p.Evaluate(tb.toGojaValue(`() => new Promise(r => setTimeout(() => r(window.result), 0))`))
+	assert.Equal(t, false, tb.asGojaValue(result).Export())
+}
+
+func TestPageFrame(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/main", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `
+			<iframe name="checkout" src="/pay?id=123"></iframe>
+			<iframe src="/ads"></iframe>
+		`)
+		require.NoError(t, err)
+	})
+	tb.withHandler("/pay", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `<p>pay</p>`)
+		require.NoError(t, err)
+	})
+	tb.withHandler("/ads", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `<p>ads</p>`)
+		require.NoError(t, err)
+	})
+
+	p := tb.NewPage(nil)
+	_, err := p.Goto(tb.url("/main"), tb.toGojaValue(map[string]any{"waitUntil": "load"}))
+	require.NoError(t, err)
+
+	regexp, err := tb.runtime().RunString(`/\/ads$/`)
+	require.NoError(t, err)
+	predicate, err := tb.runtime().RunString(`url => url.endsWith("/ads")`)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		frameSelector goja.Value
+		wantURL       string
+	}{
+		{name: "name", frameSelector: tb.toGojaValue("checkout"), wantURL: tb.url("/pay?id=123")},
+		{name: "name_option", frameSelector: tb.toGojaValue(map[string]any{"name": "checkout"}), wantURL: tb.url("/pay?id=123")},
+		{name: "glob", frameSelector: tb.toGojaValue(map[string]any{"url": "**/pay*"}), wantURL: tb.url("/pay?id=123")},
+		{name: "regexp", frameSelector: tb.toGojaValue(map[string]any{"url": regexp}), wantURL: tb.url("/ads")},
+		{name: "predicate", frameSelector: tb.toGojaValue(map[string]any{"url": predicate}), wantURL: tb.url("/ads")},
+		{name: "main_frame", frameSelector: tb.toGojaValue(map[string]any{"url": tb.url("/main")}), wantURL: tb.url("/main")},
+		{name: "no_name", frameSelector: tb.toGojaValue("missing")},
+		{
+			name: "name_and_url",
+			frameSelector: tb.toGojaValue(map[string]any{
+				"name": "checkout",
+				"url":  "**/ads",
+			}),
+		},
+	}
+	for _, tc := range testCases {
+		f, err := p.Frame(tc.frameSelector)
+		require.NoError(t, err, tc.name)
+		if tc.wantURL == "" {
+			assert.Nil(t, f, tc.name)
+			continue
+		}
+		require.NotNil(t, f, tc.name)
+		assert.Equal(t, tc.wantURL, f.URL(), tc.name)
+	}
+
+	_, err = p.Frame(nil)
+	assert.ErrorContains(t, err, "provide a frame name or an object with a name or url property")
+}
+
 func assertExceptionContains(t *testing.T, rt *goja.Runtime, fn func(), expErrMsg string) {
 	t.Helper()
 
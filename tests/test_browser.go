@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/grafana/xk6-browser/k6ext/k6test"
 
 	k6http "go.k6.io/k6/js/modules/k6/http"
+	k6fsext "go.k6.io/k6/lib/fsext"
 	k6httpmultibin "go.k6.io/k6/lib/testutils/httpmultibin"
 	k6metrics "go.k6.io/k6/metrics"
 )
@@ -122,6 +125,12 @@ func newTestBrowserVU(tb testing.TB, tbr *testBrowser) (_ *k6test.VU, cancel fun
 	tb.Cleanup(cancel)
 	vu.CtxField = ctx
 	vu.InitEnvField.LookupEnv = tbr.lookupFunc
+	// the files are read from the OS file system, and relative
+	// to the tests directory, as if the tests were scripts in it.
+	wd, err := os.Getwd()
+	require.NoError(tb, err)
+	vu.InitEnvField.FileSystems = map[string]k6fsext.Fs{"file": k6fsext.NewOsFs()}
+	vu.InitEnvField.CWD = &url.URL{Scheme: "file", Path: filepath.ToSlash(wd) + "/"}
 
 	return vu, cancel
 }
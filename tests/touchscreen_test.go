@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+)
+
+func TestTouchscreenGestures(t *testing.T) {
+	t.Parallel()
+
+	const html = `
+		<div style="width: 500px; height: 500px"></div>
+		<script>
+			var events = [];
+			const record = e => events.push(e.type + ":" + [...e.touches].map(
+				t => Math.round(t.clientX) + "," + Math.round(t.clientY)
+			).join(";"));
+			["touchstart", "touchmove", "touchend"].forEach(
+				typ => document.addEventListener(typ, record, { passive: true }),
+			);
+		</script>
+	`
+	// The touch points of a multi-touch event are pressed, moved and
+	// released one by one, so the events are checked to include the
+	// wanted ones, and end with all the touch points released.
+	testCases := []struct {
+		name    string
+		gesture func(tb *testBrowser, ts api.Touchscreen)
+		want    []string
+	}{
+		{
+			name: "touch",
+			gesture: func(tb *testBrowser, ts api.Touchscreen) {
+				ts.TouchStart(tb.toGojaValue([]any{
+					map[string]any{"x": 10, "y": 20},
+					map[string]any{"x": 30, "y": 40},
+				}))
+				ts.TouchMove(tb.toGojaValue([]any{
+					map[string]any{"x": 15, "y": 20},
+					map[string]any{"x": 35, "y": 40},
+				}))
+				ts.TouchEnd()
+			},
+			want: []string{"touchstart:10,20;30,40", "touchmove:15,20;35,40", "touchend:"},
+		},
+		{
+			name: "swipe",
+			gesture: func(tb *testBrowser, ts api.Touchscreen) {
+				ts.Swipe(200, 100, tb.toGojaValue(map[string]any{"direction": "left", "distance": 100, "steps": 2}))
+			},
+			want: []string{"touchstart:200,100", "touchmove:150,100", "touchmove:100,100", "touchend:"},
+		},
+		{
+			name: "pinch",
+			gesture: func(tb *testBrowser, ts api.Touchscreen) {
+				ts.Pinch(200, 100, tb.toGojaValue(map[string]any{"distance": 100, "scale": 2, "steps": 1}))
+			},
+			want: []string{"touchstart:150,100;250,100", "touchmove:100,100;300,100", "touchend:"},
+		},
+		{
+			name: "long_press",
+			gesture: func(tb *testBrowser, ts api.Touchscreen) {
+				ts.LongPress(50, 60, tb.toGojaValue(map[string]any{"duration": 100}))
+			},
+			want: []string{"touchstart:50,60", "touchend:"},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tb := newTestBrowser(t)
+			bctx, err := tb.NewContext(tb.toGojaValue(map[string]any{"hasTouch": true}))
+			require.NoError(t, err)
+			p, err := bctx.NewPage()
+			require.NoError(t, err)
+			p.SetContent(html, nil)
+
+			tc.gesture(tb, p.GetTouchscreen())
+
+			var events []string
+			got := p.Evaluate(tb.toGojaValue(`() => events`))
+			require.NoError(t, tb.runtime().ExportTo(tb.asGojaValue(got), &events))
+			assert.Subset(t, events, tc.want)
+			require.NotEmpty(t, events)
+			assert.Equal(t, "touchend:", events[len(events)-1])
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k6metrics "go.k6.io/k6/metrics"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+func TestPageOnWebSocket(t *testing.T) {
+	t.Parallel()
+
+	samples := make(chan k6metrics.SampleContainer, 1000)
+	tb := newTestBrowser(t, withHTTPServer(), withSamples(samples))
+	p := tb.NewPage(nil)
+	wsURL := "ws" + strings.TrimPrefix(tb.url("/ws-echo"), "http")
+
+	var (
+		ws             api.WebSocket
+		sent, received []any
+		closed         = make(chan struct{})
+	)
+	err := tb.vu.Loop.Start(func() error {
+		require.NoError(t, p.On(common.EventPageWebSocket, nil, func(data any) error {
+			var ok bool
+			ws, ok = data.(api.WebSocket)
+			require.Truef(t, ok, "want api.WebSocket; got %T", data)
+			for event, frames := range map[string]*[]any{
+				common.EventWebSocketFrameSent:     &sent,
+				common.EventWebSocketFrameReceived: &received,
+			} {
+				frames := frames
+				err := ws.On(event, func(data any) error {
+					f, ok := data.(*api.WebSocketFrame)
+					require.Truef(t, ok, "want *api.WebSocketFrame; got %T", data)
+					*frames = append(*frames, string(f.Payload))
+					return nil
+				})
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+			}
+			return ws.On(common.EventWebSocketClose, func(any) error { //nolint:wrapcheck
+				close(closed)
+				return nil
+			})
+		}))
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			p.Evaluate(tb.toGojaValue(fmt.Sprintf(`() => new Promise(resolve => {
+				const ws = new WebSocket(%q);
+				ws.onopen = () => ws.send("hello");
+				ws.onmessage = () => ws.close();
+				ws.onclose = () => resolve();
+			})`, wsURL)))
+			select {
+			case <-closed:
+			case <-time.After(5 * time.Second):
+				return nil, errors.New("timed out waiting for the WebSocket to close")
+			}
+			return nil, p.Close(nil) //nolint:wrapcheck
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, wsURL, ws.URL())
+	assert.True(t, ws.IsClosed())
+	assert.Equal(t, []any{"hello"}, sent)
+	assert.Equal(t, []any{"hello"}, received)
+
+	metrics := make(map[string]float64)
+	for len(samples) > 0 {
+		for _, s := range (<-samples).GetSamples() {
+			if strings.HasPrefix(s.Metric.Name, "browser_ws_") {
+				metrics[s.Metric.Name] += s.Value
+			}
+		}
+	}
+	assert.Equal(t, float64(1), metrics["browser_ws_sessions"])
+	assert.Equal(t, float64(1), metrics["browser_ws_msgs_sent"])
+	assert.Equal(t, float64(1), metrics["browser_ws_msgs_received"])
+	assert.Equal(t, float64(len("hello")), metrics["browser_ws_data_sent"])
+	assert.Equal(t, float64(len("hello")), metrics["browser_ws_data_received"])
+	assert.Contains(t, metrics, "browser_ws_session_duration")
+}
+
+func TestPageOnWebSocketEarlyEvents(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	p := tb.NewPage(nil)
+	wsURL := "ws" + strings.TrimPrefix(tb.url("/ws-echo"), "http")
+
+	var (
+		sent   []any
+		closed bool
+	)
+	err := tb.vu.Loop.Start(func() error {
+		if err := p.On(common.EventPageWebSocket, nil, func(data any) error {
+			ws, ok := data.(api.WebSocket)
+			if !ok {
+				return fmt.Errorf("want api.WebSocket; got %T", data)
+			}
+			if err := ws.On(common.EventWebSocketFrameSent, func(data any) error {
+				f, ok := data.(*api.WebSocketFrame)
+				if !ok {
+					return fmt.Errorf("want *api.WebSocketFrame; got %T", data)
+				}
+				sent = append(sent, string(f.Payload))
+				return nil
+			}); err != nil {
+				return err //nolint:wrapcheck
+			}
+			return ws.On(common.EventWebSocketClose, func(any) error { //nolint:wrapcheck
+				closed = true
+				return p.Close(nil)
+			})
+		}); err != nil {
+			return err //nolint:wrapcheck
+		}
+		// the frames are sent and the WebSocket is closed before
+		// the websocket event handler of the page can run.
+		p.Evaluate(tb.toGojaValue(fmt.Sprintf(`() => {
+			const ws = new WebSocket(%q);
+			ws.onopen = () => { ws.send("a"); ws.send("b"); ws.close(); };
+		}`, wsURL)))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, sent)
+	assert.True(t, closed)
+}
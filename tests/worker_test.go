@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/xk6-browser/api"
+	"github.com/grafana/xk6-browser/common"
+	"github.com/grafana/xk6-browser/k6ext"
+)
+
+func TestWorkerEvaluate(t *testing.T) {
+	t.Parallel()
+
+	tb := newTestBrowser(t, withHTTPServer())
+	tb.withHandler("/page", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprint(w, `<script>window.worker = new Worker("/worker.js")</script>`)
+		require.NoError(t, err)
+	})
+	tb.withHandler("/worker.js", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript")
+		_, err := fmt.Fprint(w, `self.state = { crunched: 42 };`)
+		require.NoError(t, err)
+	})
+	p := tb.NewPage(nil)
+
+	var (
+		workerURL        string
+		crunched, shared any
+		closed           bool
+	)
+	err := tb.vu.Loop.Start(func() error {
+		require.NoError(t, p.On(common.EventPageWorker, nil, func(data any) error {
+			w, ok := data.(api.Worker)
+			require.Truef(t, ok, "want api.Worker; got %T", data)
+			workerURL = w.URL()
+			return w.On(common.EventWorkerClose, func(any) error { //nolint:wrapcheck
+				closed = true
+				return nil
+			})
+		}))
+		k6ext.Promise(tb.vu.Context(), func() (any, error) {
+			if _, err := p.Goto(tb.url("/page"), nil); err != nil {
+				return nil, err
+			}
+			var workers []api.Worker
+			for start := time.Now(); len(workers) == 0; workers = p.Workers() {
+				if time.Since(start) > 5*time.Second {
+					return nil, errors.New("timed out waiting for the worker")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			w := workers[0]
+			crunched = w.Evaluate(tb.toGojaValue(`() => self.state.crunched`))
+			state, err := w.EvaluateHandle(tb.toGojaValue(`() => self.state`))
+			if err != nil {
+				return nil, err
+			}
+			// the handle can be passed back to the worker.
+			shared = w.Evaluate(tb.toGojaValue(`state => state.crunched + 1`), tb.toGojaValue(state))
+
+			p.Evaluate(tb.toGojaValue(`() => window.worker.terminate()`))
+			for start := time.Now(); len(p.Workers()) > 0; {
+				if time.Since(start) > 5*time.Second {
+					return nil, errors.New("timed out waiting for the worker to close")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			return nil, p.Close(nil)
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, tb.url("/worker.js"), workerURL)
+	assert.EqualValues(t, 42, tb.asGojaValue(crunched).Export())
+	assert.EqualValues(t, 43, tb.asGojaValue(shared).Export())
+	assert.True(t, closed)
+}